@@ -6,16 +6,18 @@ package cargo
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/opensbom-generator/parsers/internal/helper"
 	"github.com/opensbom-generator/parsers/meta"
 	toml "github.com/pelletier/go-toml/v2"
 	"github.com/sirupsen/logrus"
-	"sigs.k8s.io/release-utils/command"
 )
 
 const (
@@ -26,22 +28,24 @@ const (
 
 	tomlFileName = "Cargo.toml"
 	lockFileName = "Cargo.lock"
+
+	cratesIOSource = "registry+https://github.com/rust-lang/crates.io-index"
 )
 
 //counterfeiter:generate . cargoImplementation
 
 // cargoImplementation implemnents the functionality to read cargo files and run the required commands
 type cargoImplementation interface {
-	GetCargoMetadata(string) (Metadata, error)
-	GetCargoMetadataIfNeeded(*Mod, string) (*Metadata, error)
-	ConvertPackagesToModulesList([]*Package) (map[string]*meta.Package, error)
-	ConvertCargoPackageToMetaPackage(*Package) meta.Package
+	GetCargoMetadata(context.Context, string) (Metadata, error)
+	GetCargoMetadataIfNeeded(context.Context, *Mod, string) (*Metadata, error)
+	ConvertPackagesToModulesList(context.Context, []*Package) (map[string]*meta.Package, error)
+	ConvertCargoPackageToMetaPackage(context.Context, *Package) meta.Package
 	ReadLockFile(string) (*LockFile, error)
 	ReadConfig(string) (*Config, error)
 	GetRootProjectName(string) (string, error)
 	GetPackageDependencies(*Metadata, string) ([]*Package, error)
-	GetRootModule(*Metadata, string) (meta.Package, error)
-	PopulateDependencies(*Metadata, *meta.Package, bool, *map[string]*meta.Package) error
+	GetRootModule(context.Context, *Metadata, string) (meta.Package, error)
+	PopulateDependencies(context.Context, *Metadata, *meta.Package, bool, *map[string]*meta.Package) error
 }
 
 type defaultImplementation struct{}
@@ -133,23 +137,29 @@ func (di *defaultImplementation) ReadConfig(path string) (*Config, error) {
 	return conf, nil
 }
 
-func (di *defaultImplementation) GetCargoMetadata(path string) (Metadata, error) {
+func (di *defaultImplementation) GetCargoMetadata(ctx context.Context, path string) (Metadata, error) {
 	// to be assembled from the output of:
 	// rustc --print cfg
 	// using target_arch target_vendor target_os target_env
 	var cargoMetadata Metadata
-	logrus.Infof("running cargo --metadata")
+	logrus.Debugf("running cargo --metadata")
 	cmdArgs := []string{
 		"metadata",
 		"--filter-platform=x86_64-unknown-linux-gnu", // TODO: Detect effective platform or option
 	}
-	output, err := command.NewWithWorkDir(
-		path, string(Cmd), cmdArgs...).RunSilentSuccessOutput()
+	if helper.IsOffline(ctx) {
+		cmdArgs = append(cmdArgs, "--offline")
+	}
+	if helper.IsReadOnly(ctx) {
+		// cargo fails rather than creating or updating Cargo.lock
+		cmdArgs = append(cmdArgs, "--locked")
+	}
+	output, err := runCargo(ctx, path, cmdArgs...)
 	if err != nil {
 		return cargoMetadata, fmt.Errorf("running cargo metadata: %w", err)
 	}
 
-	if err := json.Unmarshal([]byte(output.Output()), &cargoMetadata); err != nil {
+	if err := json.Unmarshal(output, &cargoMetadata); err != nil {
 		return cargoMetadata, fmt.Errorf("decoding cargo metadata: %w", err)
 	}
 
@@ -171,10 +181,25 @@ func (di *defaultImplementation) GetCargoMetadata(path string) (Metadata, error)
 		}
 	}
 
-	logrus.Infof("Got data describing %d packages", len(cargoMetadata.Packages))
+	logrus.Debugf("Got data describing %d packages", len(cargoMetadata.Packages))
 	return cargoMetadata, nil
 }
 
+// runCargo runs cargo in the directory and returns its standard output.
+// The process is killed when ctx is done.
+func runCargo(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	result, err := helper.Run(ctx, helper.Command{Name: Cmd, Args: args, Dir: dir})
+	if err != nil {
+		var exitErr *helper.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, err
+	}
+
+	return result.Stdout, nil
+}
+
 func (di *defaultImplementation) GetRootProjectName(path string) (string, error) {
 	data, err := di.ReadConfig(filepath.Join(path, tomlFileName))
 	if err != nil {
@@ -185,10 +210,10 @@ func (di *defaultImplementation) GetRootProjectName(path string) (string, error)
 
 // ConvertMetadataToModulesList gets a list of cargo metadata packages
 // and converts it to our own metapackage
-func (di *defaultImplementation) ConvertPackagesToModulesList(cargoPackages []*Package) (map[string]*meta.Package, error) {
+func (di *defaultImplementation) ConvertPackagesToModulesList(ctx context.Context, cargoPackages []*Package) (map[string]*meta.Package, error) {
 	collection := map[string]*meta.Package{}
 	for _, dep := range cargoPackages {
-		module := di.ConvertCargoPackageToMetaPackage(dep)
+		module := di.ConvertCargoPackageToMetaPackage(ctx, dep)
 		// Why this?! Is download location so important?
 		if module.Name == "" || module.PackageDownloadLocation == "" {
 			return nil, fmt.Errorf("incomplete information when converting package")
@@ -200,25 +225,21 @@ func (di *defaultImplementation) ConvertPackagesToModulesList(cargoPackages []*P
 
 // ConvertCargoPackageToModule converts a cargo metadata
 // package to a meta.Package
-func (di *defaultImplementation) ConvertCargoPackageToMetaPackage(dep *Package) meta.Package {
+func (di *defaultImplementation) ConvertCargoPackageToMetaPackage(ctx context.Context, dep *Package) meta.Package {
 	localPath := convertToLocalPath(dep.ManifestPath)
 	supplier := getPackageSupplier(dep.Authors, dep.Name)
 
 	// We know where to get crates packages
 	downloadURL := ""
-	if dep.Source == "registry+https://github.com/rust-lang/crates.io-index" {
+	if dep.Source == cratesIOSource {
 		downloadURL = fmt.Sprintf("https://crates.io/api/v1/crates/%s/%s/download", dep.Name, dep.Version)
 	}
 
 	module := meta.Package{
-		Version:    dep.Version,
-		Name:       dep.Name,
-		Root:       false,
-		PackageURL: formatPackageURL(*dep),
-		Checksum: meta.Checksum{
-			Algorithm: meta.HashAlgoSHA256,
-			Value:     dep.Checksum,
-		},
+		Version:                 dep.Version,
+		Name:                    dep.Name,
+		Root:                    false,
+		PackageURL:              formatPackageURL(*dep),
 		LocalPath:               localPath,
 		PackageHomePage:         dep.Homepage,
 		Supplier:                supplier,
@@ -226,17 +247,14 @@ func (di *defaultImplementation) ConvertCargoPackageToMetaPackage(dep *Package)
 		Packages:                map[string]*meta.Package{},
 	}
 
-	licensePkg, err := helper.GetLicenses(localPath)
-	if err == nil {
-		module.LicenseDeclared = helper.BuildLicenseDeclared(licensePkg.ID)
-		module.LicenseConcluded = helper.BuildLicenseConcluded(licensePkg.ID)
-		module.Copyright = helper.GetCopyright(licensePkg.ExtractedText)
-		module.CommentsLicense = licensePkg.Comments
-	} else if dep.License != "" {
-		module.LicenseDeclared = dep.License
-		module.LicenseConcluded = dep.License
+	// Cargo.lock records the SHA256 of the crates downloaded from a registry
+	if c, err := meta.NewChecksum(meta.HashAlgoSHA256, dep.Checksum); err == nil {
+		module.AddChecksum(c)
 	}
 
+	helper.SetLicenses(ctx, &module, dep.License, localPath)
+	module.Copyright = helper.ScanCopyright(localPath)
+
 	return module
 }
 
@@ -261,12 +279,12 @@ func (di *defaultImplementation) GetPackageDependencies(md *Metadata, rootName s
 }
 
 // getCargoMetadataIfNeeded checks if we need to load metadata or not
-func (di *defaultImplementation) GetCargoMetadataIfNeeded(m *Mod, path string) (*Metadata, error) {
+func (di *defaultImplementation) GetCargoMetadataIfNeeded(ctx context.Context, m *Mod, path string) (*Metadata, error) {
 	if m.cargoMetadata != nil {
 		return m.cargoMetadata, nil
 	}
 
-	newMd, err := di.GetCargoMetadata(path)
+	newMd, err := di.GetCargoMetadata(ctx, path)
 	if err != nil {
 		return nil, err
 	}
@@ -278,7 +296,7 @@ func (di *defaultImplementation) GetCargoMetadataIfNeeded(m *Mod, path string) (
 
 // populateDependencies
 func (di *defaultImplementation) PopulateDependencies(
-	md *Metadata, metaPackage *meta.Package, recurse bool, seen *map[string]*meta.Package,
+	ctx context.Context, md *Metadata, metaPackage *meta.Package, recurse bool, seen *map[string]*meta.Package,
 ) error {
 	if seen == nil {
 		seen = &map[string]*meta.Package{}
@@ -289,16 +307,18 @@ func (di *defaultImplementation) PopulateDependencies(
 	}
 
 	// Convert packages to metapackages
-	metaPackages, err := di.ConvertPackagesToModulesList(packages)
+	metaPackages, err := di.ConvertPackagesToModulesList(ctx, packages)
 	if err != nil {
 		return fmt.Errorf("converting cargo packages: %w", err)
 	}
 	if len(metaPackages) != len(packages) {
-		logrus.Warnf(
+		helper.Report(
+			ctx, meta.SeverityError, meta.CodeMissingDependency, metaPackage.Name,
 			"Number of converted metapackages don't match cargo packages (%d vs %d)",
 			len(packages), len(metaPackages),
 		)
 	}
+	setDependencyScopes(md, metaPackage.Name, metaPackages)
 
 	if !recurse {
 		metaPackage.Packages = metaPackages
@@ -310,7 +330,7 @@ func (di *defaultImplementation) PopulateDependencies(
 	// get deps of deps
 	for _, ptr := range metaPackages {
 		if _, ok := (*seen)[ptr.Name+":"+ptr.Version]; !ok {
-			if err := di.PopulateDependencies(md, ptr, true, seen); err != nil {
+			if err := di.PopulateDependencies(ctx, md, ptr, true, seen); err != nil {
 				return fmt.Errorf("getting dependencies of %s: %w", ptr.Name, err)
 			}
 		} else {
@@ -323,14 +343,31 @@ func (di *defaultImplementation) PopulateDependencies(
 	return nil
 }
 
-func (di *defaultImplementation) GetRootModule(md *Metadata, path string) (meta.Package, error) {
+// setDependencyScopes sets the scope of the dependencies of the named
+// package. A crate listed under several kinds keeps the runtime one.
+func setDependencyScopes(md *Metadata, name string, deps map[string]*meta.Package) {
+	pkg := md.GetPackageByName(name)
+	if pkg == nil {
+		return
+	}
+
+	for i := range pkg.Dependencies {
+		dep, ok := deps[pkg.Dependencies[i].Name]
+		if !ok || dep.Scope == meta.ScopeRuntime {
+			continue
+		}
+		dep.Scope = pkg.Dependencies[i].Scope()
+	}
+}
+
+func (di *defaultImplementation) GetRootModule(ctx context.Context, md *Metadata, path string) (meta.Package, error) {
 	name, err := di.GetRootProjectName(path)
 	if err != nil {
 		return meta.Package{}, err
 	}
 
 	rootPackage := md.GetPackageByName(name)
-	mod := convertCargoPackageToRootModule(*rootPackage)
+	mod := convertCargoPackageToRootModule(ctx, *rootPackage)
 
 	return mod, nil
 }
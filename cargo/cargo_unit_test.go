@@ -4,9 +4,14 @@
 package cargo
 
 import (
+	"context"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/opensbom-generator/parsers/internal/helper"
+	"github.com/opensbom-generator/parsers/meta"
+	"github.com/opensbom-generator/parsers/plugin"
 	"github.com/stretchr/testify/require"
 )
 
@@ -43,13 +48,46 @@ func TestReadConfigFile(t *testing.T) {
 
 func TestGetCargoMetadata(t *testing.T) {
 	sut := defaultImplementation{}
-	data, err := sut.GetCargoMetadata("testdata")
+	data, err := sut.GetCargoMetadata(context.Background(), "testdata")
 	require.NoError(t, err)
 	require.NotNil(t, data)
 	require.Len(t, data.Packages, 56)
 	require.True(t, strings.HasSuffix(data.WorkspaceRoot, "cargo/testdata"))
 }
 
+func TestGetCargoMetadataReplay(t *testing.T) {
+	root, err := filepath.Abs("testdata")
+	require.NoError(t, err)
+	ctx := helper.WithRunner(context.Background(), &helper.Replayer{Dir: "testdata/fixtures", Root: root})
+
+	sut := defaultImplementation{}
+	data, err := sut.GetCargoMetadata(ctx, root)
+	require.NoError(t, err)
+	require.Len(t, data.Packages, 56)
+	require.Equal(t, root, data.WorkspaceRoot)
+}
+
+func TestListModulesWithDepsReplay(t *testing.T) {
+	root, err := filepath.Abs("testdata")
+	require.NoError(t, err)
+
+	mod := New()
+	mod.SetOptions(plugin.Options{Runner: &plugin.Replayer{Dir: "testdata/fixtures", Root: root}})
+	version, err := mod.GetVersion()
+	require.NoError(t, err)
+	require.Equal(t, "1.62.0 (a748cf5a3 2022-06-08)", version)
+
+	mods, err := mod.ListModulesWithDeps(root, "")
+	require.NoError(t, err)
+	require.NotEmpty(t, mods)
+	names := map[string]bool{}
+	for i := range mods {
+		names[mods[i].Name] = true
+	}
+	require.True(t, names["hyper"])
+	require.True(t, names["tokio"])
+}
+
 func TestGetRootProjectName(t *testing.T) {
 	sut := defaultImplementation{}
 	name, err := sut.GetRootProjectName("testdata")
@@ -73,10 +111,51 @@ func TestConvertCargoPackageToModule(t *testing.T) {
 		Homepage:     "https://github.com/BurntSushi/aho-corasick",
 		License:      "Unlicense/MIT",
 	}
-	metaPackage := sut.ConvertCargoPackageToMetaPackage(cargoPackage)
+	metaPackage := sut.ConvertCargoPackageToMetaPackage(context.Background(), cargoPackage)
 	require.Equal(t, cargoPackage.Name, metaPackage.Name)
 	require.Equal(t, cargoPackage.Version, metaPackage.Version)
 	require.Equal(t, "John Doe", metaPackage.Supplier.Name)
 	require.Equal(t, "johndow@example.com", metaPackage.Supplier.Email)
-	require.Equal(t, "Unlicense/MIT", metaPackage.LicenseDeclared)
+	require.Equal(t, "Unlicense OR MIT", metaPackage.LicenseDeclared)
+	require.Equal(t, "pkg:cargo/aho-corasick@0.7.18", metaPackage.PackageURL)
+}
+
+func TestFormatPackageURL(t *testing.T) {
+	require.Equal(t, "pkg:cargo/rand@0.8.5", formatPackageURL(Package{
+		Name: "rand", Version: "0.8.5", Source: cratesIOSource,
+	}))
+	require.Equal(t,
+		"pkg:cargo/rand@0.8.5?repository_url=https%3A%2F%2Fexample.com%2Findex",
+		formatPackageURL(Package{
+			Name: "rand", Version: "0.8.5", Source: "registry+https://example.com/index",
+		}),
+	)
+}
+
+func TestSetDependencyScopes(t *testing.T) {
+	md := &Metadata{Packages: []Package{{
+		Name: "root",
+		Dependencies: []PackageDependency{
+			{Name: "serde"},
+			{Name: "criterion", Kind: "dev"},
+			{Name: "cc", Kind: "build"},
+			{Name: "log", Optional: true},
+			{Name: "rand", Kind: "dev"},
+			{Name: "rand"},
+		},
+	}}}
+	deps := map[string]*meta.Package{
+		"serde":     {Name: "serde"},
+		"criterion": {Name: "criterion"},
+		"cc":        {Name: "cc"},
+		"log":       {Name: "log"},
+		"rand":      {Name: "rand"},
+	}
+
+	setDependencyScopes(md, "root", deps)
+	require.Equal(t, meta.ScopeRuntime, deps["serde"].Scope)
+	require.Equal(t, meta.ScopeDevelopment, deps["criterion"].Scope)
+	require.Equal(t, meta.ScopeBuild, deps["cc"].Scope)
+	require.Equal(t, meta.ScopeOptional, deps["log"].Scope)
+	require.Equal(t, meta.ScopeRuntime, deps["rand"].Scope)
 }
@@ -2,6 +2,7 @@
 package cargofakes
 
 import (
+	"context"
 	"sync"
 
 	"github.com/opensbom-generator/parsers/cargo"
@@ -9,10 +10,11 @@ import (
 )
 
 type FakeCargoImplementation struct {
-	ConvertCargoPackageToMetaPackageStub        func(*cargo.Package) meta.Package
+	ConvertCargoPackageToMetaPackageStub        func(context.Context, *cargo.Package) meta.Package
 	convertCargoPackageToMetaPackageMutex       sync.RWMutex
 	convertCargoPackageToMetaPackageArgsForCall []struct {
-		arg1 *cargo.Package
+		arg1 context.Context
+		arg2 *cargo.Package
 	}
 	convertCargoPackageToMetaPackageReturns struct {
 		result1 meta.Package
@@ -20,10 +22,11 @@ type FakeCargoImplementation struct {
 	convertCargoPackageToMetaPackageReturnsOnCall map[int]struct {
 		result1 meta.Package
 	}
-	ConvertPackagesToModulesListStub        func([]*cargo.Package) (map[string]*meta.Package, error)
+	ConvertPackagesToModulesListStub        func(context.Context, []*cargo.Package) (map[string]*meta.Package, error)
 	convertPackagesToModulesListMutex       sync.RWMutex
 	convertPackagesToModulesListArgsForCall []struct {
-		arg1 []*cargo.Package
+		arg1 context.Context
+		arg2 []*cargo.Package
 	}
 	convertPackagesToModulesListReturns struct {
 		result1 map[string]*meta.Package
@@ -33,10 +36,11 @@ type FakeCargoImplementation struct {
 		result1 map[string]*meta.Package
 		result2 error
 	}
-	GetCargoMetadataStub        func(string) (cargo.Metadata, error)
+	GetCargoMetadataStub        func(context.Context, string) (cargo.Metadata, error)
 	getCargoMetadataMutex       sync.RWMutex
 	getCargoMetadataArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
+		arg2 string
 	}
 	getCargoMetadataReturns struct {
 		result1 cargo.Metadata
@@ -46,11 +50,12 @@ type FakeCargoImplementation struct {
 		result1 cargo.Metadata
 		result2 error
 	}
-	GetCargoMetadataIfNeededStub        func(*cargo.Mod, string) (*cargo.Metadata, error)
+	GetCargoMetadataIfNeededStub        func(context.Context, *cargo.Mod, string) (*cargo.Metadata, error)
 	getCargoMetadataIfNeededMutex       sync.RWMutex
 	getCargoMetadataIfNeededArgsForCall []struct {
-		arg1 *cargo.Mod
-		arg2 string
+		arg1 context.Context
+		arg2 *cargo.Mod
+		arg3 string
 	}
 	getCargoMetadataIfNeededReturns struct {
 		result1 *cargo.Metadata
@@ -74,11 +79,12 @@ type FakeCargoImplementation struct {
 		result1 []*cargo.Package
 		result2 error
 	}
-	GetRootModuleStub        func(*cargo.Metadata, string) (meta.Package, error)
+	GetRootModuleStub        func(context.Context, *cargo.Metadata, string) (meta.Package, error)
 	getRootModuleMutex       sync.RWMutex
 	getRootModuleArgsForCall []struct {
-		arg1 *cargo.Metadata
-		arg2 string
+		arg1 context.Context
+		arg2 *cargo.Metadata
+		arg3 string
 	}
 	getRootModuleReturns struct {
 		result1 meta.Package
@@ -101,13 +107,14 @@ type FakeCargoImplementation struct {
 		result1 string
 		result2 error
 	}
-	PopulateDependenciesStub        func(*cargo.Metadata, *meta.Package, bool, *map[string]*meta.Package) error
+	PopulateDependenciesStub        func(context.Context, *cargo.Metadata, *meta.Package, bool, *map[string]*meta.Package) error
 	populateDependenciesMutex       sync.RWMutex
 	populateDependenciesArgsForCall []struct {
-		arg1 *cargo.Metadata
-		arg2 *meta.Package
-		arg3 bool
-		arg4 *map[string]*meta.Package
+		arg1 context.Context
+		arg2 *cargo.Metadata
+		arg3 *meta.Package
+		arg4 bool
+		arg5 *map[string]*meta.Package
 	}
 	populateDependenciesReturns struct {
 		result1 error
@@ -145,18 +152,19 @@ type FakeCargoImplementation struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeCargoImplementation) ConvertCargoPackageToMetaPackage(arg1 *cargo.Package) meta.Package {
+func (fake *FakeCargoImplementation) ConvertCargoPackageToMetaPackage(arg1 context.Context, arg2 *cargo.Package) meta.Package {
 	fake.convertCargoPackageToMetaPackageMutex.Lock()
 	ret, specificReturn := fake.convertCargoPackageToMetaPackageReturnsOnCall[len(fake.convertCargoPackageToMetaPackageArgsForCall)]
 	fake.convertCargoPackageToMetaPackageArgsForCall = append(fake.convertCargoPackageToMetaPackageArgsForCall, struct {
-		arg1 *cargo.Package
-	}{arg1})
+		arg1 context.Context
+		arg2 *cargo.Package
+	}{arg1, arg2})
 	stub := fake.ConvertCargoPackageToMetaPackageStub
 	fakeReturns := fake.convertCargoPackageToMetaPackageReturns
-	fake.recordInvocation("ConvertCargoPackageToMetaPackage", []interface{}{arg1})
+	fake.recordInvocation("ConvertCargoPackageToMetaPackage", []interface{}{arg1, arg2})
 	fake.convertCargoPackageToMetaPackageMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -170,17 +178,17 @@ func (fake *FakeCargoImplementation) ConvertCargoPackageToMetaPackageCallCount()
 	return len(fake.convertCargoPackageToMetaPackageArgsForCall)
 }
 
-func (fake *FakeCargoImplementation) ConvertCargoPackageToMetaPackageCalls(stub func(*cargo.Package) meta.Package) {
+func (fake *FakeCargoImplementation) ConvertCargoPackageToMetaPackageCalls(stub func(context.Context, *cargo.Package) meta.Package) {
 	fake.convertCargoPackageToMetaPackageMutex.Lock()
 	defer fake.convertCargoPackageToMetaPackageMutex.Unlock()
 	fake.ConvertCargoPackageToMetaPackageStub = stub
 }
 
-func (fake *FakeCargoImplementation) ConvertCargoPackageToMetaPackageArgsForCall(i int) *cargo.Package {
+func (fake *FakeCargoImplementation) ConvertCargoPackageToMetaPackageArgsForCall(i int) (context.Context, *cargo.Package) {
 	fake.convertCargoPackageToMetaPackageMutex.RLock()
 	defer fake.convertCargoPackageToMetaPackageMutex.RUnlock()
 	argsForCall := fake.convertCargoPackageToMetaPackageArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeCargoImplementation) ConvertCargoPackageToMetaPackageReturns(result1 meta.Package) {
@@ -206,23 +214,24 @@ func (fake *FakeCargoImplementation) ConvertCargoPackageToMetaPackageReturnsOnCa
 	}{result1}
 }
 
-func (fake *FakeCargoImplementation) ConvertPackagesToModulesList(arg1 []*cargo.Package) (map[string]*meta.Package, error) {
-	var arg1Copy []*cargo.Package
-	if arg1 != nil {
-		arg1Copy = make([]*cargo.Package, len(arg1))
-		copy(arg1Copy, arg1)
+func (fake *FakeCargoImplementation) ConvertPackagesToModulesList(arg1 context.Context, arg2 []*cargo.Package) (map[string]*meta.Package, error) {
+	var arg2Copy []*cargo.Package
+	if arg2 != nil {
+		arg2Copy = make([]*cargo.Package, len(arg2))
+		copy(arg2Copy, arg2)
 	}
 	fake.convertPackagesToModulesListMutex.Lock()
 	ret, specificReturn := fake.convertPackagesToModulesListReturnsOnCall[len(fake.convertPackagesToModulesListArgsForCall)]
 	fake.convertPackagesToModulesListArgsForCall = append(fake.convertPackagesToModulesListArgsForCall, struct {
-		arg1 []*cargo.Package
-	}{arg1Copy})
+		arg1 context.Context
+		arg2 []*cargo.Package
+	}{arg1, arg2Copy})
 	stub := fake.ConvertPackagesToModulesListStub
 	fakeReturns := fake.convertPackagesToModulesListReturns
-	fake.recordInvocation("ConvertPackagesToModulesList", []interface{}{arg1Copy})
+	fake.recordInvocation("ConvertPackagesToModulesList", []interface{}{arg1, arg2Copy})
 	fake.convertPackagesToModulesListMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -236,17 +245,17 @@ func (fake *FakeCargoImplementation) ConvertPackagesToModulesListCallCount() int
 	return len(fake.convertPackagesToModulesListArgsForCall)
 }
 
-func (fake *FakeCargoImplementation) ConvertPackagesToModulesListCalls(stub func([]*cargo.Package) (map[string]*meta.Package, error)) {
+func (fake *FakeCargoImplementation) ConvertPackagesToModulesListCalls(stub func(context.Context, []*cargo.Package) (map[string]*meta.Package, error)) {
 	fake.convertPackagesToModulesListMutex.Lock()
 	defer fake.convertPackagesToModulesListMutex.Unlock()
 	fake.ConvertPackagesToModulesListStub = stub
 }
 
-func (fake *FakeCargoImplementation) ConvertPackagesToModulesListArgsForCall(i int) []*cargo.Package {
+func (fake *FakeCargoImplementation) ConvertPackagesToModulesListArgsForCall(i int) (context.Context, []*cargo.Package) {
 	fake.convertPackagesToModulesListMutex.RLock()
 	defer fake.convertPackagesToModulesListMutex.RUnlock()
 	argsForCall := fake.convertPackagesToModulesListArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeCargoImplementation) ConvertPackagesToModulesListReturns(result1 map[string]*meta.Package, result2 error) {
@@ -275,18 +284,19 @@ func (fake *FakeCargoImplementation) ConvertPackagesToModulesListReturnsOnCall(i
 	}{result1, result2}
 }
 
-func (fake *FakeCargoImplementation) GetCargoMetadata(arg1 string) (cargo.Metadata, error) {
+func (fake *FakeCargoImplementation) GetCargoMetadata(arg1 context.Context, arg2 string) (cargo.Metadata, error) {
 	fake.getCargoMetadataMutex.Lock()
 	ret, specificReturn := fake.getCargoMetadataReturnsOnCall[len(fake.getCargoMetadataArgsForCall)]
 	fake.getCargoMetadataArgsForCall = append(fake.getCargoMetadataArgsForCall, struct {
-		arg1 string
-	}{arg1})
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
 	stub := fake.GetCargoMetadataStub
 	fakeReturns := fake.getCargoMetadataReturns
-	fake.recordInvocation("GetCargoMetadata", []interface{}{arg1})
+	fake.recordInvocation("GetCargoMetadata", []interface{}{arg1, arg2})
 	fake.getCargoMetadataMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -300,17 +310,17 @@ func (fake *FakeCargoImplementation) GetCargoMetadataCallCount() int {
 	return len(fake.getCargoMetadataArgsForCall)
 }
 
-func (fake *FakeCargoImplementation) GetCargoMetadataCalls(stub func(string) (cargo.Metadata, error)) {
+func (fake *FakeCargoImplementation) GetCargoMetadataCalls(stub func(context.Context, string) (cargo.Metadata, error)) {
 	fake.getCargoMetadataMutex.Lock()
 	defer fake.getCargoMetadataMutex.Unlock()
 	fake.GetCargoMetadataStub = stub
 }
 
-func (fake *FakeCargoImplementation) GetCargoMetadataArgsForCall(i int) string {
+func (fake *FakeCargoImplementation) GetCargoMetadataArgsForCall(i int) (context.Context, string) {
 	fake.getCargoMetadataMutex.RLock()
 	defer fake.getCargoMetadataMutex.RUnlock()
 	argsForCall := fake.getCargoMetadataArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeCargoImplementation) GetCargoMetadataReturns(result1 cargo.Metadata, result2 error) {
@@ -339,19 +349,20 @@ func (fake *FakeCargoImplementation) GetCargoMetadataReturnsOnCall(i int, result
 	}{result1, result2}
 }
 
-func (fake *FakeCargoImplementation) GetCargoMetadataIfNeeded(arg1 *cargo.Mod, arg2 string) (*cargo.Metadata, error) {
+func (fake *FakeCargoImplementation) GetCargoMetadataIfNeeded(arg1 context.Context, arg2 *cargo.Mod, arg3 string) (*cargo.Metadata, error) {
 	fake.getCargoMetadataIfNeededMutex.Lock()
 	ret, specificReturn := fake.getCargoMetadataIfNeededReturnsOnCall[len(fake.getCargoMetadataIfNeededArgsForCall)]
 	fake.getCargoMetadataIfNeededArgsForCall = append(fake.getCargoMetadataIfNeededArgsForCall, struct {
-		arg1 *cargo.Mod
-		arg2 string
-	}{arg1, arg2})
+		arg1 context.Context
+		arg2 *cargo.Mod
+		arg3 string
+	}{arg1, arg2, arg3})
 	stub := fake.GetCargoMetadataIfNeededStub
 	fakeReturns := fake.getCargoMetadataIfNeededReturns
-	fake.recordInvocation("GetCargoMetadataIfNeeded", []interface{}{arg1, arg2})
+	fake.recordInvocation("GetCargoMetadataIfNeeded", []interface{}{arg1, arg2, arg3})
 	fake.getCargoMetadataIfNeededMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -365,17 +376,17 @@ func (fake *FakeCargoImplementation) GetCargoMetadataIfNeededCallCount() int {
 	return len(fake.getCargoMetadataIfNeededArgsForCall)
 }
 
-func (fake *FakeCargoImplementation) GetCargoMetadataIfNeededCalls(stub func(*cargo.Mod, string) (*cargo.Metadata, error)) {
+func (fake *FakeCargoImplementation) GetCargoMetadataIfNeededCalls(stub func(context.Context, *cargo.Mod, string) (*cargo.Metadata, error)) {
 	fake.getCargoMetadataIfNeededMutex.Lock()
 	defer fake.getCargoMetadataIfNeededMutex.Unlock()
 	fake.GetCargoMetadataIfNeededStub = stub
 }
 
-func (fake *FakeCargoImplementation) GetCargoMetadataIfNeededArgsForCall(i int) (*cargo.Mod, string) {
+func (fake *FakeCargoImplementation) GetCargoMetadataIfNeededArgsForCall(i int) (context.Context, *cargo.Mod, string) {
 	fake.getCargoMetadataIfNeededMutex.RLock()
 	defer fake.getCargoMetadataIfNeededMutex.RUnlock()
 	argsForCall := fake.getCargoMetadataIfNeededArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeCargoImplementation) GetCargoMetadataIfNeededReturns(result1 *cargo.Metadata, result2 error) {
@@ -469,19 +480,20 @@ func (fake *FakeCargoImplementation) GetPackageDependenciesReturnsOnCall(i int,
 	}{result1, result2}
 }
 
-func (fake *FakeCargoImplementation) GetRootModule(arg1 *cargo.Metadata, arg2 string) (meta.Package, error) {
+func (fake *FakeCargoImplementation) GetRootModule(arg1 context.Context, arg2 *cargo.Metadata, arg3 string) (meta.Package, error) {
 	fake.getRootModuleMutex.Lock()
 	ret, specificReturn := fake.getRootModuleReturnsOnCall[len(fake.getRootModuleArgsForCall)]
 	fake.getRootModuleArgsForCall = append(fake.getRootModuleArgsForCall, struct {
-		arg1 *cargo.Metadata
-		arg2 string
-	}{arg1, arg2})
+		arg1 context.Context
+		arg2 *cargo.Metadata
+		arg3 string
+	}{arg1, arg2, arg3})
 	stub := fake.GetRootModuleStub
 	fakeReturns := fake.getRootModuleReturns
-	fake.recordInvocation("GetRootModule", []interface{}{arg1, arg2})
+	fake.recordInvocation("GetRootModule", []interface{}{arg1, arg2, arg3})
 	fake.getRootModuleMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -495,17 +507,17 @@ func (fake *FakeCargoImplementation) GetRootModuleCallCount() int {
 	return len(fake.getRootModuleArgsForCall)
 }
 
-func (fake *FakeCargoImplementation) GetRootModuleCalls(stub func(*cargo.Metadata, string) (meta.Package, error)) {
+func (fake *FakeCargoImplementation) GetRootModuleCalls(stub func(context.Context, *cargo.Metadata, string) (meta.Package, error)) {
 	fake.getRootModuleMutex.Lock()
 	defer fake.getRootModuleMutex.Unlock()
 	fake.GetRootModuleStub = stub
 }
 
-func (fake *FakeCargoImplementation) GetRootModuleArgsForCall(i int) (*cargo.Metadata, string) {
+func (fake *FakeCargoImplementation) GetRootModuleArgsForCall(i int) (context.Context, *cargo.Metadata, string) {
 	fake.getRootModuleMutex.RLock()
 	defer fake.getRootModuleMutex.RUnlock()
 	argsForCall := fake.getRootModuleArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeCargoImplementation) GetRootModuleReturns(result1 meta.Package, result2 error) {
@@ -598,21 +610,22 @@ func (fake *FakeCargoImplementation) GetRootProjectNameReturnsOnCall(i int, resu
 	}{result1, result2}
 }
 
-func (fake *FakeCargoImplementation) PopulateDependencies(arg1 *cargo.Metadata, arg2 *meta.Package, arg3 bool, arg4 *map[string]*meta.Package) error {
+func (fake *FakeCargoImplementation) PopulateDependencies(arg1 context.Context, arg2 *cargo.Metadata, arg3 *meta.Package, arg4 bool, arg5 *map[string]*meta.Package) error {
 	fake.populateDependenciesMutex.Lock()
 	ret, specificReturn := fake.populateDependenciesReturnsOnCall[len(fake.populateDependenciesArgsForCall)]
 	fake.populateDependenciesArgsForCall = append(fake.populateDependenciesArgsForCall, struct {
-		arg1 *cargo.Metadata
-		arg2 *meta.Package
-		arg3 bool
-		arg4 *map[string]*meta.Package
-	}{arg1, arg2, arg3, arg4})
+		arg1 context.Context
+		arg2 *cargo.Metadata
+		arg3 *meta.Package
+		arg4 bool
+		arg5 *map[string]*meta.Package
+	}{arg1, arg2, arg3, arg4, arg5})
 	stub := fake.PopulateDependenciesStub
 	fakeReturns := fake.populateDependenciesReturns
-	fake.recordInvocation("PopulateDependencies", []interface{}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("PopulateDependencies", []interface{}{arg1, arg2, arg3, arg4, arg5})
 	fake.populateDependenciesMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3, arg4)
+		return stub(arg1, arg2, arg3, arg4, arg5)
 	}
 	if specificReturn {
 		return ret.result1
@@ -626,17 +639,17 @@ func (fake *FakeCargoImplementation) PopulateDependenciesCallCount() int {
 	return len(fake.populateDependenciesArgsForCall)
 }
 
-func (fake *FakeCargoImplementation) PopulateDependenciesCalls(stub func(*cargo.Metadata, *meta.Package, bool, *map[string]*meta.Package) error) {
+func (fake *FakeCargoImplementation) PopulateDependenciesCalls(stub func(context.Context, *cargo.Metadata, *meta.Package, bool, *map[string]*meta.Package) error) {
 	fake.populateDependenciesMutex.Lock()
 	defer fake.populateDependenciesMutex.Unlock()
 	fake.PopulateDependenciesStub = stub
 }
 
-func (fake *FakeCargoImplementation) PopulateDependenciesArgsForCall(i int) (*cargo.Metadata, *meta.Package, bool, *map[string]*meta.Package) {
+func (fake *FakeCargoImplementation) PopulateDependenciesArgsForCall(i int) (context.Context, *cargo.Metadata, *meta.Package, bool, *map[string]*meta.Package) {
 	fake.populateDependenciesMutex.RLock()
 	defer fake.populateDependenciesMutex.RUnlock()
 	argsForCall := fake.populateDependenciesArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
 }
 
 func (fake *FakeCargoImplementation) PopulateDependenciesReturns(result1 error) {
@@ -4,15 +4,15 @@
 package cargo
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"path/filepath"
 	"strings"
 
+	"github.com/opensbom-generator/parsers/internal/helper"
 	"github.com/opensbom-generator/parsers/meta"
 	"github.com/opensbom-generator/parsers/plugin"
-	"sigs.k8s.io/release-utils/command"
-	"sigs.k8s.io/release-utils/util"
 )
 
 type Mod struct {
@@ -20,6 +20,11 @@ type Mod struct {
 	rootModule    *meta.Package
 	cargoMetadata *Metadata
 	impl          cargoImplementation
+	options       plugin.Options
+}
+
+func init() {
+	plugin.Register("cargo", func() plugin.Plugin { return New() })
 }
 
 func New() *Mod {
@@ -29,6 +34,13 @@ func New() *Mod {
 			Slug:       "cargo",
 			Manifest:   []string{tomlFileName},
 			ModulePath: []string{"vendor"},
+			ReadOnly:   true,
+			Capabilities: plugin.Capabilities{
+				Executables:     []plugin.Executable{{Name: "cargo", Constraint: ">=1.41"}},
+				Network:         plugin.NetworkOptional,
+				Lockfiles:       []string{lockFileName},
+				TransitiveGraph: true,
+			},
 		},
 		impl: &defaultImplementation{},
 	}
@@ -38,12 +50,22 @@ func (m *Mod) SetImplementation(impl cargoImplementation) {
 	m.impl = impl
 }
 
+// SetOptions configures how modules are listed
+func (m *Mod) SetOptions(opts plugin.Options) {
+	m.options = opts
+}
+
 func (m *Mod) GetMetadata() plugin.Metadata {
 	return m.metadata
 }
 
 func (m *Mod) SetRootModule(path string) error {
-	module, err := m.getRootModule(path)
+	return m.SetRootModuleContext(context.Background(), path)
+}
+
+func (m *Mod) SetRootModuleContext(ctx context.Context, path string) error {
+	ctx = m.options.Context(ctx)
+	module, err := m.getRootModule(ctx, path)
 	if err != nil {
 		return err
 	}
@@ -53,20 +75,30 @@ func (m *Mod) SetRootModule(path string) error {
 }
 
 func (m *Mod) GetVersion() (string, error) {
-	output, err := command.NewWithWorkDir(".", "cargo", "--version").RunSilentSuccessOutput()
+	return m.GetVersionContext(context.Background())
+}
+
+func (m *Mod) GetVersionContext(ctx context.Context) (string, error) {
+	ctx = m.options.Context(ctx)
+	output, err := runCargo(ctx, ".", VersionArg)
 	if err != nil {
 		return "", fmt.Errorf("getting cargo version: %w", err)
 	}
 
-	return strings.TrimPrefix(output.OutputTrimNL(), "cargo "), nil
+	return strings.TrimPrefix(strings.TrimSpace(string(output)), "cargo "), nil
 }
 
 func (m *Mod) GetRootModule(path string) (*meta.Package, error) {
+	return m.GetRootModuleContext(context.Background(), path)
+}
+
+func (m *Mod) GetRootModuleContext(ctx context.Context, path string) (*meta.Package, error) {
+	ctx = m.options.Context(ctx)
 	if m.rootModule != nil {
 		return m.rootModule, nil
 	}
 
-	md, err := m.impl.GetCargoMetadataIfNeeded(m, path)
+	md, err := m.impl.GetCargoMetadataIfNeeded(ctx, m, path)
 	if err != nil {
 		return nil, fmt.Errorf("getting cargo metadata: %w", err)
 	}
@@ -77,24 +109,30 @@ func (m *Mod) GetRootModule(path string) (*meta.Package, error) {
 	}
 
 	cargoPackage := md.GetPackageByName(rootName)
-	metaPackage := m.impl.ConvertCargoPackageToMetaPackage(cargoPackage)
+	metaPackage := m.impl.ConvertCargoPackageToMetaPackage(ctx, cargoPackage)
 
 	return &metaPackage, nil
 }
 
 // ListUsedModules returns the firs tier dependencies of the module
 func (m *Mod) ListUsedModules(path string) ([]meta.Package, error) {
-	md, err := m.impl.GetCargoMetadataIfNeeded(m, path)
+	return m.ListUsedModulesContext(context.Background(), path)
+}
+
+// ListUsedModulesContext returns the firs tier dependencies of the module
+func (m *Mod) ListUsedModulesContext(ctx context.Context, path string) ([]meta.Package, error) {
+	ctx = m.options.Context(ctx)
+	md, err := m.impl.GetCargoMetadataIfNeeded(ctx, m, path)
 	if err != nil {
 		return nil, fmt.Errorf("getting cargo metadata: %w", err)
 	}
 
-	mod, err := m.impl.GetRootModule(md, path)
+	mod, err := m.impl.GetRootModule(ctx, md, path)
 	if err != nil {
 		return nil, fmt.Errorf("getting root module: %w", err)
 	}
 
-	if err := m.impl.PopulateDependencies(md, &mod, false, nil); err != nil {
+	if err := m.impl.PopulateDependencies(ctx, md, &mod, false, nil); err != nil {
 		return nil, fmt.Errorf("populating deps of %s: %w", mod.Name, err)
 	}
 
@@ -102,21 +140,28 @@ func (m *Mod) ListUsedModules(path string) ([]meta.Package, error) {
 	for _, p := range mod.Packages {
 		r = append(r, *p)
 	}
+	meta.Sort(r)
+
 	return r, nil
 }
 
 func (m *Mod) ListModulesWithDeps(path string, globalSettingFile string) ([]meta.Package, error) {
-	md, err := m.impl.GetCargoMetadataIfNeeded(m, path)
+	return m.ListModulesWithDepsContext(context.Background(), path, globalSettingFile)
+}
+
+func (m *Mod) ListModulesWithDepsContext(ctx context.Context, path string, globalSettingFile string) ([]meta.Package, error) {
+	ctx = m.options.Context(ctx)
+	md, err := m.impl.GetCargoMetadataIfNeeded(ctx, m, path)
 	if err != nil {
 		return nil, fmt.Errorf("getting cargo metadata: %w", err)
 	}
 
-	mod, err := m.impl.GetRootModule(md, path)
+	mod, err := m.impl.GetRootModule(ctx, md, path)
 	if err != nil {
 		return nil, fmt.Errorf("getting root module: %w", err)
 	}
 
-	if err := m.impl.PopulateDependencies(md, &mod, true, nil); err != nil {
+	if err := m.impl.PopulateDependencies(ctx, md, &mod, true, nil); err != nil {
 		return nil, fmt.Errorf("populating deps of %s: %w", mod.Name, err)
 	}
 
@@ -124,12 +169,12 @@ func (m *Mod) ListModulesWithDeps(path string, globalSettingFile string) ([]meta
 	for _, p := range mod.Packages {
 		r = append(r, *p)
 	}
-	return r, nil
+	return m.options.Filter(r), nil
 }
 
 func (m *Mod) IsValid(path string) bool {
 	for i := range m.metadata.Manifest {
-		if util.Exists(filepath.Join(path, m.metadata.Manifest[i])) {
+		if helper.Exists(filepath.Join(path, m.metadata.Manifest[i])) {
 			return true
 		}
 	}
@@ -137,7 +182,11 @@ func (m *Mod) IsValid(path string) bool {
 }
 
 func (m *Mod) HasModulesInstalled(path string) error {
-	if util.Exists(filepath.Join(path, lockFileName)) {
+	return m.HasModulesInstalledContext(context.Background(), path)
+}
+
+func (m *Mod) HasModulesInstalledContext(ctx context.Context, path string) error {
+	if helper.Exists(filepath.Join(path, lockFileName)) {
 		return nil
 	}
 	return errors.New("project lockfile not found")
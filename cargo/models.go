@@ -3,6 +3,8 @@
 
 package cargo
 
+import "github.com/opensbom-generator/parsers/meta"
+
 type Metadata struct {
 	WorkspaceRoot   string    `json:"workspace_root"`
 	Version         int64     `json:"version"`
@@ -47,3 +49,18 @@ func (md *Metadata) GetPackageByName(name string) *Package {
 	}
 	return nil
 }
+
+// Scope returns the scope of the dependency from its kind
+func (pd *PackageDependency) Scope() meta.Scope {
+	switch pd.Kind {
+	case "dev":
+		return meta.ScopeDevelopment
+	case "build":
+		return meta.ScopeBuild
+	}
+	if pd.Optional {
+		return meta.ScopeOptional
+	}
+
+	return meta.ScopeRuntime
+}
@@ -4,12 +4,14 @@
 package cargo
 
 import (
+	"context"
 	"fmt"
 	"net/mail"
 	"strings"
 
 	"github.com/opensbom-generator/parsers/internal/helper"
 	"github.com/opensbom-generator/parsers/meta"
+	"github.com/opensbom-generator/parsers/purl"
 )
 
 func getPackageSupplier(authors []string, defaultValue string) meta.Supplier {
@@ -47,40 +49,34 @@ func getPackageSupplier(authors []string, defaultValue string) meta.Supplier {
 	return supplier
 }
 
-func (m *Mod) getRootModule(path string) (meta.Package, error) {
-	md, err := m.impl.GetCargoMetadataIfNeeded(m, path)
+func (m *Mod) getRootModule(ctx context.Context, path string) (meta.Package, error) {
+	md, err := m.impl.GetCargoMetadataIfNeeded(ctx, m, path)
 	if err != nil {
 		return meta.Package{}, fmt.Errorf("getting cargo metadata: %w", err)
 	}
-	return m.impl.GetRootModule(md, path)
+	return m.impl.GetRootModule(ctx, md, path)
 }
 
-func convertCargoPackageToRootModule(dep Package) meta.Package {
+func convertCargoPackageToRootModule(ctx context.Context, dep Package) meta.Package {
 	localPath := convertToLocalPath(dep.ManifestPath)
 
 	module := meta.Package{
-		Version:    dep.Version,
-		Name:       dep.Name,
-		Root:       true,
-		PackageURL: formatPackageURL(dep),
-		Checksum: meta.Checksum{
-			Algorithm: meta.HashAlgoSHA1,
-			Value:     readCheckSum(dep.ID),
-		},
+		Version:                 dep.Version,
+		Name:                    dep.Name,
+		Root:                    true,
+		PackageURL:              formatPackageURL(dep),
 		LocalPath:               localPath,
 		PackageHomePage:         removeURLProtocol(dep.Homepage),
 		Supplier:                getPackageSupplier(dep.Authors, dep.Name),
 		Packages:                map[string]*meta.Package{},
 		PackageDownloadLocation: dep.Repository,
 	}
+	// the crate being scanned is not packaged, Cargo.lock has no checksum
+	// for it
+	module.AddChecksum(meta.Checksum{Algorithm: meta.HashAlgoSHA1, Content: []byte(dep.ID), Synthetic: true})
 
-	licensePkg, err := helper.GetLicenses(localPath)
-	if err == nil {
-		module.LicenseDeclared = helper.BuildLicenseDeclared(licensePkg.ID)
-		module.LicenseConcluded = helper.BuildLicenseConcluded(licensePkg.ID)
-		module.Copyright = helper.GetCopyright(licensePkg.ExtractedText)
-		module.CommentsLicense = licensePkg.Comments
-	}
+	helper.SetLicenses(ctx, &module, dep.License, localPath)
+	module.Copyright = helper.ScanCopyright(localPath)
 
 	return module
 }
@@ -90,22 +86,13 @@ func convertToLocalPath(manifestPath string) string {
 	return localPath
 }
 
-func getDefaultPackageURL(dep Package) string {
-	if dep.Homepage != "" {
-		return dep.Homepage
-	}
-
-	if dep.Source != "" {
-		return dep.Source
-	}
-
-	return dep.Repository
-}
-
+// formatPackageURL returns the purl of a crate. Crates that do not come
+// from crates.io carry their registry in the repository_url qualifier.
 func formatPackageURL(dep Package) string {
-	URL := getDefaultPackageURL(dep)
-	URL = removeURLProtocol(URL)
-	URL = removeRegisrySuffix(URL)
+	p := purl.New(purl.TypeCargo, "", dep.Name, dep.Version)
+	if strings.HasPrefix(dep.Source, "registry+") && dep.Source != cratesIOSource {
+		p = p.WithQualifier("repository_url", removeRegisrySuffix(dep.Source))
+	}
 
-	return URL
+	return p.String()
 }
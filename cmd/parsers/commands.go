@@ -0,0 +1,256 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/opensbom-generator/parsers/cyclonedx"
+	"github.com/opensbom-generator/parsers/meta"
+	"github.com/opensbom-generator/parsers/plugin"
+	"github.com/opensbom-generator/parsers/spdx"
+)
+
+// Document formats written by the sbom command
+const (
+	formatSPDXJSON      = "spdx-json"
+	formatSPDXTagValue  = "spdx-tv"
+	formatCycloneDXJSON = "cyclonedx-json"
+	formatCycloneDXXML  = "cyclonedx-xml"
+)
+
+func (a *app) detect(ctx context.Context, args []string) error {
+	f := a.newFlags("detect", "[dir]")
+	recursive := f.Bool("recursive", false, "search the projects in the subdirectories too")
+	dir, err := f.parse(args)
+	if err != nil {
+		return err
+	}
+
+	projects := []plugin.Project{{Path: ".", Matches: a.registry.Matches(dir)}}
+	if *recursive {
+		if projects, err = a.registry.Discover(dir, plugin.DiscoverOptions{}); err != nil {
+			return err
+		}
+	}
+
+	detected := []detection{}
+	for _, project := range projects {
+		for _, match := range project.Matches {
+			if f.plugin != "" && match.Slug != f.plugin {
+				continue
+			}
+			detected = append(detected, detection{
+				Path:       project.Path,
+				Slug:       match.Slug,
+				Name:       match.Plugin.GetMetadata().Name,
+				Confidence: match.Confidence,
+			})
+		}
+	}
+	if len(detected) == 0 {
+		return fmt.Errorf("no plugin matches %s", dir)
+	}
+
+	if f.json {
+		return writeJSON(a.stdout, detected)
+	}
+	for _, d := range detected {
+		fmt.Fprintf(a.stdout, "%s\t%s\t%.2f\t%s\n", d.Path, d.Slug, d.Confidence, d.Name)
+	}
+
+	return nil
+}
+
+func (a *app) root(ctx context.Context, args []string) error {
+	f := a.newFlags("root", "[dir]")
+	dir, err := f.parse(args)
+	if err != nil {
+		return err
+	}
+
+	p, err := a.prepare(ctx, f, dir)
+	if err != nil {
+		return err
+	}
+
+	pkg, diagnostics, err := plugin.GetRootModule(ctx, p, dir)
+	a.report(diagnostics)
+	if err != nil {
+		return err
+	}
+
+	if f.json {
+		return writeJSON(a.stdout, newJSONPackage(pkg))
+	}
+	writePackage(a.stdout, pkg)
+
+	return nil
+}
+
+func (a *app) list(ctx context.Context, args []string) error {
+	f := a.newFlags("list", "[dir]")
+	deps := f.Bool("deps", false, "list the dependencies of every module, as ListModulesWithDeps does")
+	dir, err := f.parse(args)
+	if err != nil {
+		return err
+	}
+
+	pkgs, err := a.modules(ctx, f, dir, *deps)
+	if err != nil {
+		return err
+	}
+
+	if f.json {
+		list := make([]jsonPackage, 0, len(pkgs))
+		for i := range pkgs {
+			list = append(list, newJSONPackage(&pkgs[i]))
+		}
+		return writeJSON(a.stdout, list)
+	}
+	for i := range pkgs {
+		fmt.Fprintln(a.stdout, nameVersion(&pkgs[i]))
+	}
+
+	return nil
+}
+
+func (a *app) graph(ctx context.Context, args []string) error {
+	f := a.newFlags("graph", "[dir]")
+	dir, err := f.parse(args)
+	if err != nil {
+		return err
+	}
+
+	pkgs, err := a.modules(ctx, f, dir, true)
+	if err != nil {
+		return err
+	}
+
+	roots := rootPackages(pkgs)
+	if f.json {
+		trees := make([]*jsonNode, 0, len(roots))
+		expanded := map[string]bool{}
+		for _, root := range roots {
+			trees = append(trees, newJSONNode(root, expanded))
+		}
+		return writeJSON(a.stdout, trees)
+	}
+	expanded := map[string]bool{}
+	for _, root := range roots {
+		writeTree(a.stdout, root, "", expanded)
+	}
+
+	return nil
+}
+
+func (a *app) sbom(ctx context.Context, args []string) error {
+	f := a.newFlags("sbom", "[dir]")
+	format := f.String("format", formatSPDXJSON, "document format: spdx-json, spdx-tv, cyclonedx-json or cyclonedx-xml")
+	files := f.Bool("files", false, "list the files of the packages found on disk and compute their SPDX verification code")
+	fileLicenses := f.Bool("file-licenses", false, "detect the licenses of the listed files, implies -files")
+	dir, err := f.parse(args)
+	if err != nil {
+		return err
+	}
+
+	switch *format {
+	case formatSPDXJSON, formatSPDXTagValue, formatCycloneDXJSON, formatCycloneDXXML:
+	default:
+		fmt.Fprintf(a.stderr, "unknown format %q\n", *format)
+		f.Usage()
+		return errUsage
+	}
+
+	pkgs, err := a.modules(ctx, f, dir, true)
+	if err != nil {
+		return err
+	}
+	if *files || *fileLicenses {
+		a.analyzeFiles(pkgs, meta.FileOptions{Licenses: *fileLicenses})
+	}
+
+	switch *format {
+	case formatSPDXTagValue:
+		return spdx.New(pkgs, spdx.Options{}).WriteTagValue(a.stdout)
+	case formatCycloneDXJSON:
+		return cyclonedx.New(pkgs, cyclonedx.Options{}).WriteJSON(a.stdout)
+	case formatCycloneDXXML:
+		return cyclonedx.New(pkgs, cyclonedx.Options{}).WriteXML(a.stdout)
+	}
+
+	return spdx.New(pkgs, spdx.Options{}).WriteJSON(a.stdout)
+}
+
+// prepare returns the plugin selected by the flags for dir, configured and
+// with its root module set
+func (a *app) prepare(ctx context.Context, f *flags, dir string) (plugin.Plugin, error) {
+	var p plugin.Plugin
+	if f.plugin != "" {
+		selected, err := a.registry.Get(f.plugin)
+		if err != nil {
+			return nil, err
+		}
+		if !selected.IsValid(dir) {
+			return nil, fmt.Errorf("plugin %q does not match %s", f.plugin, dir)
+		}
+		p = selected
+	} else {
+		matches := a.registry.Matches(dir)
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no plugin matches %s", dir)
+		}
+		p = matches[0].Plugin
+	}
+
+	plugin.Configure(p, f.options())
+	v2 := plugin.WithContext(p)
+	if err := v2.SetRootModuleContext(ctx, dir); err != nil {
+		return nil, fmt.Errorf("setting the root module: %w", err)
+	}
+	if err := v2.HasModulesInstalledContext(ctx, dir); err != nil {
+		return nil, err
+	}
+
+	return v2, nil
+}
+
+// modules lists the modules of dir, with their dependencies when deps is
+// true
+func (a *app) modules(ctx context.Context, f *flags, dir string, deps bool) ([]meta.Package, error) {
+	p, err := a.prepare(ctx, f, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		pkgs        []meta.Package
+		diagnostics meta.Diagnostics
+	)
+	if deps {
+		pkgs, diagnostics, err = plugin.ListModulesWithDeps(ctx, p, dir, f.globalSettingFile)
+	} else {
+		pkgs, diagnostics, err = plugin.ListUsedModules(ctx, p, dir)
+	}
+	a.report(diagnostics)
+
+	return pkgs, err
+}
+
+// analyzeFiles lists the files of the packages with a local directory, the
+// packages whose files cannot be read are written without them
+func (a *app) analyzeFiles(pkgs []meta.Package, opts meta.FileOptions) {
+	for i := range pkgs {
+		if err := pkgs[i].AnalyzeFiles(opts); err != nil {
+			fmt.Fprintf(a.stderr, "listing the files of %s: %v\n", nameVersion(&pkgs[i]), err)
+		}
+	}
+}
+
+// report writes the diagnostics to stderr, keeping stdout parseable
+func (a *app) report(diagnostics meta.Diagnostics) {
+	for _, d := range diagnostics {
+		fmt.Fprintln(a.stderr, d)
+	}
+}
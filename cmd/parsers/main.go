@@ -0,0 +1,157 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Command parsers runs the parsers of this module on a project, to try them
+// out or to debug one that misbehaves.
+//
+//	parsers detect [flags] [dir]  list the plugins matching the project
+//	parsers root [flags] [dir]    print the root module
+//	parsers list [flags] [dir]    list the modules used by the project
+//	parsers graph [flags] [dir]   print the dependency tree
+//	parsers sbom [flags] [dir]    write an SPDX or CycloneDX document
+//
+// Run "parsers <command> -h" for the flags of a command.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	"github.com/opensbom-generator/parsers/plugin"
+	_ "github.com/opensbom-generator/parsers/plugin/all"
+)
+
+const usage = `usage: parsers <command> [flags] [dir]
+
+commands:
+  detect  list the plugins matching the project
+  root    print the root module
+  list    list the modules used by the project
+  graph   print the dependency tree
+  sbom    write an SPDX or CycloneDX document
+
+Run "parsers <command> -h" for the flags of a command.
+`
+
+// errUsage is returned for invalid command lines, the usage is already
+// printed
+var errUsage = errors.New("invalid usage")
+
+type app struct {
+	registry *plugin.Registry
+	stdout   io.Writer
+	stderr   io.Writer
+}
+
+type command func(a *app, ctx context.Context, args []string) error
+
+var commands = map[string]command{
+	"detect": (*app).detect,
+	"root":   (*app).root,
+	"list":   (*app).list,
+	"graph":  (*app).graph,
+	"sbom":   (*app).sbom,
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	a := &app{registry: plugin.DefaultRegistry, stdout: os.Stdout, stderr: os.Stderr}
+	os.Exit(a.run(ctx, os.Args[1:]))
+}
+
+// run executes the command line and returns the exit code: 0 on success, 1
+// when the command fails and 2 for invalid usage
+func (a *app) run(ctx context.Context, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(a.stderr, usage)
+		return 2
+	}
+	if args[0] == "-h" || args[0] == "-help" || args[0] == "help" {
+		fmt.Fprint(a.stdout, usage)
+		return 0
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(a.stderr, "unknown command %q\n\n%s", args[0], usage)
+		return 2
+	}
+
+	err := cmd(a, ctx, args[1:])
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.Is(err, errUsage):
+		return 2
+	}
+
+	fmt.Fprintf(a.stderr, "parsers %s: %v\n", args[0], err)
+	return 1
+}
+
+// flags are shared by every command
+type flags struct {
+	*flag.FlagSet
+	plugin            string
+	offline           bool
+	readOnly          bool
+	runtimeOnly       bool
+	licenseThreshold  float64
+	globalSettingFile string
+	json              bool
+}
+
+func (a *app) newFlags(name, args string) *flags {
+	f := &flags{FlagSet: flag.NewFlagSet(name, flag.ContinueOnError)}
+	f.SetOutput(a.stderr)
+	f.Usage = func() {
+		fmt.Fprintf(a.stderr, "usage: parsers %s [flags] %s\n\nflags:\n", name, args)
+		f.PrintDefaults()
+	}
+	f.StringVar(&f.plugin, "plugin", "", "slug of the plugin to run, by default the best match for the project")
+	f.BoolVar(&f.offline, "offline", false, "forbid network access")
+	f.BoolVar(&f.readOnly, "read-only", false, "forbid changes to the project")
+	f.BoolVar(&f.runtimeOnly, "runtime-only", false, "leave out development, test, build, optional and peer dependencies")
+	f.Float64Var(&f.licenseThreshold, "license-threshold", 0, "confidence from 0 to 1 above which detected licenses are kept, 0 for the default")
+	f.StringVar(&f.globalSettingFile, "global-settings", "", "global settings `file` of the package manager, e.g. the maven settings.xml")
+	f.BoolVar(&f.json, "json", false, "write JSON")
+
+	return f
+}
+
+// parse parses args and returns the project directory, "." by default
+func (f *flags) parse(args []string) (string, error) {
+	if err := f.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return "", err
+		}
+		return "", errUsage
+	}
+
+	switch f.NArg() {
+	case 0:
+		return ".", nil
+	case 1:
+		return f.Arg(0), nil
+	}
+	f.Usage()
+
+	return "", errUsage
+}
+
+func (f *flags) options() plugin.Options {
+	return plugin.Options{
+		RuntimeOnly:      f.runtimeOnly,
+		Offline:          f.offline,
+		ReadOnly:         f.readOnly,
+		LicenseThreshold: float32(f.licenseThreshold),
+	}
+}
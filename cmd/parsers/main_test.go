@@ -0,0 +1,131 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opensbom-generator/parsers/meta"
+	"github.com/opensbom-generator/parsers/plugin"
+)
+
+// fakePlugin matches directories holding fake.json and lists an application
+// whose dependencies depend on each other
+type fakePlugin struct{}
+
+func (f *fakePlugin) SetRootModule(string) error  { return nil }
+func (f *fakePlugin) GetVersion() (string, error) { return "1.0", nil }
+func (f *fakePlugin) GetMetadata() plugin.Metadata {
+	return plugin.Metadata{Name: "Fake", Slug: "fake", Manifest: []string{"fake.json"}}
+}
+func (f *fakePlugin) GetRootModule(string) (*meta.Package, error) {
+	return &meta.Package{Name: "app", Version: "1.0.0", Root: true}, nil
+}
+func (f *fakePlugin) ListUsedModules(string) ([]meta.Package, error) {
+	return []meta.Package{{Name: "app", Version: "1.0.0", Root: true}, {Name: "a", Version: "1.0.0"}}, nil
+}
+func (f *fakePlugin) ListModulesWithDeps(string, string) ([]meta.Package, error) {
+	a := &meta.Package{Name: "a", Version: "1.0.0", PackageURL: "pkg:generic/a@1.0.0"}
+	b := &meta.Package{Name: "b", Version: "2.0.0", PackageURL: "pkg:generic/b@2.0.0"}
+	a.Packages = map[string]*meta.Package{"b": b}
+	b.Packages = map[string]*meta.Package{"a": a}
+	app := meta.Package{
+		Name:       "app",
+		Version:    "1.0.0",
+		PackageURL: "pkg:generic/app@1.0.0",
+		Root:       true,
+		Packages:   map[string]*meta.Package{"a": a, "b": b},
+	}
+
+	return []meta.Package{app, *a, *b}, nil
+}
+func (f *fakePlugin) HasModulesInstalled(string) error { return nil }
+func (f *fakePlugin) IsValid(path string) bool {
+	_, err := os.Stat(filepath.Join(path, "fake.json"))
+	return err == nil
+}
+
+func newTestApp(t *testing.T) (*app, *bytes.Buffer, *bytes.Buffer, string) {
+	r := plugin.NewRegistry()
+	require.NoError(t, r.Register("fake", func() plugin.Plugin { return &fakePlugin{} }))
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "fake.json"), nil, 0o600))
+
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	return &app{registry: r, stdout: stdout, stderr: stderr}, stdout, stderr, dir
+}
+
+func TestUsage(t *testing.T) {
+	a, _, stderr, _ := newTestApp(t)
+
+	assert.Equal(t, 2, a.run(context.Background(), nil))
+	assert.Equal(t, 2, a.run(context.Background(), []string{"unknown"}))
+	assert.Contains(t, stderr.String(), `unknown command "unknown"`)
+	assert.Equal(t, 2, a.run(context.Background(), []string{"list", "a", "b"}))
+	assert.Equal(t, 2, a.run(context.Background(), []string{"sbom", "-format", "pdf"}))
+	assert.Equal(t, 0, a.run(context.Background(), []string{"root", "-h"}))
+}
+
+func TestDetect(t *testing.T) {
+	a, stdout, stderr, dir := newTestApp(t)
+
+	require.Equal(t, 0, a.run(context.Background(), []string{"detect", "-json", dir}))
+	detected := []detection{}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &detected))
+	assert.Equal(t, []detection{{Path: ".", Slug: "fake", Name: "Fake", Confidence: 1}}, detected)
+
+	assert.Equal(t, 1, a.run(context.Background(), []string{"detect", t.TempDir()}))
+	assert.Contains(t, stderr.String(), "no plugin matches")
+
+	assert.Equal(t, 1, a.run(context.Background(), []string{"root", "-plugin", "missing", dir}))
+}
+
+func TestList(t *testing.T) {
+	a, stdout, stderr, dir := newTestApp(t)
+
+	require.Equal(t, 0, a.run(context.Background(), []string{"list", dir}))
+	assert.Equal(t, "app@1.0.0\na@1.0.0\n", stdout.String())
+
+	stdout.Reset()
+	require.Equal(t, 0, a.run(context.Background(), []string{"list", "-deps", "-json", dir}), stderr.String())
+	list := []map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &list))
+	require.Len(t, list, 3)
+	assert.Equal(t, []interface{}{"a", "b"}, list[0]["dependencies"])
+	assert.NotContains(t, list[0], "Packages")
+}
+
+func TestGraph(t *testing.T) {
+	a, stdout, _, dir := newTestApp(t)
+
+	require.Equal(t, 0, a.run(context.Background(), []string{"graph", dir}))
+	assert.Equal(t, `app@1.0.0
+├── a@1.0.0
+│   └── b@2.0.0
+│       └── a@1.0.0 (*)
+└── b@2.0.0 (*)
+`, stdout.String())
+}
+
+func TestSBOM(t *testing.T) {
+	a, stdout, _, dir := newTestApp(t)
+
+	require.Equal(t, 0, a.run(context.Background(), []string{"sbom", dir}))
+	doc := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &doc))
+	assert.Equal(t, "SPDX-2.3", doc["spdxVersion"])
+
+	stdout.Reset()
+	require.Equal(t, 0, a.run(context.Background(), []string{"sbom", "-format", "cyclonedx-json", dir}))
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &doc))
+	assert.Equal(t, "CycloneDX", doc["bomFormat"])
+}
@@ -0,0 +1,160 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/opensbom-generator/parsers/meta"
+)
+
+// detection is a plugin matching a project
+type detection struct {
+	Path       string  `json:"path"`
+	Slug       string  `json:"slug"`
+	Name       string  `json:"name"`
+	Confidence float64 `json:"confidence"`
+}
+
+// jsonPackage is a package with its dependencies replaced by their names,
+// as dependency graphs may have cycles. The always nil Packages field hides
+// the one of meta.Package from the encoder.
+type jsonPackage struct {
+	meta.Package
+	Packages     *struct{} `json:"Packages,omitempty"`
+	Dependencies []string  `json:"dependencies,omitempty"`
+}
+
+func newJSONPackage(pkg *meta.Package) jsonPackage {
+	return jsonPackage{Package: *pkg, Dependencies: dependencyNames(pkg)}
+}
+
+// jsonNode is a package of a dependency tree. The dependencies of a package
+// are only listed the first time it appears in the tree.
+type jsonNode struct {
+	Name         string      `json:"name"`
+	Version      string      `json:"version,omitempty"`
+	PackageURL   string      `json:"purl,omitempty"`
+	Dependencies []*jsonNode `json:"dependencies,omitempty"`
+}
+
+func newJSONNode(pkg *meta.Package, expanded map[string]bool) *jsonNode {
+	node := &jsonNode{Name: pkg.Name, Version: pkg.Version, PackageURL: pkg.PackageURL}
+	if expanded[nodeKey(pkg)] {
+		return node
+	}
+	expanded[nodeKey(pkg)] = true
+
+	for _, name := range dependencyNames(pkg) {
+		node.Dependencies = append(node.Dependencies, newJSONNode(pkg.Packages[name], expanded))
+	}
+
+	return node
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+func writePackage(w io.Writer, pkg *meta.Package) {
+	fields := []struct{ name, value string }{
+		{"name", pkg.Name},
+		{"version", pkg.Version},
+		{"purl", pkg.PackageURL},
+		{"path", pkg.Path},
+		{"dir", pkg.LocalPath},
+		{"supplier", pkg.Supplier.Name},
+		{"homepage", pkg.PackageHomePage},
+		{"download", pkg.PackageDownloadLocation},
+		{"license", pkg.LicenseDeclared},
+		{"copyright", pkg.Copyright},
+	}
+	for _, f := range fields {
+		if f.value != "" {
+			fmt.Fprintf(w, "%-10s %s\n", f.name+":", f.value)
+		}
+	}
+	for _, name := range dependencyNames(pkg) {
+		fmt.Fprintf(w, "%-10s %s\n", "requires:", nameVersion(pkg.Packages[name]))
+	}
+}
+
+// writeTree writes pkg and its dependencies, the dependencies of a package
+// already written are replaced by "(*)"
+func writeTree(w io.Writer, pkg *meta.Package, indent string, expanded map[string]bool) {
+	if indent == "" {
+		fmt.Fprintln(w, nameVersion(pkg))
+	}
+
+	names := dependencyNames(pkg)
+	if expanded[nodeKey(pkg)] {
+		return
+	}
+	expanded[nodeKey(pkg)] = true
+
+	for i, name := range names {
+		dep := pkg.Packages[name]
+		branch, next := "├── ", "│   "
+		if i == len(names)-1 {
+			branch, next = "└── ", "    "
+		}
+
+		line := nameVersion(dep)
+		if expanded[nodeKey(dep)] && len(dep.Packages) > 0 {
+			line += " (*)"
+		}
+		fmt.Fprintln(w, indent+branch+line)
+		writeTree(w, dep, indent+next, expanded)
+	}
+}
+
+// rootPackages returns the root modules of pkgs, or the first package when
+// the plugin marked none
+func rootPackages(pkgs []meta.Package) []*meta.Package {
+	roots := []*meta.Package{}
+	for i := range pkgs {
+		if pkgs[i].Root {
+			roots = append(roots, &pkgs[i])
+		}
+	}
+	if len(roots) == 0 && len(pkgs) > 0 {
+		roots = append(roots, &pkgs[0])
+	}
+
+	return roots
+}
+
+func dependencyNames(pkg *meta.Package) []string {
+	names := make([]string, 0, len(pkg.Packages))
+	for name, dep := range pkg.Packages {
+		if dep != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// nodeKey identifies a package of a tree, plugins may return several
+// copies of the same package
+func nodeKey(pkg *meta.Package) string {
+	if pkg.PackageURL != "" {
+		return pkg.PackageURL
+	}
+
+	return nameVersion(pkg)
+}
+
+func nameVersion(pkg *meta.Package) string {
+	if pkg.Version == "" {
+		return pkg.Name
+	}
+
+	return pkg.Name + "@" + pkg.Version
+}
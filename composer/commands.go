@@ -3,6 +3,7 @@
 package composer
 
 import (
+	"context"
 	"strings"
 
 	"github.com/opensbom-generator/parsers/internal/helper"
@@ -26,16 +27,23 @@ func (c command) Parse() []string {
 	return strings.Fields(cmd)
 }
 
-func (m *Composer) buildCmd(cmd command, path string) error {
+func (m *Composer) buildCmd(ctx context.Context, cmd command, path string) error {
 	cmdArgs := cmd.Parse()
 	if cmdArgs[0] != "composer" {
 		return errNoComposerCommand
 	}
 
+	var env []string
+	if helper.IsOffline(ctx) {
+		env = []string{"COMPOSER_DISABLE_NETWORK=1"}
+	}
+
 	command := helper.NewCmd(helper.CmdOptions{
 		Name:      cmdArgs[0],
 		Args:      cmdArgs[1:],
 		Directory: path,
+		Context:   ctx,
+		Env:       env,
 	})
 
 	m.command = command
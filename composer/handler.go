@@ -3,6 +3,7 @@
 package composer
 
 import (
+	"context"
 	"path/filepath"
 
 	"github.com/opensbom-generator/parsers/internal/helper"
@@ -13,6 +14,11 @@ import (
 type Composer struct {
 	metadata plugin.Metadata
 	command  *helper.Cmd
+	options  plugin.Options
+}
+
+func init() {
+	plugin.Register("composer", func() plugin.Plugin { return New() })
 }
 
 // New ...
@@ -23,10 +29,23 @@ func New() *Composer {
 			Slug:       "composer",
 			Manifest:   []string{ComposerJSONFileName},
 			ModulePath: []string{ComposerVendorFolder},
+			ReadOnly:   true,
+			Capabilities: plugin.Capabilities{
+				Executables:      []plugin.Executable{{Name: "composer"}},
+				Network:          plugin.NetworkOptional,
+				InstalledModules: true,
+				Lockfiles:        []string{ComposerLockFileName},
+				TransitiveGraph:  true,
+			},
 		},
 	}
 }
 
+// SetOptions configures how modules are listed
+func (m *Composer) SetOptions(opts plugin.Options) {
+	m.options = opts
+}
+
 // GetMetadata ...
 func (m *Composer) GetMetadata() plugin.Metadata {
 	return m.metadata
@@ -44,6 +63,11 @@ func (m *Composer) IsValid(path string) bool {
 
 // HasModulesInstalled ...
 func (m *Composer) HasModulesInstalled(path string) error {
+	return m.HasModulesInstalledContext(context.Background(), path)
+}
+
+// HasModulesInstalledContext ...
+func (m *Composer) HasModulesInstalledContext(ctx context.Context, path string) error {
 	for i := range m.metadata.ModulePath {
 		if helper.Exists(filepath.Join(path, m.metadata.ModulePath[i])) {
 			return nil
@@ -54,7 +78,13 @@ func (m *Composer) HasModulesInstalled(path string) error {
 
 // GetVersion ...
 func (m *Composer) GetVersion() (string, error) {
-	if err := m.buildCmd(VersionCmd, "."); err != nil {
+	return m.GetVersionContext(context.Background())
+}
+
+// GetVersionContext ...
+func (m *Composer) GetVersionContext(ctx context.Context) (string, error) {
+	ctx = m.options.Context(ctx)
+	if err := m.buildCmd(ctx, VersionCmd, "."); err != nil {
 		return "", err
 	}
 
@@ -63,27 +93,56 @@ func (m *Composer) GetVersion() (string, error) {
 
 // SetRootModule ...
 func (m *Composer) SetRootModule(path string) error {
+	return m.SetRootModuleContext(context.Background(), path)
+}
+
+// SetRootModuleContext ...
+func (m *Composer) SetRootModuleContext(ctx context.Context, path string) error {
+	ctx = m.options.Context(ctx)
 	return nil
 }
 
 // GetRootModule ...
 func (m *Composer) GetRootModule(path string) (*meta.Package, error) {
+	return m.GetRootModuleContext(context.Background(), path)
+}
+
+// GetRootModuleContext ...
+func (m *Composer) GetRootModuleContext(ctx context.Context, path string) (*meta.Package, error) {
+	ctx = m.options.Context(ctx)
 	return nil, nil
 }
 
 // ListModulesWithDeps ...
 func (m *Composer) ListModulesWithDeps(path string, globalSettingFile string) ([]meta.Package, error) {
-	return m.ListUsedModules(path)
+	return m.ListModulesWithDepsContext(context.Background(), path, globalSettingFile)
+}
+
+// ListModulesWithDepsContext ...
+func (m *Composer) ListModulesWithDepsContext(ctx context.Context, path string, globalSettingFile string) ([]meta.Package, error) {
+	ctx = m.options.Context(ctx)
+	modules, err := m.ListUsedModulesContext(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.options.Filter(modules), nil
 }
 
 // ListUsedModules...
 func (m *Composer) ListUsedModules(path string) ([]meta.Package, error) {
-	modules, err := m.getModulesFromComposerLockFile(path)
+	return m.ListUsedModulesContext(context.Background(), path)
+}
+
+// ListUsedModulesContext...
+func (m *Composer) ListUsedModulesContext(ctx context.Context, path string) ([]meta.Package, error) {
+	ctx = m.options.Context(ctx)
+	modules, err := m.getModulesFromComposerLockFile(ctx, path)
 	if err != nil {
 		return nil, errFailedToReadComposerFile
 	}
 
-	treeList, err := m.getTreeListFromComposerShowTree(path)
+	treeList, err := m.getTreeListFromComposerShowTree(ctx, path)
 	if err != nil {
 		return nil, errFailedToShowComposerTree
 	}
@@ -91,6 +150,7 @@ func (m *Composer) ListUsedModules(path string) ([]meta.Package, error) {
 	for _, treeComponent := range treeList.Installed {
 		addTreeComponentsToModule(treeComponent, modules)
 	}
+	meta.Sort(modules)
 
 	return modules, nil
 }
@@ -2,6 +2,8 @@
 
 package composer
 
+import "encoding/json"
+
 type LockFile struct {
 	Packages    []LockPackage
 	PackagesDev []LockPackage `json:"packages-dev"`
@@ -58,13 +60,32 @@ type JSONObject struct {
 	Description string   `json:"description"`
 	Keywords    []string `json:"keywords"`
 	Homepage    string   `json:"homepage"`
-	License     string   `json:"license"`
+	License     Licenses `json:"license"`
 	Authors     []struct {
 		Name  string `json:"name"`
 		Email string `json:"email"`
 	} `json:"authors"`
 }
 
+// Licenses are the licenses of composer.json, either a single one or a
+// list of licenses to choose from
+type Licenses []string
+
+func (l *Licenses) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*l = Licenses{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*l = list
+
+	return nil
+}
+
 type PackageJSONObject struct {
 	Name       string `json:"name"`
 	Title      string `json:"title"`
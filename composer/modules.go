@@ -4,18 +4,20 @@ package composer
 
 import (
 	"bytes"
-	"crypto/sha1"
-	"encoding/hex"
+	"context"
 	"encoding/json"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/opensbom-generator/parsers/internal/helper"
+	"github.com/opensbom-generator/parsers/internal/license"
 	"github.com/opensbom-generator/parsers/meta"
+	"github.com/opensbom-generator/parsers/purl"
 )
 
-func (m *Composer) getRootProjectInfo(path string) (meta.Package, error) {
-	if err := m.buildCmd(projectInfoCmd, path); err != nil {
+func (m *Composer) getRootProjectInfo(ctx context.Context, path string) (meta.Package, error) {
+	if err := m.buildCmd(ctx, projectInfoCmd, path); err != nil {
 		return meta.Package{}, err
 	}
 
@@ -35,51 +37,44 @@ func (m *Composer) getRootProjectInfo(path string) (meta.Package, error) {
 		return meta.Package{}, errRootProject
 	}
 
-	module := convertProjectInfoToModule(projectInfo, path)
+	module := convertProjectInfoToModule(ctx, projectInfo, path)
 	return module, nil
 }
 
-func convertProjectInfoToModule(project ProjectInfo, path string) meta.Package {
+func convertProjectInfoToModule(ctx context.Context, project ProjectInfo, path string) meta.Package {
 	version := normalizePackageVersion(project.Versions[0])
 	packageURL := genComposerURL(project.Name)
 
 	if packageURL == "" {
-		composerJSON, _ := getComposerJSONFileData()
+		composerJSON, _ := getComposerJSONFileData(path)
 		packageURL = composerJSON.Homepage
 	}
 
-	packageDownloadLocation := rootPackageDownloadLocation(packageURL)
+	packageDownloadLocation := rootPackageDownloadLocation(path, packageURL)
 
-	checkSumValue := readCheckSum(packageURL)
 	name := getName(project.Name)
-	supplier := rootProjectSupplier(name)
+	supplier := rootProjectSupplier(path, name)
 
 	module := meta.Package{
-		Name:       name,
-		Version:    version,
-		Root:       true,
-		PackageURL: packageURL,
-		Checksum: meta.Checksum{
-			Algorithm: meta.HashAlgoSHA1,
-			Value:     checkSumValue,
-		},
+		Name:                    name,
+		Version:                 version,
+		Root:                    true,
+		PackageURL:              purl.Composer(project.Name, version),
 		PackageDownloadLocation: packageDownloadLocation,
 		Supplier:                supplier,
 	}
+	// the project being scanned has no dist archive
+	module.AddChecksum(meta.Checksum{Algorithm: meta.HashAlgoSHA1, Content: []byte(packageURL), Synthetic: true})
 
-	licensePkg, err := helper.GetLicenses(path)
-	if err == nil {
-		module.LicenseDeclared = helper.BuildLicenseDeclared(licensePkg.ID)
-		module.LicenseConcluded = helper.BuildLicenseConcluded(licensePkg.ID)
-		module.Copyright = helper.GetCopyright(licensePkg.ExtractedText)
-		module.CommentsLicense = licensePkg.Comments
-	}
+	composerJSON, _ := getComposerJSONFileData(path)
+	helper.SetLicenses(ctx, &module, license.Or(composerJSON.License...), path)
+	module.Copyright = helper.ScanCopyright(path)
 
 	return module
 }
 
-func rootPackageDownloadLocation(defaultValue string) string {
-	packageJSON, _ := getPackageJSONFileData()
+func rootPackageDownloadLocation(path string, defaultValue string) string {
+	packageJSON, _ := getPackageJSONFileData(path)
 	packageDownloadLocation := packageJSON.Repository.URL
 
 	if packageDownloadLocation == "" {
@@ -100,8 +95,8 @@ func rootPackageDownloadLocation(defaultValue string) string {
 	return packageDownloadLocation
 }
 
-func rootProjectSupplier(projectName string) meta.Supplier {
-	composerJSON, _ := getComposerJSONFileData()
+func rootProjectSupplier(path string, projectName string) meta.Supplier {
+	composerJSON, _ := getComposerJSONFileData(path)
 	if len(composerJSON.Authors) > 0 {
 		author := composerJSON.Authors[0]
 		return meta.Supplier{
@@ -117,8 +112,8 @@ func rootProjectSupplier(projectName string) meta.Supplier {
 	}
 }
 
-func (m *Composer) getTreeListFromComposerShowTree(path string) (TreeList, error) {
-	if err := m.buildCmd(ShowModulesCmd, path); err != nil {
+func (m *Composer) getTreeListFromComposerShowTree(ctx context.Context, path string) (TreeList, error) {
+	if err := m.buildCmd(ctx, ShowModulesCmd, path); err != nil {
 		return TreeList{}, err
 	}
 
@@ -175,6 +170,8 @@ func addTreeComponentsToModule(treeComponent TreeComponent, modules []meta.Packa
 	return true
 }
 
+// addSubModuleToAModule adds a runtime edge, require-dev is only honoured for
+// the root project
 func addSubModuleToAModule(modules []meta.Package, moduleIndex int, subModule meta.Package) {
 	modules[moduleIndex].Packages[subModule.Name] = &meta.Package{
 		Name:             subModule.Name,
@@ -184,6 +181,7 @@ func addSubModuleToAModule(modules []meta.Package, moduleIndex int, subModule me
 		Supplier:         subModule.Supplier,
 		PackageURL:       subModule.PackageURL,
 		Checksum:         subModule.Checksum,
+		Checksums:        subModule.Checksums,
 		PackageHomePage:  subModule.PackageHomePage,
 		LicenseConcluded: subModule.LicenseConcluded,
 		LicenseDeclared:  subModule.LicenseDeclared,
@@ -192,11 +190,12 @@ func addSubModuleToAModule(modules []meta.Package, moduleIndex int, subModule me
 		Copyright:        subModule.Copyright,
 		PackageComment:   subModule.PackageComment,
 		Root:             subModule.Root,
+		Scope:            meta.ScopeRuntime,
 	}
 }
 
-func getComposerLockFileData() (LockFile, error) {
-	raw, err := os.ReadFile(ComposerLockFileName)
+func getComposerLockFileData(path string) (LockFile, error) {
+	raw, err := os.ReadFile(filepath.Join(path, ComposerLockFileName))
 	if err != nil {
 		return LockFile{}, err
 	}
@@ -210,8 +209,8 @@ func getComposerLockFileData() (LockFile, error) {
 	return fileData, nil
 }
 
-func getComposerJSONFileData() (JSONObject, error) {
-	raw, err := os.ReadFile(ComposerJSONFileName)
+func getComposerJSONFileData(path string) (JSONObject, error) {
+	raw, err := os.ReadFile(filepath.Join(path, ComposerJSONFileName))
 	if err != nil {
 		return JSONObject{}, err
 	}
@@ -225,8 +224,8 @@ func getComposerJSONFileData() (JSONObject, error) {
 	return fileData, nil
 }
 
-func getPackageJSONFileData() (PackageJSONObject, error) {
-	raw, err := os.ReadFile(PackageJSON)
+func getPackageJSONFileData(path string) (PackageJSONObject, error) {
+	raw, err := os.ReadFile(filepath.Join(path, PackageJSON))
 	if err != nil {
 		return PackageJSONObject{}, err
 	}
@@ -240,15 +239,15 @@ func getPackageJSONFileData() (PackageJSONObject, error) {
 	return fileData, nil
 }
 
-func (m *Composer) getModulesFromComposerLockFile(path string) ([]meta.Package, error) {
+func (m *Composer) getModulesFromComposerLockFile(ctx context.Context, path string) ([]meta.Package, error) {
 	modules := make([]meta.Package, 0)
 
-	info, err := getComposerLockFileData()
+	info, err := getComposerLockFileData(path)
 	if err != nil {
 		return nil, err
 	}
 
-	mainMod, err := m.getRootProjectInfo(path)
+	mainMod, err := m.getRootProjectInfo(ctx, path)
 	if err != nil {
 		return nil, err
 	}
@@ -257,14 +256,16 @@ func (m *Composer) getModulesFromComposerLockFile(path string) ([]meta.Package,
 
 	if len(info.Packages) > 0 {
 		for _, pckg := range info.Packages {
-			mod := convertLockPackageToModule(pckg)
+			mod := convertLockPackageToModule(ctx, path, pckg)
+			mod.Scope = meta.ScopeRuntime
 			modules = append(modules, mod)
 		}
 	}
 
 	if len(info.PackagesDev) > 0 {
 		for _, pckg := range info.PackagesDev {
-			mod := convertLockPackageToModule(pckg)
+			mod := convertLockPackageToModule(ctx, path, pckg)
+			mod.Scope = meta.ScopeDevelopment
 			modules = append(modules, mod)
 		}
 	}
@@ -272,33 +273,23 @@ func (m *Composer) getModulesFromComposerLockFile(path string) ([]meta.Package,
 	return modules, nil
 }
 
-func convertLockPackageToModule(dep LockPackage) meta.Package {
+func convertLockPackageToModule(ctx context.Context, path string, dep LockPackage) meta.Package {
+	version := normalizePackageVersion(dep.Version)
 	module := meta.Package{
-		Version:                 normalizePackageVersion(dep.Version),
+		Version:                 version,
 		Name:                    getName(dep.Name),
 		Root:                    false,
-		PackageURL:              genURLFromComposerPackage(dep),
+		PackageURL:              purl.Composer(dep.Name, version),
+		PackageHomePage:         dep.Homepage,
 		PackageDownloadLocation: dep.Source.URL,
-		Checksum: meta.Checksum{
-			Algorithm: meta.HashAlgoSHA1,
-			Value:     getCheckSumValue(dep),
-		},
-		Supplier:  getAuthorFromComposerLockFileDep(dep),
-		LocalPath: getLocalPath(dep),
-		Packages:  map[string]*meta.Package{},
-	}
-	path := getLocalPath(dep)
-	licensePkg, err := helper.GetLicenses(path)
-	if err == nil {
-		module.LicenseDeclared = helper.BuildLicenseDeclared(licensePkg.ID)
-		module.LicenseConcluded = helper.BuildLicenseConcluded(licensePkg.ID)
-		module.Copyright = helper.GetCopyright(licensePkg.ExtractedText)
-		module.CommentsLicense = licensePkg.Comments
-	} else if len(dep.License) > 0 {
-		licenseValue := dep.License[0]
-		module.LicenseDeclared = licenseValue
-		module.LicenseConcluded = licenseValue
+		Supplier:                getAuthorFromComposerLockFileDep(dep),
+		LocalPath:               getLocalPath(path, dep),
+		Packages:                map[string]*meta.Package{},
 	}
+	module.AddChecksum(lockChecksum(dep))
+	// the licenses listed in composer.lock are to choose from
+	helper.SetLicenses(ctx, &module, license.Or(dep.License...), module.LocalPath)
+	module.Copyright = helper.ScanCopyright(module.LocalPath)
 
 	return module
 }
@@ -368,25 +359,23 @@ func normalizePackageVersion(version string) string {
 	return parts[0]
 }
 
-func getCheckSumValue(module LockPackage) string {
-	value := module.Dist.Shasum
-	if value != "" {
-		return value
+// lockChecksum returns the SHA1 of the dist archive recorded in
+// composer.lock, or a synthetic checksum of the package URL when the lock
+// file has none, as for archives served by GitHub
+func lockChecksum(module LockPackage) meta.Checksum {
+	if c, err := meta.NewChecksum(meta.HashAlgoSHA1, module.Dist.Shasum); err == nil {
+		return c
 	}
 
-	return readCheckSum(genURLFromComposerPackage(module))
-}
-
-func readCheckSum(content string) string {
-	if content == "" {
-		return ""
+	return meta.Checksum{
+		Algorithm: meta.HashAlgoSHA1,
+		Content:   []byte(genURLFromComposerPackage(module)),
+		Synthetic: true,
 	}
-	h := sha1.New()
-	h.Write([]byte(content))
-	return hex.EncodeToString(h.Sum(nil))
 }
 
-func getLocalPath(module LockPackage) string {
-	path := "./vendor/" + module.Name
-	return path
+// getLocalPath returns the directory composer installed a dependency of
+// the project in path to
+func getLocalPath(path string, module LockPackage) string {
+	return filepath.Join(path, "vendor", filepath.FromSlash(module.Name))
 }
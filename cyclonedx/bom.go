@@ -0,0 +1,334 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cyclonedx converts the packages returned by the parsers into
+// CycloneDX 1.5 BOMs, serialised as JSON or XML.
+package cyclonedx
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/opensbom-generator/parsers/meta"
+)
+
+// SpecVersion is the CycloneDX specification version of the generated BOMs
+const SpecVersion = "1.5"
+
+const (
+	bomFormat   = "CycloneDX"
+	xmlns       = "http://cyclonedx.org/schema/bom/1.5"
+	defaultTool = "opensbom-generator-parsers"
+)
+
+// Component types used in generated BOMs
+const (
+	ComponentTypeApplication = "application"
+	ComponentTypeLibrary     = "library"
+)
+
+// Options configures the generated BOM. Empty fields get defaults.
+type Options struct {
+	// SerialNumber is the urn:uuid of the BOM. By default it is derived from
+	// the components of the BOM.
+	SerialNumber string
+	// Timestamp is the creation time, defaults to now
+	Timestamp time.Time
+	// Tool is the name of the tool reported in the metadata
+	Tool string
+}
+
+// BOM is a CycloneDX bill of materials
+type BOM struct {
+	XMLName      xml.Name     `json:"-" xml:"bom"`
+	XMLNS        string       `json:"-" xml:"xmlns,attr"`
+	BOMFormat    string       `json:"bomFormat" xml:"-"`
+	SpecVersion  string       `json:"specVersion" xml:"-"`
+	SerialNumber string       `json:"serialNumber" xml:"serialNumber,attr"`
+	Version      int          `json:"version" xml:"version,attr"`
+	Metadata     Metadata     `json:"metadata" xml:"metadata"`
+	Components   []Component  `json:"components" xml:"components>component"`
+	Dependencies []Dependency `json:"dependencies" xml:"dependencies>dependency"`
+}
+
+// Metadata describes the BOM itself
+type Metadata struct {
+	Timestamp string     `json:"timestamp" xml:"timestamp"`
+	Tools     Tools      `json:"tools" xml:"tools"`
+	Component *Component `json:"component,omitempty" xml:"component,omitempty"`
+}
+
+// Tools lists the tools that created the BOM
+type Tools struct {
+	Components []Component `json:"components" xml:"components>component"`
+}
+
+// Component is a CycloneDX component
+type Component struct {
+	Type               string                `json:"type" xml:"type,attr"`
+	BOMRef             string                `json:"bom-ref,omitempty" xml:"bom-ref,attr,omitempty"`
+	Supplier           *OrganizationalEntity `json:"supplier,omitempty" xml:"supplier,omitempty"`
+	Name               string                `json:"name" xml:"name"`
+	Version            string                `json:"version,omitempty" xml:"version,omitempty"`
+	Description        string                `json:"description,omitempty" xml:"description,omitempty"`
+	Hashes             Hashes                `json:"hashes,omitempty" xml:"hashes,omitempty"`
+	Licenses           Licenses              `json:"licenses,omitempty" xml:"licenses,omitempty"`
+	Copyright          string                `json:"copyright,omitempty" xml:"copyright,omitempty"`
+	PackageURL         string                `json:"purl,omitempty" xml:"purl,omitempty"`
+	ExternalReferences ExternalReferences    `json:"externalReferences,omitempty" xml:"externalReferences,omitempty"`
+}
+
+// OrganizationalEntity is the supplier of a component
+type OrganizationalEntity struct {
+	Name    string    `json:"name,omitempty" xml:"name,omitempty"`
+	Contact []Contact `json:"contact,omitempty" xml:"contact,omitempty"`
+}
+
+// Contact is a person or team to reach out to
+type Contact struct {
+	Name  string `json:"name,omitempty" xml:"name,omitempty"`
+	Email string `json:"email,omitempty" xml:"email,omitempty"`
+}
+
+// Hashes lists the digests of a component
+type Hashes []Hash
+
+// Hash is a component digest
+type Hash struct {
+	Algorithm string `json:"alg" xml:"alg,attr"`
+	Content   string `json:"content" xml:",chardata"`
+}
+
+// ExternalReferences lists the resources related to a component
+type ExternalReferences []ExternalReference
+
+// ExternalReference points to a resource related to the component
+type ExternalReference struct {
+	Type string `json:"type" xml:"type,attr"`
+	URL  string `json:"url" xml:"url"`
+}
+
+// Dependency lists the components a component depends on
+type Dependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn,omitempty"`
+}
+
+// builder keeps the state needed to turn a package tree into a BOM
+type builder struct {
+	components []Component
+	refs       map[string]string
+	used       map[string]bool
+	deps       map[string][]string
+}
+
+// New builds a CycloneDX BOM from the packages returned by a plugin. The
+// first root package describes the BOM, every other package becomes a
+// component and the Packages maps become the dependencies section.
+func New(pkgs []meta.Package, opts Options) *BOM {
+	b := &builder{
+		components: []Component{},
+		refs:       map[string]string{},
+		used:       map[string]bool{},
+		deps:       map[string][]string{},
+	}
+
+	for i := range pkgs {
+		b.addComponent(&pkgs[i])
+	}
+	meta.WalkDependencies(pkgs, b.addDependencies)
+
+	bom := &BOM{
+		XMLNS:        xmlns,
+		BOMFormat:    bomFormat,
+		SpecVersion:  SpecVersion,
+		Version:      1,
+		Components:   []Component{},
+		Dependencies: make([]Dependency, 0, len(b.components)),
+	}
+
+	rootRef := ""
+	for i := range pkgs {
+		if pkgs[i].Root {
+			rootRef = b.refs[meta.Key(&pkgs[i])]
+			break
+		}
+	}
+	for i := range b.components {
+		c := b.components[i]
+		if c.BOMRef == rootRef && bom.Metadata.Component == nil {
+			c.Type = ComponentTypeApplication
+			bom.Metadata.Component = &c
+		} else {
+			bom.Components = append(bom.Components, c)
+		}
+		bom.Dependencies = append(bom.Dependencies, Dependency{
+			Ref:       c.BOMRef,
+			DependsOn: b.deps[c.BOMRef],
+		})
+	}
+
+	tool := opts.Tool
+	if tool == "" {
+		tool = defaultTool
+	}
+	bom.Metadata.Tools = Tools{Components: []Component{{Type: ComponentTypeApplication, Name: tool}}}
+
+	timestamp := opts.Timestamp
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+	bom.Metadata.Timestamp = timestamp.UTC().Format(time.RFC3339)
+
+	bom.SerialNumber = opts.SerialNumber
+	if bom.SerialNumber == "" {
+		bom.SerialNumber = b.serialNumber()
+	}
+
+	return bom
+}
+
+// addComponent adds p unless a package with the same name and version was
+// already added, and returns its bom-ref
+func (b *builder) addComponent(p *meta.Package) string {
+	k := meta.Key(p)
+	if ref, ok := b.refs[k]; ok {
+		return ref
+	}
+
+	ref := b.newRef(p)
+	b.refs[k] = ref
+	b.components = append(b.components, convert(p, ref))
+
+	return ref
+}
+
+// addDependencies records the dependencies of p
+func (b *builder) addDependencies(p *meta.Package, deps []*meta.Package) {
+	ref := b.refs[meta.Key(p)]
+	seen := map[string]bool{}
+	for _, dep := range deps {
+		depRef := b.addComponent(dep)
+		if depRef == ref || seen[depRef] {
+			continue
+		}
+		seen[depRef] = true
+		b.deps[ref] = append(b.deps[ref], depRef)
+	}
+	sort.Strings(b.deps[ref])
+}
+
+// newRef returns a unique bom-ref for the package. The purl is used when
+// present as it is stable across runs.
+func (b *builder) newRef(p *meta.Package) string {
+	base := p.PackageURL
+	if !strings.HasPrefix(base, "pkg:") {
+		base = "pkg-" + p.Name
+		if p.Version != "" {
+			base += "@" + p.Version
+		}
+	}
+
+	ref := base
+	for i := 2; b.used[ref]; i++ {
+		ref = fmt.Sprintf("%s-%d", base, i)
+	}
+	b.used[ref] = true
+
+	return ref
+}
+
+// serialNumber derives a stable urn:uuid from the components of the BOM
+func (b *builder) serialNumber() string {
+	h := sha256.New()
+	for i := range b.components {
+		fmt.Fprintf(h, "%s\n", b.components[i].BOMRef)
+	}
+	sum := h.Sum(nil)[:16]
+	sum[6] = (sum[6] & 0x0f) | 0x50 // name based version
+	sum[8] = (sum[8] & 0x3f) | 0x80 // RFC 4122 variant
+
+	s := hex.EncodeToString(sum)
+	return fmt.Sprintf("urn:uuid:%s-%s-%s-%s-%s", s[0:8], s[8:12], s[12:16], s[16:20], s[20:32])
+}
+
+func convert(p *meta.Package, ref string) Component {
+	c := Component{
+		Type:        ComponentTypeLibrary,
+		BOMRef:      ref,
+		Name:        p.Name,
+		Version:     p.Version,
+		Description: p.PackageComment,
+		Copyright:   strings.TrimSpace(p.Copyright),
+		Licenses:    licenses(p),
+	}
+	if strings.HasPrefix(p.PackageURL, "pkg:") {
+		c.PackageURL = p.PackageURL
+	}
+
+	if p.Supplier.Name != "" {
+		c.Supplier = &OrganizationalEntity{Name: p.Supplier.Name}
+		if p.Supplier.Email != "" && !strings.EqualFold(p.Supplier.Email, "none") {
+			c.Supplier.Contact = []Contact{{Name: p.Supplier.Name, Email: p.Supplier.Email}}
+		}
+	}
+
+	for _, checksum := range p.ArtifactChecksums() {
+		if h, ok := hash(checksum); ok {
+			c.Hashes = append(c.Hashes, h)
+		}
+	}
+
+	if u := url(p.PackageHomePage); u != "" {
+		c.ExternalReferences = append(c.ExternalReferences, ExternalReference{Type: "website", URL: u})
+	}
+	if u := url(p.PackageDownloadLocation); u != "" {
+		c.ExternalReferences = append(c.ExternalReferences, ExternalReference{Type: "distribution", URL: u})
+	}
+
+	return c
+}
+
+// hashAlgorithms maps checksum algorithms to their CycloneDX names. The
+// ones missing from the map are not supported by CycloneDX.
+var hashAlgorithms = map[meta.HashAlgorithm]string{
+	meta.HashAlgoMD5:         "MD5",
+	meta.HashAlgoSHA1:        "SHA-1",
+	meta.HashAlgoSHA256:      "SHA-256",
+	meta.HashAlgoSHA384:      "SHA-384",
+	meta.HashAlgoSHA512:      "SHA-512",
+	meta.HashAlgoSHA3_256:    "SHA3-256",
+	meta.HashAlgoSHA3_384:    "SHA3-384",
+	meta.HashAlgoSHA3_512:    "SHA3-512",
+	meta.HashAlgoBLAKE2b_256: "BLAKE2b-256",
+	meta.HashAlgoBLAKE2b_384: "BLAKE2b-384",
+	meta.HashAlgoBLAKE2b_512: "BLAKE2b-512",
+	meta.HashAlgoBLAKE3:      "BLAKE3",
+}
+
+func hash(c meta.Checksum) (Hash, bool) {
+	alg, ok := hashAlgorithms[c.Algorithm]
+	if !ok || (c.Value == "" && len(c.Content) == 0) {
+		return Hash{}, false
+	}
+	value, err := c.Digest()
+	if err != nil {
+		return Hash{}, false
+	}
+
+	return Hash{Algorithm: alg, Content: strings.TrimSpace(value)}, true
+}
+
+// url returns the value if it is an absolute URL
+func url(value string) string {
+	value = strings.TrimSpace(value)
+	if strings.Contains(value, "://") {
+		return value
+	}
+
+	return ""
+}
@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cyclonedx
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/opensbom-generator/parsers/meta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPackages() []meta.Package {
+	dep := &meta.Package{
+		Name:                    "left-pad",
+		Version:                 "1.3.0",
+		PackageURL:              "pkg:npm/left-pad@1.3.0",
+		PackageDownloadLocation: "https://registry.npmjs.org/left-pad/-/left-pad-1.3.0.tgz",
+		LicenseDeclared:         "WTFPL",
+		Checksum:                meta.Checksum{Algorithm: meta.HashAlgoSHA1, Value: "abc"},
+		Packages:                map[string]*meta.Package{},
+	}
+	other := &meta.Package{
+		Name:             "other",
+		Version:          "0.1.0",
+		LicenseConcluded: "MIT OR Apache-2.0",
+		Supplier:         meta.Supplier{Name: "Jane", Email: "jane@example.com"},
+		Checksum:         meta.Checksum{Algorithm: meta.HashAlgoMD6, Value: "unsupported"},
+		Packages:         map[string]*meta.Package{"left-pad": dep},
+	}
+
+	return []meta.Package{
+		{
+			Name:             "app",
+			Version:          "1.0.0",
+			Root:             true,
+			PackageURL:       "pkg:npm/app@1.0.0",
+			PackageHomePage:  "https://example.com",
+			LicenseConcluded: "Unlicense/MIT",
+			Packages:         map[string]*meta.Package{"left-pad": dep, "other": other},
+		},
+		*dep,
+	}
+}
+
+func testOptions() Options {
+	return Options{Timestamp: time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)}
+}
+
+func TestNew(t *testing.T) {
+	bom := New(testPackages(), testOptions())
+
+	assert.Equal(t, "CycloneDX", bom.BOMFormat)
+	assert.Equal(t, "1.5", bom.SpecVersion)
+	assert.Regexp(t, `^urn:uuid:[0-9a-f]{8}-[0-9a-f]{4}-5[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, bom.SerialNumber)
+	assert.Equal(t, "2023-01-02T03:04:05Z", bom.Metadata.Timestamp)
+
+	root := bom.Metadata.Component
+	require.NotNil(t, root)
+	assert.Equal(t, ComponentTypeApplication, root.Type)
+	assert.Equal(t, "pkg:npm/app@1.0.0", root.BOMRef)
+	assert.Equal(t, Licenses{{License: &License{Name: "Unlicense/MIT"}}}, root.Licenses)
+	assert.Equal(t, ExternalReferences{{Type: "website", URL: "https://example.com"}}, root.ExternalReferences)
+
+	require.Len(t, bom.Components, 2)
+	leftPad := bom.Components[0]
+	assert.Equal(t, ComponentTypeLibrary, leftPad.Type)
+	assert.Equal(t, Hashes{{Algorithm: "SHA-1", Content: "abc"}}, leftPad.Hashes)
+	assert.Equal(t, Licenses{{License: &License{ID: "WTFPL"}}}, leftPad.Licenses)
+
+	other := bom.Components[1]
+	assert.Equal(t, "pkg-other@0.1.0", other.BOMRef)
+	assert.Empty(t, other.Hashes)
+	assert.Equal(t, Licenses{{Expression: "MIT OR Apache-2.0"}}, other.Licenses)
+	assert.Equal(t, &OrganizationalEntity{
+		Name:    "Jane",
+		Contact: []Contact{{Name: "Jane", Email: "jane@example.com"}},
+	}, other.Supplier)
+
+	assert.Equal(t, []Dependency{
+		{Ref: "pkg:npm/app@1.0.0", DependsOn: []string{"pkg-other@0.1.0", "pkg:npm/left-pad@1.3.0"}},
+		{Ref: "pkg:npm/left-pad@1.3.0"},
+		{Ref: "pkg-other@0.1.0", DependsOn: []string{"pkg:npm/left-pad@1.3.0"}},
+	}, bom.Dependencies)
+}
+
+func TestNewIsDeterministic(t *testing.T) {
+	assert.Equal(t, New(testPackages(), testOptions()), New(testPackages(), testOptions()))
+}
+
+func TestWriteJSON(t *testing.T) {
+	buf := &bytes.Buffer{}
+	require.NoError(t, New(testPackages(), testOptions()).WriteJSON(buf))
+
+	decoded := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, "CycloneDX", decoded["bomFormat"])
+	assert.Equal(t, "1.5", decoded["specVersion"])
+	assert.Len(t, decoded["components"], 2)
+	assert.Len(t, decoded["dependencies"], 3)
+	assert.Contains(t, buf.String(), `"expression": "MIT OR Apache-2.0"`)
+	assert.Contains(t, buf.String(), `"bom-ref": "pkg:npm/left-pad@1.3.0"`)
+}
+
+func TestWriteXML(t *testing.T) {
+	buf := &bytes.Buffer{}
+	require.NoError(t, New(testPackages(), testOptions()).WriteXML(buf))
+
+	out := buf.String()
+	assert.Contains(t, out, `<bom xmlns="http://cyclonedx.org/schema/bom/1.5" serialNumber="urn:uuid:`)
+	assert.Contains(t, out, `<hash alg="SHA-1">abc</hash>`)
+	assert.Contains(t, out, "<expression>MIT OR Apache-2.0</expression>")
+	assert.Contains(t, out, "<id>WTFPL</id>")
+	assert.NotContains(t, out, "<hashes></hashes>")
+	assert.Contains(t, out, `<dependency ref="pkg-other@0.1.0">`)
+	assert.Contains(t, out, `<reference type="distribution">`)
+
+	var decoded struct {
+		Components []struct {
+			Name string `xml:"name"`
+		} `xml:"components>component"`
+	}
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &decoded))
+	assert.Len(t, decoded.Components, 2)
+}
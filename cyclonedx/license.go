@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cyclonedx
+
+import (
+	"encoding/xml"
+	"strings"
+
+	"github.com/opensbom-generator/parsers/meta"
+	"github.com/opensbom-generator/parsers/spdx"
+)
+
+// Licenses holds either a single SPDX expression or a list of licenses
+type Licenses []LicenseChoice
+
+// LicenseChoice is either a license or an expression
+type LicenseChoice struct {
+	License    *License `json:"license,omitempty"`
+	Expression string   `json:"expression,omitempty"`
+}
+
+// License is identified by its SPDX id or, for other licenses, its name
+type License struct {
+	ID   string `json:"id,omitempty" xml:"id,omitempty"`
+	Name string `json:"name,omitempty" xml:"name,omitempty"`
+}
+
+// MarshalXML writes the licenses without the wrapper element used in JSON
+func (l Licenses) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for i := range l {
+		var err error
+		if l[i].License != nil {
+			err = e.EncodeElement(l[i].License, xml.StartElement{Name: xml.Name{Local: "license"}})
+		} else {
+			err = e.EncodeElement(l[i].Expression, xml.StartElement{Name: xml.Name{Local: "expression"}})
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+// licenses maps the concluded license, or the declared one when nothing was
+// concluded. Compound values become expressions, values that are not valid
+// SPDX expressions are kept as license names.
+func licenses(p *meta.Package) Licenses {
+	value := strings.TrimSpace(p.LicenseConcluded)
+	if isUnknown(value) {
+		value = strings.TrimSpace(p.LicenseDeclared)
+	}
+	if isUnknown(value) {
+		return nil
+	}
+
+	expr, ok := spdx.LicenseExpression(value)
+	switch {
+	case !ok:
+		return Licenses{{License: &License{Name: value}}}
+	case strings.ContainsAny(expr, " ()"):
+		return Licenses{{Expression: expr}}
+	case strings.HasPrefix(expr, "LicenseRef-"):
+		return Licenses{{License: &License{Name: expr}}}
+	}
+
+	return Licenses{{License: &License{ID: expr}}}
+}
+
+func isUnknown(value string) bool {
+	return value == "" || value == spdx.NoAssertion || value == spdx.None
+}
@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cyclonedx
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io"
+)
+
+// WriteJSON writes the BOM in the CycloneDX JSON format
+func (b *BOM) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+
+	return enc.Encode(b)
+}
+
+// WriteXML writes the BOM in the CycloneDX XML format
+func (b *BOM) WriteXML(w io.Writer) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(b); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// MarshalXML writes the dependency with its dependencies as nested elements
+func (d Dependency) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "ref"}, Value: d.Ref})
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for _, ref := range d.DependsOn {
+		dep := xml.StartElement{
+			Name: xml.Name{Local: "dependency"},
+			Attr: []xml.Attr{{Name: xml.Name{Local: "ref"}, Value: ref}},
+		}
+		if err := e.EncodeToken(dep); err != nil {
+			return err
+		}
+		if err := e.EncodeToken(dep.End()); err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+// MarshalXML writes every hash as a hash element
+func (h Hashes) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeList(e, start, "hash", h)
+}
+
+// MarshalXML writes every external reference as a reference element
+func (r ExternalReferences) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeList(e, start, "reference", r)
+}
+
+// encodeList writes the items wrapped in the start element. Lists are
+// written by hand because the a>b tag syntax keeps empty wrappers.
+func encodeList[T any](e *xml.Encoder, start xml.StartElement, name string, items []T) error {
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for i := range items {
+		if err := e.EncodeElement(items[i], xml.StartElement{Name: xml.Name{Local: name}}); err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
@@ -3,9 +3,9 @@
 package gem
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"os/exec"
 	"path/filepath"
 	"strings"
 
@@ -17,6 +17,7 @@ import (
 type Gem struct {
 	metadata   plugin.Metadata
 	rootModule *meta.Package
+	options    plugin.Options
 }
 
 var (
@@ -26,6 +27,10 @@ var (
 	errInvalidProjectType = errors.New("* Tool only supports ruby gems projects with valid .gemspec manifest in project root directory")
 )
 
+func init() {
+	plugin.Register("bundler", func() plugin.Plugin { return New() })
+}
+
 // New ...
 func New() *Gem {
 	return &Gem{
@@ -34,10 +39,27 @@ func New() *Gem {
 			Slug:       "bundler",
 			Manifest:   []string{"Gemfile", "Gemfile.lock", "gems.rb", "gems.locked"},
 			ModulePath: []string{"vendor/bundle"},
+			ReadOnly:   true,
+			Capabilities: plugin.Capabilities{
+				Executables: []plugin.Executable{
+					{Name: "gem"},
+					{Name: "bundler", Optional: true},
+				},
+				Network:          plugin.NetworkOptional,
+				MutatesWorkspace: true,
+				InstalledModules: true,
+				Lockfiles:        []string{"Gemfile.lock", "gems.locked"},
+				TransitiveGraph:  true,
+			},
 		},
 	}
 }
 
+// SetOptions configures how modules are listed
+func (g *Gem) SetOptions(opts plugin.Options) {
+	g.options = opts
+}
+
 // GetMetadata ...
 func (g *Gem) GetMetadata() plugin.Metadata {
 	return g.metadata
@@ -56,11 +78,22 @@ func (g *Gem) IsValid(path string) bool {
 
 // HasModulesInstalled ...
 func (g *Gem) HasModulesInstalled(path string) error {
+	return g.HasModulesInstalledContext(context.Background(), path)
+}
+
+// HasModulesInstalledContext ...
+func (g *Gem) HasModulesInstalledContext(ctx context.Context, path string) error {
+	ctx = g.options.Context(ctx)
 	if !validateProjectType(path) {
 		return errInvalidProjectType
 	}
 
-	hasRake, _, hasModule := hasRakefile(path), ensurePlatform(path), false
+	// the Rakefile and the platform of the lock file only help later bundler
+	// runs, read-only scans leave the project as is
+	hasRake, hasModule := true, false
+	if !helper.IsReadOnly(ctx) {
+		hasRake, _ = hasRakefile(path), ensurePlatform(path)
+	}
 	for i := range g.metadata.ModulePath {
 		if helper.Exists(filepath.Join(path, g.metadata.ModulePath[i])) {
 			hasModule = true
@@ -75,8 +108,13 @@ func (g *Gem) HasModulesInstalled(path string) error {
 
 // GetVersion ...
 func (g *Gem) GetVersion() (string, error) {
-	cmd := exec.Command("bundler", "version")
-	output, err := cmd.Output()
+	return g.GetVersionContext(context.Background())
+}
+
+// GetVersionContext ...
+func (g *Gem) GetVersionContext(ctx context.Context) (string, error) {
+	ctx = g.options.Context(ctx)
+	output, err := helper.Output(ctx, "", "bundler", "version")
 	if err != nil {
 		return "", err
 	}
@@ -95,7 +133,13 @@ func (g *Gem) GetVersion() (string, error) {
 
 // SetRootModule ...
 func (g *Gem) SetRootModule(path string) error {
-	module, err := g.GetRootModule(path)
+	return g.SetRootModuleContext(context.Background(), path)
+}
+
+// SetRootModuleContext ...
+func (g *Gem) SetRootModuleContext(ctx context.Context, path string) error {
+	ctx = g.options.Context(ctx)
+	module, err := g.GetRootModuleContext(ctx, path)
 	if err != nil {
 		return err
 	}
@@ -107,11 +151,17 @@ func (g *Gem) SetRootModule(path string) error {
 
 // GetRootModule...
 func (g *Gem) GetRootModule(path string) (*meta.Package, error) {
-	if err := g.HasModulesInstalled(path); err != nil {
+	return g.GetRootModuleContext(context.Background(), path)
+}
+
+// GetRootModuleContext...
+func (g *Gem) GetRootModuleContext(ctx context.Context, path string) (*meta.Package, error) {
+	ctx = g.options.Context(ctx)
+	if err := g.HasModulesInstalledContext(ctx, path); err != nil {
 		return &meta.Package{}, err
 	}
 
-	return getGemRootModule(path)
+	return getGemRootModule(ctx, path)
 }
 
 // GetModule ...
@@ -121,15 +171,32 @@ func (g *Gem) GetModule(path string) ([]meta.Package, error) {
 
 // ListUsedModules ...
 func (g *Gem) ListUsedModules(path string) ([]meta.Package, error) {
+	return g.ListUsedModulesContext(context.Background(), path)
+}
+
+// ListUsedModulesContext ...
+func (g *Gem) ListUsedModulesContext(ctx context.Context, path string) ([]meta.Package, error) {
+	ctx = g.options.Context(ctx)
 	var globalSettingFile string
-	return g.ListModulesWithDeps(path, globalSettingFile)
+	return g.ListModulesWithDepsContext(ctx, path, globalSettingFile)
 }
 
 // ListModulesWithDeps ...
 func (g *Gem) ListModulesWithDeps(path string, globalSettingFile string) ([]meta.Package, error) {
-	if err := g.HasModulesInstalled(path); err != nil {
+	return g.ListModulesWithDepsContext(context.Background(), path, globalSettingFile)
+}
+
+// ListModulesWithDepsContext ...
+func (g *Gem) ListModulesWithDepsContext(ctx context.Context, path string, globalSettingFile string) ([]meta.Package, error) {
+	ctx = g.options.Context(ctx)
+	if err := g.HasModulesInstalledContext(ctx, path); err != nil {
 		return []meta.Package{}, err
 	}
 
-	return listGemRootModule(path)
+	modules, err := listGemRootModule(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.options.Filter(modules), nil
 }
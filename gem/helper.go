@@ -4,13 +4,10 @@ package gem
 
 import (
 	"bufio"
-	"crypto/sha256"
-	"encoding/hex"
+	"context"
 	"errors"
 	"fmt"
-	"io"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"runtime"
 	"strconv"
@@ -18,9 +15,10 @@ import (
 	"sync"
 
 	"github.com/opensbom-generator/parsers/meta"
-	log "github.com/sirupsen/logrus"
+	"github.com/opensbom-generator/parsers/purl"
 
 	"github.com/opensbom-generator/parsers/internal/helper"
+	"github.com/opensbom-generator/parsers/internal/license"
 )
 
 var (
@@ -116,18 +114,18 @@ type (
 )
 
 // Returns the root module
-func getGemRootModule(path string) (*meta.Package, error) {
+func getGemRootModule(ctx context.Context, path string) (*meta.Package, error) {
 	wg := sync.WaitGroup{}
 	wg.Add(1)
 	go func() {
-		initializeDepCache(&wg)
+		initializeDepCache(ctx, &wg)
 	}()
 	wg.Wait()
 
 	rootPath = &path
 	rootModule := meta.Package{}
 	rootModule.Packages = make(map[string]*meta.Package)
-	spec, err := getSpecDependencies(path)
+	spec, err := getSpecDependencies(ctx, path)
 	if err != nil {
 		return nil, err
 	}
@@ -138,25 +136,22 @@ func getGemRootModule(path string) (*meta.Package, error) {
 		supplier.Name = authors[0]
 	}
 
-	setLicenseInfo(spec.GemLocationDir, &rootModule)
 	rootModule.Name = gemName(spec.Name)
 	rootModule.Version = spec.Version
+	setLicenseInfo(ctx, spec.GemLocationDir, specLicense(spec), &rootModule)
 	rootModule.Supplier = supplier
 	rootModule.Root = true
 	rootModule.Path = spec.GemLocationDir
 	rootModule.PackageHomePage = cleanURI(spec.HomePage)
 	rootModule.PackageDownloadLocation = cleanURI(spec.HomePage)
-	rootModule.PackageURL = cleanURI(spec.HomePage)
-	rootModule.Checksum = meta.Checksum{
-		Algorithm: meta.HashAlgoSHA256,
-		Value:     spec.Checksum,
-	}
+	rootModule.PackageURL = purl.Gem(rootModule.Name, rootModule.Version)
+	rootModule.AddChecksum(gemChecksum(spec))
 
 	return &rootModule, nil
 }
 
 // Returns the root module and associated dependencies
-func listGemRootModule(path string) ([]meta.Package, error) {
+func listGemRootModule(ctx context.Context, path string) ([]meta.Package, error) {
 	rootPath = &path
 	modules := make([]meta.Package, 0)
 	noSpecs := make(map[string]bool)
@@ -177,17 +172,19 @@ func listGemRootModule(path string) ([]meta.Package, error) {
 		secondLayerModule meta.Package
 
 	// Parent Layer - Root
-	rootModule, err := getGemRootModule(path)
+	rootModule, err := getGemRootModule(ctx, path)
 	if err != nil {
 		return nil, err
 	}
 
 	modules = append(modules, *rootModule)
-	rootSpec, err := getSpecDependencies(path)
+	rootSpec, err := getSpecDependencies(ctx, path)
 	if err != nil {
 		return nil, err
 	}
 
+	devDependencies := dependencyNames(rootSpec.DevelopmentDependencies)
+
 	// Populate Child Layers
 	for _, dep := range rootSpec.Specifications {
 		// Don't include root in children
@@ -196,7 +193,7 @@ func listGemRootModule(path string) ([]meta.Package, error) {
 		}
 
 		parentLayerModule := parseSpec(dep)
-		setLicenseInfo(dep.GemLocationDir, &parentLayerModule)
+		setLicenseInfo(ctx, dep.GemLocationDir, specLicense(dep), &parentLayerModule)
 
 		for _, firstDescendant := range dep.RuntimeDependencies {
 			firstDescendantSpec, name, err := getDescendantInfo(firstDescendant)
@@ -206,7 +203,7 @@ func listGemRootModule(path string) ([]meta.Package, error) {
 				continue
 			}
 			// Add 1st Layer
-			layerOneGems, firstLayerModule = addGemLayer(firstDescendantSpec, name, &parentLayerModule, _1stLayerMapped, layerOneGems)
+			layerOneGems, firstLayerModule = addGemLayer(ctx, firstDescendantSpec, name, &parentLayerModule, _1stLayerMapped, layerOneGems)
 
 			for _, secondDescendant := range firstDescendantSpec.RuntimeDependencies {
 				secondDescendantSpec, name, err := getDescendantInfo(secondDescendant)
@@ -216,7 +213,7 @@ func listGemRootModule(path string) ([]meta.Package, error) {
 					continue
 				}
 				// Add 2nd Layer
-				layerTwoGems, secondLayerModule = addGemLayer(secondDescendantSpec, name, &firstLayerModule, _2ndLayerMapped, layerTwoGems)
+				layerTwoGems, secondLayerModule = addGemLayer(ctx, secondDescendantSpec, name, &firstLayerModule, _2ndLayerMapped, layerTwoGems)
 
 				for _, thirdDescendant := range secondDescendantSpec.RuntimeDependencies {
 					thirdDescendantSpec, name, err := getDescendantInfo(thirdDescendant)
@@ -226,10 +223,14 @@ func listGemRootModule(path string) ([]meta.Package, error) {
 						continue
 					}
 					// Add 3rd Layer
-					layerThreeGems, _ = addGemLayer(thirdDescendantSpec, name, &secondLayerModule, _3rdLayerMapped, layerThreeGems)
+					layerThreeGems, _ = addGemLayer(ctx, thirdDescendantSpec, name, &secondLayerModule, _3rdLayerMapped, layerThreeGems)
 				}
 			}
 		}
+		parentLayerModule.Scope = meta.ScopeRuntime
+		if devDependencies[parentLayerModule.Name] {
+			parentLayerModule.Scope = meta.ScopeDevelopment
+		}
 		rootModule.Packages[dep.Name] = &parentLayerModule
 		modules = append(modules, parentLayerModule)
 	}
@@ -238,11 +239,10 @@ func listGemRootModule(path string) ([]meta.Package, error) {
 	modules = append(modules, layerTwoGems...)
 	modules = append(modules, layerThreeGems...)
 
-	if len(noSpecs) > 0 {
-		for dep := range noSpecs {
-			log.Warnf("manifest for %s not found in gem paths", dep)
-		}
+	for dep := range noSpecs {
+		helper.Report(ctx, meta.SeverityWarning, meta.CodeMissingMetadata, dep, "manifest for %s not found in gem paths", dep)
 	}
+	meta.ResolveScopes(modules)
 
 	return modules, nil
 }
@@ -255,31 +255,39 @@ func parseSpec(spec Spec) meta.Package {
 		supplier.Type = meta.Person
 		supplier.Name = authors[0]
 	}
-	return meta.Package{
+	module := meta.Package{
 		Name:                    gemName(spec.Name),
 		Version:                 spec.Version,
 		Root:                    false,
 		PackageHomePage:         cleanURI(spec.HomePage),
 		PackageDownloadLocation: cleanURI(spec.HomePage),
 		Supplier:                supplier,
-		PackageURL:              cleanURI(spec.HomePage),
-		Checksum: meta.Checksum{
-			Algorithm: meta.HashAlgoSHA256,
-			Value:     spec.Checksum,
-		},
-		Packages: make(map[string]*meta.Package),
+		PackageURL:              purl.Gem(gemName(spec.Name), spec.Version),
+		Packages:                make(map[string]*meta.Package),
 	}
+	module.AddChecksum(gemChecksum(spec))
+	return module
+}
+
+// gemChecksum returns the SHA256 of the cached .gem file of spec, or a
+// synthetic checksum when the gem is not cached
+func gemChecksum(spec Spec) meta.Checksum {
+	if c, err := meta.NewChecksum(meta.HashAlgoSHA256, spec.Checksum); err == nil {
+		return c
+	}
+	return meta.SyntheticChecksum(fmt.Sprintf("%s-%s", spec.Name, spec.Version))
 }
 
 // Adds a new layer to the dependency tree
-func addGemLayer(descendant Spec, name string, parent *meta.Package, layer map[string]bool, gems []meta.Package) ([]meta.Package, meta.Package) {
+func addGemLayer(ctx context.Context, descendant Spec, name string, parent *meta.Package, layer map[string]bool, gems []meta.Package) ([]meta.Package, meta.Package) {
 	descendantModule := parseSpec(descendant)
-	setLicenseInfo(descendant.GemLocationDir, &descendantModule)
+	setLicenseInfo(ctx, descendant.GemLocationDir, specLicense(descendant), &descendantModule)
 	return setChildModule(name, parent, &descendantModule, layer, gems), descendantModule
 }
 
-// Sets the child of a parent module
+// Sets the child of a parent module, gems only pull their runtime dependencies
 func setChildModule(name string, parent, child *meta.Package, layer map[string]bool, gems []meta.Package) []meta.Package {
+	child.Scope = meta.ScopeRuntime
 	parent.Packages[name] = child
 	if !layer[child.Name] {
 		gems = append(gems, *child)
@@ -289,20 +297,24 @@ func setChildModule(name string, parent, child *meta.Package, layer map[string]b
 	return gems
 }
 
-// Sets license info from generic helper
-func setLicenseInfo(path string, module *meta.Package) {
-	licensePkg, err := helper.GetLicenses(path)
-	if err == nil {
-		module.LicenseDeclared = helper.BuildLicenseDeclared(licensePkg.ID)
-		module.LicenseConcluded = helper.BuildLicenseConcluded(licensePkg.ID)
-		if !helper.LicenseSPDXExists(licensePkg.ID) {
-			licensePkg.ID = fmt.Sprintf("LicenseRef-%s", licensePkg.ID)
-			module.OtherLicense = append(module.OtherLicense, *licensePkg)
-		}
-		module.Copyright = helper.GetCopyright(licensePkg.ExtractedText)
-		module.CommentsLicense = licensePkg.Comments
+// Sets license and copyright info from the license declared in the gemspec
+// and the files of the gem
+func setLicenseInfo(ctx context.Context, path, declared string, module *meta.Package) {
+	if detected := helper.SetLicenses(ctx, module, declared, path); detected != nil {
 		module.LocalPath = path
 	}
+	module.Copyright = helper.ScanCopyright(path)
+}
+
+// Gets the license declared in a gemspec, the licenses listed are to
+// choose from
+func specLicense(spec Spec) string {
+	values := []string{}
+	for _, value := range append([]string{spec.License}, spec.Licenses...) {
+		values = append(values, strings.TrimSpace(cleanName(unfreeze(value))))
+	}
+
+	return license.Or(values...)
 }
 
 // Gets gem info from in-memory cache
@@ -318,13 +330,13 @@ func getDescendantInfo(child string) (Spec, string, error) {
 }
 
 // Gets parent and child dependency tree from .gemspec
-func getSpecDependencies(path string) (Spec, error) {
+func getSpecDependencies(ctx context.Context, path string) (Spec, error) {
 	manifest, err := detectManifest(path, DetectionModeSpec)
 	if err != nil {
 		return Spec{}, err
 	}
 	module := getSpecs(filepath.Join(path, manifest))
-	BuildSpecDependencies(filepath.Join(path, SpecDependencyPath), false, &module)
+	BuildSpecDependencies(ctx, filepath.Join(path, SpecDependencyPath), false, &module)
 
 	return module, nil
 }
@@ -343,18 +355,18 @@ func GetLockedDependencies(path string) ([]Package, error) {
 }
 
 // Builds parent and child dependency tree from .gemspec
-func BuildSpecDependencies(path string, isFullPath bool, module *Spec) {
+func BuildSpecDependencies(ctx context.Context, path string, isFullPath bool, module *Spec) {
 	files, err := os.ReadDir(path)
-
 	if err != nil {
-		log.Fatal(err)
+		helper.Report(ctx, meta.SeverityError, meta.CodeMissingMetadata, module.Name, "reading the specifications in %s: %v", path, err)
+		return
 	}
 
 	if !isFullPath {
 		for _, dir := range files {
 			if dir.IsDir() {
 				fullPath := filepath.Join(path, dir.Name(), SpecDefaultDir)
-				BuildSpecDependencies(fullPath, true, module)
+				BuildSpecDependencies(ctx, fullPath, true, module)
 				return
 			}
 		}
@@ -365,7 +377,7 @@ func BuildSpecDependencies(path string, isFullPath bool, module *Spec) {
 	name, version, _ := rootGem(cachePath, cleanName(module.Name))
 	versionedName := fmt.Sprintf("%s-%s", name, version)
 
-	rootSha, err := checkSum(cachePath, versionedName, true)
+	rootSha, err := checkSum(ctx, cachePath, versionedName, true)
 	if err == nil && rootSha != "" {
 		module.Checksum = rootSha
 	}
@@ -387,7 +399,7 @@ func BuildSpecDependencies(path string, isFullPath bool, module *Spec) {
 			module.Specifications = append(module.Specifications, getSpecs(specPath))
 			fileName := cleanName(strings.Replace(f.Name(), SpecExtension, "", 1))
 
-			sha, err := checkSum(cachePath, fileName, true)
+			sha, err := checkSum(ctx, cachePath, fileName, true)
 			if err == nil {
 				module.Specifications[i].Checksum = sha
 			}
@@ -403,7 +415,7 @@ func BuildSpecDependencies(path string, isFullPath bool, module *Spec) {
 				module.Specifications[i].GemLocationDir = LicensePath
 				module.Specifications[i].Version = gemVersion(fileName)
 			} else {
-				log.Error(err)
+				helper.Report(ctx, meta.SeverityWarning, meta.CodeLicenseNotDetected, fileName, "could not extract license of %s: %v", fileName, err)
 			}
 		}
 	}
@@ -512,7 +524,7 @@ func ReduceSpec(row, column string, spec *Spec) {
 		if strings.ContainsAny(row, "[]") {
 			value := fmt.Sprintf("%s%s%s", clean(row, "<", ">"), " ", clean(row, "[", "]"))
 			if !isDuplicate(value, *spec) {
-				spec.RuntimeDependencies = append(spec.RuntimeDependencies, value)
+				spec.DevelopmentDependencies = append(spec.DevelopmentDependencies, value)
 			}
 		} else {
 			_, value := strings.SplitN(strings.TrimLeft(row, " "), " ", 2)[0], strings.ReplaceAll(strings.SplitN(strings.TrimLeft(row, " "), " ", 2)[1], " ", "")
@@ -577,7 +589,7 @@ func BuildLockDependencyTree(rows []string) {
 }
 
 // Compute SHA 256 Checksum for gems
-func checkSum(path string, filename string, isFullPath bool) (string, error) { //nolint: unparam
+func checkSum(ctx context.Context, path string, filename string, isFullPath bool) (string, error) { //nolint: unparam
 	var sha string
 	files, err := os.ReadDir(path)
 	if err != nil {
@@ -588,7 +600,7 @@ func checkSum(path string, filename string, isFullPath bool) (string, error) { /
 		for _, f := range files {
 			if f.IsDir() {
 				fullPath := filepath.Join(path, f.Name(), CacheDefaultDir)
-				return checkSum(fullPath, filename, true)
+				return checkSum(ctx, fullPath, filename, true)
 			}
 		}
 	} else {
@@ -596,7 +608,7 @@ func checkSum(path string, filename string, isFullPath bool) (string, error) { /
 			return "", nil
 		}
 		if _, err := os.Stat(path); os.IsNotExist(err) {
-			path = gemDir()
+			path = gemDir(ctx)
 		}
 		var ops = runtime.GOOS
 		if strings.Contains(strings.ToLower(ops), "linux") {
@@ -611,8 +623,7 @@ func checkSum(path string, filename string, isFullPath bool) (string, error) { /
 		switch ops {
 		case "linux":
 			linuxcmd := "sha256sum"
-			cmd := exec.Command(linuxcmd, filepath.Join(path, filename+GemDefaultExtension)) //nolint: gosec
-			output, err := cmd.Output()
+			output, err := helper.Output(ctx, "", linuxcmd, filepath.Join(path, filename+GemDefaultExtension))
 			if err != nil {
 				return "", err
 			}
@@ -625,8 +636,7 @@ func checkSum(path string, filename string, isFullPath bool) (string, error) { /
 			sha = sha256
 		case "darwin":
 			osxCmd := `shasum`
-			cmd := exec.Command(osxCmd, "-a", "256", filepath.Join(path, filename+GemDefaultExtension)) //nolint: gosec
-			output, err := cmd.Output()
+			output, err := helper.Output(ctx, "", osxCmd, "-a", "256", filepath.Join(path, filename+GemDefaultExtension))
 			if err != nil {
 				return "", err
 			}
@@ -676,8 +686,7 @@ func extractRootLicense(path string) (string, string, string, error) {
 
 	files, err := os.ReadDir(path)
 	if err != nil {
-		log.Println("error extracting licence from :" + path)
-		return "", "", "", err
+		return "", "", "", fmt.Errorf("extracting licence from %s: %w", path, err)
 	}
 	licensePath = path
 	for _, f := range files {
@@ -688,7 +697,6 @@ func extractRootLicense(path string) (string, string, string, error) {
 	}
 	data, err := os.ReadFile(path)
 	if err != nil {
-		log.Println("File reading error", err)
 		return "", "", "", err
 	}
 	text = string(data)
@@ -809,6 +817,21 @@ func buildTree(linesToRead []Line) {
 	}
 }
 
+// Gets the gem names of gemspec dependency declarations
+func dependencyNames(deps []string) map[string]bool {
+	names := make(map[string]bool, len(deps))
+	for _, dep := range deps {
+		fields := strings.FieldsFunc(dep, func(r rune) bool {
+			return r == '"' || r == '\'' || r == ',' || r == ' '
+		})
+		if len(fields) > 0 {
+			names[fields[0]] = true
+		}
+	}
+
+	return names
+}
+
 // Get child dependency info
 func childDepInfo(value string) (string, string, string) { //nolint: unparam
 	var version, name, fullname string
@@ -836,7 +859,7 @@ func detectManifest(path, mode string) (string, error) {
 
 	files, err := os.ReadDir(path)
 	if err != nil {
-		log.Fatal(err)
+		return "", err
 	}
 	for _, f := range files {
 		switch mode {
@@ -923,18 +946,21 @@ func getInsertIndex(rows []string) (int, int) {
 }
 
 // Get local gem paths from env
-func getGemPaths() ([]string, []string) {
+func getGemPaths(ctx context.Context) ([]string, []string) {
 	var start, stop, reading bool
 	locations, secondaryLocation := []string{}, []string{}
-	cmd := exec.Command("gem", "env")
-	output, err := cmd.Output()
+	output, err := helper.Output(ctx, "", "gem", "env")
 	if err != nil {
-		log.Println(err)
+		helper.Report(ctx, meta.SeverityError, meta.CodeCommandFailed, "", "listing the gem paths: %v", err)
 	}
 	paths := strings.Fields(string(output))
 	for i, path := range paths {
-		start = paths[i] == "GEM" && paths[i+1] == "PATHS:"
-		stop = paths[i] == "GEM" && paths[i+1] == "CONFIGURATION:"
+		next := ""
+		if i+1 < len(paths) {
+			next = paths[i+1]
+		}
+		start = paths[i] == "GEM" && next == "PATHS:"
+		stop = paths[i] == "GEM" && next == "CONFIGURATION:"
 		if stop {
 			break
 		}
@@ -958,12 +984,12 @@ func getGemPaths() ([]string, []string) {
 }
 
 // Build tree mapping from all gems detected in gem paths
-func buildLocalTree(paths []string, secondaryLocation string) []Spec {
+func buildLocalTree(ctx context.Context, paths []string, secondaryLocation string) []Spec {
 	localSpecs := []Spec{}
 	for _, installPath := range paths {
 		specPath := filepath.Join(installPath, SpecDefaultDir)
 		cachePath := filepath.Join(installPath, CacheDefaultDir)
-		primaryLocation := gemDir()
+		primaryLocation := gemDir(ctx)
 		checkSumPaths := []string{cachePath, secondaryLocation, primaryLocation}
 		licensePath := filepath.Join(installPath, GemDefaultDir)
 
@@ -973,14 +999,15 @@ func buildLocalTree(paths []string, secondaryLocation string) []Spec {
 
 		files, err := os.ReadDir(specPath)
 		if err != nil {
-			log.Fatal(err)
+			helper.Report(ctx, meta.SeverityWarning, meta.CodeMissingMetadata, "", "reading the installed gems in %s: %v", specPath, err)
+			continue
 		}
 		for _, f := range files {
 			if !f.IsDir() && strings.Contains(f.Name(), SpecExtension) {
 				fullSpecsPath := filepath.Join(specPath, f.Name())
 				spec := getSpecs(fullSpecsPath)
 				if spec.Version == "" {
-					spec.Version = getExistingVersion(cleanName(spec.Name))
+					spec.Version = getExistingVersion(ctx, cleanName(spec.Name))
 				}
 				fileName := strings.Replace(f.Name(), SpecExtension, "", 1)
 
@@ -988,7 +1015,7 @@ func buildLocalTree(paths []string, secondaryLocation string) []Spec {
 					if _, err := os.Stat(csp); os.IsNotExist(err) {
 						continue
 					}
-					sha, err := checkSum(csp, fileName, true)
+					sha, err := checkSum(ctx, csp, fileName, true)
 					if err == nil && sha != "" {
 						spec.Checksum = sha
 						break
@@ -1044,13 +1071,13 @@ func lookupGemInfo(name, version string) Spec {
 }
 
 // Initialize in-memory dependency cache
-func initializeDepCache(wg *sync.WaitGroup) {
-	paths, secPaths := getGemPaths()
-	secondaryCachePath := gemDir()
+func initializeDepCache(ctx context.Context, wg *sync.WaitGroup) {
+	paths, secPaths := getGemPaths(ctx)
+	secondaryCachePath := gemDir(ctx)
 	if len(secPaths) > 0 {
 		secondaryCachePath = filepath.Join(secPaths[0], CacheDefaultDir)
 	}
-	depSpecs := buildLocalTree(paths, secondaryCachePath)
+	depSpecs := buildLocalTree(ctx, paths, secondaryCachePath)
 	for _, dep := range depSpecs {
 		name, v := cleanName(dep.Name), dep.Version
 		if dependencyMap[name].count > 0 {
@@ -1085,9 +1112,8 @@ func getRangedVersion(rv string) string {
 }
 
 // gets version existing on file system
-func getExistingVersion(gem string) string {
-	cmd := exec.Command("gem", "query", "-e", gem)
-	output, err := cmd.Output()
+func getExistingVersion(ctx context.Context, gem string) string {
+	output, err := helper.Output(ctx, "", "gem", "query", "-e", gem)
 	if err != nil {
 		return None
 	}
@@ -1111,12 +1137,12 @@ func getExistingVersion(gem string) string {
 	return strings.Fields(s)[0]
 }
 
-// Scans and return file content
+// Scans and return file content, an unreadable file has no lines
 func Content(path string) []string {
 	file, err := os.Open(path)
 	record := []string{}
 	if err != nil {
-		log.Fatal(err)
+		return record
 	}
 	defer file.Close()
 	scanner := bufio.NewScanner(file)
@@ -1257,13 +1283,17 @@ func hasRakefile(path string) bool {
 }
 
 // Gets the gem installation directory
-func gemDir() string {
-	cmd := exec.Command("gem", "environment", "gemdir")
-	output, err := cmd.Output()
+func gemDir(ctx context.Context) string {
+	output, err := helper.Output(ctx, "", "gem", "environment", "gemdir")
+	fields := strings.Fields(string(output))
+	if err == nil && len(fields) == 0 {
+		err = errors.New("no output")
+	}
 	if err != nil {
-		log.Println(err)
+		helper.Report(ctx, meta.SeverityWarning, meta.CodeCommandFailed, "", "finding the gem installation directory: %v", err)
+		return ""
 	}
-	return filepath.Join(strings.Fields(string(output))[0], CacheDefaultDir)
+	return filepath.Join(fields[0], CacheDefaultDir)
 }
 
 // extracts authors from row
@@ -1278,14 +1308,9 @@ func getAuthors(row string) []string {
 
 // computes the SHA 256 checkSum of a gem
 func getSHA(filename string) (string, error) {
-	f, err := os.Open(filename)
+	c, err := meta.ComputeFileChecksum(meta.HashAlgoSHA256, filename)
 	if err != nil {
 		return "", err
 	}
-	defer f.Close()
-	h := sha256.New()
-	if _, err := io.Copy(h, f); err != nil {
-		return "", err
-	}
-	return hex.EncodeToString(h.Sum(nil)), nil
+	return c.Value, nil
 }
@@ -3,10 +3,14 @@
 package gem
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 	"net/http"
+
+	"github.com/opensbom-generator/parsers/internal/helper"
+	"github.com/opensbom-generator/parsers/meta"
+	"github.com/opensbom-generator/parsers/purl"
 )
 
 type (
@@ -72,9 +76,9 @@ const (
 	DefaultResponseType = ".json"
 )
 
-func NewService(name string) (*Service, error) {
-	url := fmt.Sprintf("%s/%s%s", DefaultURL, name, DefaultResponseType)
-	request, err := http.NewRequest(DefaultMethod, url, nil)
+func NewService(ctx context.Context, name string) (*Service, error) {
+	url := helper.ClientFrom(ctx).MirrorURL(purl.TypeGem, DefaultURL, fmt.Sprintf("%s/%s%s", DefaultURL, name, DefaultResponseType))
+	request, err := http.NewRequestWithContext(ctx, DefaultMethod, url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -88,22 +92,22 @@ func NewService(name string) (*Service, error) {
 
 func (service *Service) GetGem() (MetaVM, error) {
 	var metadata MetaVM
-	service.response, service.err = http.DefaultClient.Do(service.request)
+	service.response, service.err = helper.ClientFrom(service.request.Context()).Do(service.request)
 
 	if service.err != nil {
-		log.Printf("Failed to get gem from rubygems.org : %v\n", service.err)
+		helper.Report(service.request.Context(), meta.SeverityWarning, meta.CodeRegistryUnavailable, service.name, "Failed to get gem from rubygems.org : %v", service.err)
 		return MetaVM{}, service.err
 	}
 	defer func() {
 		service.err = service.response.Body.Close()
 		if service.err != nil {
-			log.Printf("Failed to get gem from rubygems.org : %v\n", service.err)
+			helper.Report(service.request.Context(), meta.SeverityWarning, meta.CodeRegistryUnavailable, service.name, "Failed to get gem from rubygems.org : %v", service.err)
 		}
 	}()
 
 	service.err = json.NewDecoder(service.response.Body).Decode(&metadata)
 	if service.err != nil {
-		log.Printf("Failed to get gem from rubygems.org : %v\n", service.err)
+		helper.Report(service.request.Context(), meta.SeverityWarning, meta.CodeRegistryUnavailable, service.name, "Failed to get gem from rubygems.org : %v", service.err)
 		return MetaVM{}, service.err
 	}
 
@@ -4,6 +4,7 @@ package gomod
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -15,6 +16,7 @@ import (
 	"github.com/go-git/go-git/v5"
 	"github.com/opensbom-generator/parsers/internal/helper"
 	"github.com/opensbom-generator/parsers/meta"
+	"github.com/opensbom-generator/parsers/purl"
 )
 
 const vendorFolder = "vendor"
@@ -24,6 +26,7 @@ var errFailedtoReadMod = errors.New("failed to read go.mod line")
 // Decoder
 type Decoder struct {
 	reader io.Reader
+	sums   map[string]string
 }
 
 // NewDecoder ...
@@ -33,57 +36,48 @@ func NewDecoder(r io.Reader) *Decoder {
 	}
 }
 
-// ConvertPlainReaderToModules...
-// todo: improve code below
-func (d *Decoder) ConvertPlainReaderToModules(modules []meta.Package) error {
-	moduleMap := map[string]meta.Package{}
-	moduleIndex := map[string]int{}
-	for idx, module := range modules {
-		moduleMap[module.Name] = module
-		moduleIndex[module.Name] = idx
+// WithSums sets the go.sum hashes, keyed by module path and version, the
+// module checksums are taken from
+func (d *Decoder) WithSums(sums map[string]string) *Decoder {
+	d.sums = sums
+	return d
+}
+
+// ConvertPlainReaderToGraph reads the output of go mod graph into a graph
+// of the given modules
+func (d *Decoder) ConvertPlainReaderToGraph(modules []meta.Package) (*meta.Graph, error) {
+	graph := meta.NewGraph(purl.TypeGolang)
+	ids := map[string]meta.NodeID{}
+	for i := range modules {
+		ids[modules[i].Name] = graph.AddNode(modules[i])
 	}
 
 	scanner := bufio.NewScanner(d.reader)
 	for scanner.Scan() {
 		mods, err := readMod(scanner.Text())
 		if err != nil {
-			return err
+			return nil, err
 		}
-		moduleName := strings.Split(mods[0], "@")[0]
-		if _, ok := moduleMap[moduleName]; !ok {
+		from, ok := ids[strings.Split(mods[0], "@")[0]]
+		if !ok {
 			continue
 		}
 
-		depName := strings.Split(mods[1], "@")[0]
-		depModule, ok := moduleMap[depName]
+		to, ok := ids[strings.Split(mods[1], "@")[0]]
 		if !ok {
 			continue
 		}
 
-		modules[moduleIndex[moduleName]].Packages[depName] = &meta.Package{
-			Name:             depModule.Name,
-			Version:          depModule.Version,
-			Path:             depModule.Path,
-			LocalPath:        depModule.LocalPath,
-			Supplier:         depModule.Supplier,
-			PackageURL:       depModule.PackageURL,
-			Checksum:         depModule.Checksum,
-			PackageHomePage:  depModule.PackageHomePage,
-			LicenseConcluded: depModule.LicenseConcluded,
-			LicenseDeclared:  depModule.LicenseDeclared,
-			CommentsLicense:  depModule.CommentsLicense,
-			OtherLicense:     depModule.OtherLicense,
-			Copyright:        depModule.Copyright,
-			PackageComment:   depModule.PackageComment,
-			Root:             depModule.Root,
+		if err := graph.AddEdge(from, to, meta.ScopeRuntime); err != nil {
+			return nil, err
 		}
 	}
 
-	return nil
+	return graph, nil
 }
 
 // ConvertJSONReaderToModules ...
-func (d *Decoder) ConvertJSONReaderToModules(path string, modules *[]meta.Package) error {
+func (d *Decoder) ConvertJSONReaderToModules(ctx context.Context, path string, modules *[]meta.Package) error {
 	decoder := json.NewDecoder(d.reader)
 	pathMap := map[string]bool{}
 	for {
@@ -106,10 +100,11 @@ func (d *Decoder) ConvertJSONReaderToModules(path string, modules *[]meta.Packag
 		}
 
 		pathMap[j.Module.Path] = true
-		md, err := buildModule(j.Module)
+		md, err := buildModule(ctx, j.Module)
 		if err != nil {
 			return err
 		}
+		d.addChecksums(md, j.Module)
 
 		if j.Module.Path == path {
 			md.Root = true
@@ -131,39 +126,69 @@ func (d *Decoder) ConvertJSONReaderToSingleModule(module *meta.Package) error {
 	return nil
 }
 
-func buildModule(m *Module) (*meta.Package, error) {
+func buildModule(ctx context.Context, m *Module) (*meta.Package, error) {
 	localDir := buildLocalPath(m.Path, m.Dir)
-	contentCheckSum := helper.BuildManifestContent(localDir)
 	module := meta.Package{
 		Name:                    helper.BuildModuleName(m.Path, m.Replace.Path, m.Replace.Dir),
 		Version:                 m.Version,
 		LocalPath:               localDir,
-		PackageURL:              m.Path,
+		PackageURL:              purl.Golang(m.Path, m.Version),
 		PackageDownloadLocation: buildDownloadURL(m.Path, m.Version),
-		Checksum: meta.Checksum{
-			Algorithm: meta.HashAlgoSHA256,
-			Content:   contentCheckSum,
-		},
+		Scope:                   meta.ScopeRuntime,
 		Supplier: meta.Supplier{
 			Type: meta.Organization,
 			Name: helper.BuildModuleName(m.Path, m.Replace.Path, m.Replace.Dir),
 		},
 	}
-	licensePkg, err := helper.GetLicenses(localDir)
-	if err == nil {
-		module.LicenseDeclared = helper.BuildLicenseDeclared(licensePkg.ID)
-		module.LicenseConcluded = helper.BuildLicenseConcluded(licensePkg.ID)
-		module.Copyright = helper.GetCopyright(licensePkg.ExtractedText)
-		module.CommentsLicense = licensePkg.Comments
-		if !helper.LicenseSPDXExists(licensePkg.ID) {
-			licensePkg.ID = fmt.Sprintf("LicenseRef-%s", licensePkg.ID)
-			licensePkg.ExtractedText = fmt.Sprintf("<text>%s</text>", licensePkg.ExtractedText)
-			module.OtherLicense = append(module.OtherLicense, *licensePkg)
+	// go.mod declares no license, only the license files tell it
+	helper.SetLicenses(ctx, &module, "", localDir)
+	module.Copyright = helper.ScanCopyright(localDir)
+	module.Packages = map[string]*meta.Package{}
+
+	return &module, nil
+}
+
+// addChecksums adds the go.sum hash of the module, or a synthetic hash of
+// its path and version for the main module and directory replacements,
+// which go.sum does not list
+func (d *Decoder) addChecksums(module *meta.Package, m *Module) {
+	key := m.Path + "@" + m.Version
+	if m.Replace.Path != "" {
+		key = m.Replace.Path + "@" + m.Replace.Version
+	}
+	if sum, ok := d.sums[key]; ok {
+		// h1 hashes are the SHA256 of the list of the file hashes of the
+		// module zip, not of the zip, so they are no artifact digest
+		if c, err := meta.NewChecksum(meta.HashAlgoSHA256, strings.TrimPrefix(sum, "h1:")); err == nil {
+			c.Synthetic = true
+			module.AddChecksum(c)
+			return
 		}
 	}
-	module.Packages = map[string]*meta.Package{}
 
-	return &module, err
+	// modules missing from go.sum, e.g. replaced by a directory, have no
+	// digest, AnalyzeFiles computes a verification code of their files
+	module.AddChecksum(meta.SyntheticChecksum(m.Path + "@" + m.Version))
+}
+
+// readGoSum returns the h1 hashes of the module zips listed in a go.sum
+// file, keyed by module path and version
+func readGoSum(path string) map[string]string {
+	sums := map[string]string{}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return sums
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 3 || !strings.HasPrefix(fields[2], "h1:") || strings.HasSuffix(fields[1], "/go.mod") {
+			continue
+		}
+		sums[fields[0]+"@"+fields[1]] = fields[2]
+	}
+
+	return sums
 }
 
 func readMod(token string) ([]string, error) {
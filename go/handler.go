@@ -4,13 +4,21 @@ package gomod
 
 import (
 	"bytes"
+	"context"
+	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/opensbom-generator/parsers/internal/helper"
 	"github.com/opensbom-generator/parsers/meta"
 	"github.com/opensbom-generator/parsers/plugin"
+	"github.com/opensbom-generator/parsers/purl"
 )
 
+func init() {
+	plugin.Register("go-mod", func() plugin.Plugin { return New() })
+}
+
 // New ...
 func New() *Mod {
 	return &Mod{
@@ -18,10 +26,22 @@ func New() *Mod {
 			Name:     "Go Modules",
 			Slug:     "go-mod",
 			Manifest: []string{"go.mod"},
+			ReadOnly: true,
+			Capabilities: plugin.Capabilities{
+				Executables:     []plugin.Executable{{Name: "go", Constraint: ">=1.16"}},
+				Network:         plugin.NetworkOptional,
+				Lockfiles:       []string{"go.sum"},
+				TransitiveGraph: true,
+			},
 		},
 	}
 }
 
+// SetOptions configures how modules are listed
+func (m *Mod) SetOptions(opts plugin.Options) {
+	m.options = opts
+}
+
 // GetMetadata ...
 func (m *Mod) GetMetadata() plugin.Metadata {
 	return m.metadata
@@ -29,7 +49,13 @@ func (m *Mod) GetMetadata() plugin.Metadata {
 
 // SetRootModule ...
 func (m *Mod) SetRootModule(path string) error {
-	module, err := m.getModule(path)
+	return m.SetRootModuleContext(context.Background(), path)
+}
+
+// SetRootModuleContext ...
+func (m *Mod) SetRootModuleContext(ctx context.Context, path string) error {
+	ctx = m.options.Context(ctx)
+	module, err := m.getModule(ctx, path)
 	if err != nil {
 		return err
 	}
@@ -51,13 +77,24 @@ func (m *Mod) IsValid(path string) bool {
 
 // HasModulesInstalled ...
 func (m *Mod) HasModulesInstalled(path string) error {
+	return m.HasModulesInstalledContext(context.Background(), path)
+}
+
+// HasModulesInstalledContext ...
+func (m *Mod) HasModulesInstalledContext(ctx context.Context, path string) error {
 	// we dont need to validate if packages are installed as process to read dependencies will download them
 	return nil
 }
 
 // GetVersion...
 func (m *Mod) GetVersion() (string, error) {
-	if err := m.buildCmd(VersionCmd, "."); err != nil {
+	return m.GetVersionContext(context.Background())
+}
+
+// GetVersionContext...
+func (m *Mod) GetVersionContext(ctx context.Context) (string, error) {
+	ctx = m.options.Context(ctx)
+	if err := m.buildCmd(ctx, VersionCmd, "."); err != nil {
 		return "", err
 	}
 
@@ -66,8 +103,14 @@ func (m *Mod) GetVersion() (string, error) {
 
 // GetRootModule...
 func (m *Mod) GetRootModule(path string) (*meta.Package, error) {
+	return m.GetRootModuleContext(context.Background(), path)
+}
+
+// GetRootModuleContext...
+func (m *Mod) GetRootModuleContext(ctx context.Context, path string) (*meta.Package, error) {
+	ctx = m.options.Context(ctx)
 	if m.rootModule == nil {
-		module, err := m.getModule(path)
+		module, err := m.getModule(ctx, path)
 		if err != nil {
 			return nil, err
 		}
@@ -80,7 +123,13 @@ func (m *Mod) GetRootModule(path string) (*meta.Package, error) {
 
 // ListUsedModules...
 func (m *Mod) ListUsedModules(path string) ([]meta.Package, error) {
-	if err := m.buildCmd(ModulesCmd, path); err != nil {
+	return m.ListUsedModulesContext(context.Background(), path)
+}
+
+// ListUsedModulesContext...
+func (m *Mod) ListUsedModulesContext(ctx context.Context, path string) ([]meta.Package, error) {
+	ctx = m.options.Context(ctx)
+	if err := m.buildCmd(ctx, ModulesCmd, path); err != nil {
 		return nil, err
 	}
 
@@ -90,27 +139,35 @@ func (m *Mod) ListUsedModules(path string) ([]meta.Package, error) {
 	}
 	defer buffer.Reset()
 
-	mainModule, err := m.GetRootModule(path)
+	mainModule, err := m.GetRootModuleContext(ctx, path)
 	if err != nil {
 		return nil, err
 	}
 
 	modules := []meta.Package{}
-	if err := NewDecoder(buffer).ConvertJSONReaderToModules(mainModule.Path, &modules); err != nil {
+	sums := readGoSum(filepath.Join(path, "go.sum"))
+	if err := NewDecoder(buffer).WithSums(sums).ConvertJSONReaderToModules(ctx, mainModule.Path, &modules); err != nil {
 		return nil, err
 	}
+	meta.Sort(modules)
 
 	return modules, nil
 }
 
 // ListModulesWithDeps ...
 func (m *Mod) ListModulesWithDeps(path string, globalSettingFile string) ([]meta.Package, error) {
-	modules, err := m.ListUsedModules(path)
+	return m.ListModulesWithDepsContext(context.Background(), path, globalSettingFile)
+}
+
+// ListModulesWithDepsContext ...
+func (m *Mod) ListModulesWithDepsContext(ctx context.Context, path string, globalSettingFile string) ([]meta.Package, error) {
+	ctx = m.options.Context(ctx)
+	modules, err := m.ListUsedModulesContext(ctx, path)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := m.buildCmd(GraphModuleCmd, path); err != nil {
+	if err := m.buildCmd(ctx, GraphModuleCmd, path); err != nil {
 		return nil, err
 	}
 
@@ -120,15 +177,16 @@ func (m *Mod) ListModulesWithDeps(path string, globalSettingFile string) ([]meta
 	}
 	defer buffer.Reset()
 
-	if err := NewDecoder(buffer).ConvertPlainReaderToModules(modules); err != nil {
+	graph, err := NewDecoder(buffer).ConvertPlainReaderToGraph(modules)
+	if err != nil {
 		return nil, err
 	}
 
-	return modules, nil
+	return m.options.Filter(graph.Packages()), nil
 }
 
-func (m *Mod) getModule(path string) (meta.Package, error) {
-	if err := m.buildCmd(RootModuleCmd, path); err != nil {
+func (m *Mod) getModule(ctx context.Context, path string) (meta.Package, error) {
+	if err := m.buildCmd(ctx, RootModuleCmd, path); err != nil {
 		return meta.Package{}, err
 	}
 
@@ -146,20 +204,38 @@ func (m *Mod) getModule(path string) (meta.Package, error) {
 	if module.Path == "" {
 		return meta.Package{}, errFailedToConvertModules
 	}
+	module.PackageURL = purl.Golang(module.Path, module.Version)
 
 	return module, nil
 }
 
-func (m *Mod) buildCmd(cmd command, path string) error {
+func (m *Mod) buildCmd(ctx context.Context, cmd command, path string) error {
 	cmdArgs := cmd.Parse()
 	if cmdArgs[0] != "go" {
 		return errNoGoCommand
 	}
 
+	var env []string
+	if helper.IsOffline(ctx) {
+		// only the module cache is used
+		env = []string{"GOPROXY=off"}
+	}
+	flags := strings.Fields(os.Getenv("GOFLAGS"))
+	if helper.IsReadOnly(ctx) {
+		// the go command fails rather than updating go.mod and go.sum
+		flags = append(flags, "-mod=readonly")
+	}
+	// a single GOFLAGS keeps the flags of the environment
+	if len(flags) > 0 {
+		env = append(env, "GOFLAGS="+strings.Join(flags, " "))
+	}
+
 	command := helper.NewCmd(helper.CmdOptions{
 		Name:      cmdArgs[0],
 		Args:      cmdArgs[1:],
 		Directory: path,
+		Context:   ctx,
+		Env:       env,
 	})
 
 	m.command = command
@@ -12,6 +12,7 @@ type Mod struct {
 	metadata   plugin.Metadata
 	rootModule *meta.Package
 	command    *helper.Cmd
+	options    plugin.Options
 }
 
 type JSONOutput struct {
@@ -32,6 +33,7 @@ type Module struct {
 
 type modReplace struct {
 	Path      string `json:"Path,omitempty"`
+	Version   string `json:"Version,omitempty"`
 	Dir       string `json:"Dir,omitempty"`
 	GoMod     string `json:"GoMod,omitempty"`
 	GoVersion string `json:"GoVersion,omitempty"`
@@ -3,7 +3,8 @@
 package javagradle
 
 import (
-	"os/exec"
+	"context"
+	"os"
 	"path/filepath"
 	"runtime"
 
@@ -32,9 +33,19 @@ func hasGradlew(workingDir string) bool {
 	return helper.Exists(filepath.Join(workingDir, "gradlew")) || (runtime.GOOS == "windows" && helper.Exists(filepath.Join(workingDir, "gradlew.bat")))
 }
 
-func (ge gradleExec) run(args ...string) *exec.Cmd {
+func (ge gradleExec) run(ctx context.Context, args ...string) (helper.Result, error) {
 	args = append(args, "--console=plain")
-	cmd := exec.Command(ge.executable, args...) //nolint: gosec
-	cmd.Dir = ge.workingDir
-	return cmd
+	if helper.IsOffline(ctx) {
+		args = append(args, "--offline")
+	}
+	if helper.IsReadOnly(ctx) {
+		// keeps the .gradle directory of the build out of the project
+		dir, err := os.MkdirTemp("", "spdx-gradle-*")
+		if err != nil {
+			return helper.Result{}, err
+		}
+		defer os.RemoveAll(dir)
+		args = append(args, "--project-cache-dir", dir)
+	}
+	return helper.Run(ctx, helper.Command{Name: ge.executable, Args: args, Dir: ge.workingDir})
 }
@@ -5,35 +5,51 @@ package javagradle
 import (
 	"bufio"
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
 	"net/url"
 	"os"
 	"path"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
+
+	"github.com/opensbom-generator/parsers/internal/helper"
+	"github.com/opensbom-generator/parsers/meta"
+	"github.com/opensbom-generator/parsers/purl"
 )
 
+// repositories pointing to maven central are fetched from the maven mirror
+const mavenCentral = "https://repo.maven.apache.org/maven2"
+
+// errNoSHA1 is returned when the repository publishes no valid SHA1 of an
+// artifact
+var errNoSHA1 = errors.New("no sha1 published")
+
 type depInfo struct {
 	root  []string
 	all   []string
 	graph map[string][]string
+	// scope of each root dependency, taken from the configuration declaring it
+	scopes map[string]meta.Scope
 }
 
 // collect all non-transitive dependencies from all configuration (compile, test, runtime, etc)
 // perhaps this should be limited to just runtimeClasspath, but there's no real way to know
 // what the final packager is going to package into the bom, what a dilemma
-func getDependencies(dir string) (depInfo, error) {
-	return dependencies(dir, ":dependencies")
+func getDependencies(ctx context.Context, dir string) (depInfo, error) {
+	return dependencies(ctx, dir, ":dependencies")
 }
 
-func dependencies(dir string, command string) (depInfo, error) {
-	out, err := newGradleExec(dir).run(command, "-q").CombinedOutput()
+func dependencies(ctx context.Context, dir string, command string) (depInfo, error) {
+	result, err := newGradleExec(dir).run(ctx, command, "-q")
+	out := result.Combined()
 	if err != nil {
-		log.Println(string(out))
+		helper.Report(ctx, meta.SeverityError, meta.CodeCommandFailed, "", "gradle %s failed: %s", command, out)
 		return depInfo{}, err
 	}
 	return parseDependencyOutput(out)
@@ -46,8 +62,13 @@ func parseDependencyOutput(out []byte) (depInfo, error) {
 
 	// the only valid dependency patterns
 	dp := regexp.MustCompile(`^(([|]|[ ])[ ]{4})*([+]|[\\])---`)
+	// configuration headers, e.g. "testImplementation - Implementation only dependencies..."
+	hp := regexp.MustCompile(`^([A-Za-z][A-Za-z0-9]*)( - .*)?$`)
 
 	rootDeps := map[string]bool{}
+	scopes := map[string]meta.Scope{}
+	// the configuration being listed
+	var configuration string
 	// map of deps and their children
 	deps := make(map[string][]string)
 
@@ -58,6 +79,10 @@ func parseDependencyOutput(out []byte) (depInfo, error) {
 
 	for sc.Scan() {
 		line := sc.Text()
+		if m := hp.FindStringSubmatch(line); m != nil {
+			configuration = m[1]
+			continue
+		}
 		if dp.MatchString(line) {
 			split := strings.SplitN(line, "--- ", 2)
 			if len(split) != 2 {
@@ -77,6 +102,9 @@ func parseDependencyOutput(out []byte) (depInfo, error) {
 				deps[cp] = append(deps[cp], current)
 			} else {
 				rootDeps[current] = true
+				if scopes[current] != meta.ScopeRuntime {
+					scopes[current] = configurationScope(configuration)
+				}
 			}
 
 			// add current to map
@@ -86,29 +114,47 @@ func parseDependencyOutput(out []byte) (depInfo, error) {
 			last = current
 		}
 	}
-	rootDepsList := make([]string, len(rootDeps))
-	i := 0
+	rootDepsList := make([]string, 0, len(rootDeps))
 	for k := range rootDeps {
-		rootDepsList[i] = k
-		i++
+		rootDepsList = append(rootDepsList, k)
 	}
+	sort.Strings(rootDepsList)
 
-	allDeps := make([]string, len(deps))
-	i = 0
+	allDeps := make([]string, 0, len(deps))
 	for k := range deps {
-		allDeps[i] = k
-		i++
+		allDeps = append(allDeps, k)
 	}
+	sort.Strings(allDeps)
 
 	ret := depInfo{
-		root:  rootDepsList,
-		all:   allDeps,
-		graph: deps,
+		root:   rootDepsList,
+		all:    allDeps,
+		graph:  deps,
+		scopes: scopes,
 	}
 
 	return ret, nil
 }
 
+// configurationScope maps a gradle configuration name to a dependency scope.
+// Configurations that are neither test nor part of the main classpath
+// (checkstyle, annotationProcessor, ...) only take part in the build.
+func configurationScope(configuration string) meta.Scope {
+	switch {
+	case strings.Contains(strings.ToLower(configuration), "test"):
+		return meta.ScopeTest
+	case configuration == "compileOnly", configuration == "compileOnlyApi":
+		return meta.ScopeBuild
+	}
+
+	switch configuration {
+	case "", "compile", "runtime", "api", "implementation", "runtimeOnly",
+		"compileClasspath", "runtimeClasspath", "default":
+		return meta.ScopeRuntime
+	}
+	return meta.ScopeBuild
+}
+
 // prefix output with spdx-repo as a parsing hint. Gradle builds can print out whatever they
 // want during "configuration" phase.
 var initRepos = `
@@ -122,17 +168,19 @@ gradle.allprojects {
 `
 
 // collect all dependency repositories in order
-func getRepositories(dir string) ([]string, error) {
-	return repositories(dir, initRepos)
+func getRepositories(ctx context.Context, dir string) ([]string, error) {
+	return repositories(ctx, dir, initRepos)
 }
 
 // inject an initscript to print out all repositories
-func repositories(dir string, initContents string) ([]string, error) {
+func repositories(ctx context.Context, dir string, initContents string) ([]string, error) {
 	initFile, err := os.CreateTemp("", "*-spdx-init.gradle")
 	if err != nil {
 		return nil, err
 	}
+	defer os.Remove(initFile.Name())
 	_, err = initFile.Write([]byte(initContents))
+	initFile.Close()
 	if err != nil {
 		return nil, err
 	}
@@ -140,9 +188,10 @@ func repositories(dir string, initContents string) ([]string, error) {
 	if err != nil {
 		return nil, err
 	}
-	out, err := newGradleExec(dir).run(":spdxPrintRepos", "--init-script", initPath, "-q").CombinedOutput()
+	result, err := newGradleExec(dir).run(ctx, ":spdxPrintRepos", "--init-script", initPath, "-q")
+	out := result.Combined()
 	if err != nil {
-		log.Println(string(out))
+		helper.Report(ctx, meta.SeverityError, meta.CodeCommandFailed, "", "listing the gradle repositories failed: %s", out)
 	}
 	return parseRepoOutput(out)
 }
@@ -212,7 +261,7 @@ func mergeURL(base, suffix string) (string, error) {
 	return url.String(), nil
 }
 
-func findDownloadLocations(repos []string, deps []string) (map[string]string, error) {
+func findDownloadLocations(ctx context.Context, repos []string, deps []string) (map[string]string, error) {
 	depUrls := map[string]string{}
 	for _, dep := range deps {
 		suffix, err := calculateURLSuffix(dep)
@@ -224,41 +273,107 @@ func findDownloadLocations(repos []string, deps []string) (map[string]string, er
 			if err != nil {
 				return nil, err
 			}
-			if remoteExists(remote) {
+			if remoteExists(ctx, remote) {
 				depUrls[dep] = remote
 				break
 			}
 		}
 		if _, ok := depUrls[dep]; !ok {
+			// offline only cached locations are known
+			if helper.ClientFrom(ctx).Offline(ctx) {
+				helper.Report(ctx, meta.SeverityInfo, meta.CodeMissingMetadata, dep, "the download location of %s is not cached and cannot be found offline", dep)
+				depUrls[dep] = ""
+				continue
+			}
 			return nil, fmt.Errorf("could not find download location for %q", dep)
 		}
 	}
 	return depUrls, nil
 }
 
-func getSHA1(depURL string) (string, error) {
+func getSHA1(ctx context.Context, depURL string) (string, error) {
 	sb := make([]byte, 0, 40)
 
-	r, err := http.Get(depURL + ".sha1")
+	client := helper.ClientFrom(ctx)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.MirrorURL(purl.TypeMaven, mavenCentral, depURL)+".sha1", nil)
+	if err != nil {
+		return "", err
+	}
+	r, err := client.Do(req)
 	if err != nil {
 		return "", err
 	}
 
 	defer r.Body.Close()
+	if r.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%w: %s", errNoSHA1, r.Status)
+	}
 	b, err := io.ReadAll(io.LimitReader(r.Body, int64(cap(sb))))
 	if err != nil {
 		return "", err
 	}
+	c, err := meta.NewChecksum(meta.HashAlgoSHA1, string(b))
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", errNoSHA1, err)
+	}
 
-	return string(b), nil
+	return c.Value, nil
 }
 
-func remoteExists(depURL string) bool {
+func remoteExists(ctx context.Context, depURL string) bool {
 	// TODO: review this
-	r, err := http.Head(depURL) //nolint: gosec
+	client := helper.ClientFrom(ctx)
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, client.MirrorURL(purl.TypeMaven, mavenCentral, depURL), nil)
 	if err != nil {
-		log.Print(err)
 		return false
 	}
+	r, err := client.Do(req)
+	if errors.Is(err, helper.ErrOffline) {
+		return false
+	}
+	if err != nil {
+		helper.Report(ctx, meta.SeverityWarning, meta.CodeRegistryUnavailable, "", "checking %s: %v", depURL, err)
+		return false
+	}
+	r.Body.Close()
 	return r.StatusCode == 200
 }
+
+func gradleUserHome() string {
+	if home := os.Getenv("GRADLE_USER_HOME"); home != "" {
+		return home
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".gradle")
+}
+
+// returns the sha1 of the artifact of a dependency from the gradle cache,
+// which is laid out as group/artifact/version/<hash>/file
+func cachedSHA1(dep string) (string, error) {
+	groupID, artifactID, version, err := splitDep(dep)
+	if err != nil {
+		return "", err
+	}
+	suffix, err := calculateURLSuffix(dep)
+	if err != nil {
+		return "", err
+	}
+
+	pattern := filepath.Join(gradleUserHome(), "caches", "modules-2", "files-2.1", groupID, artifactID, version, "*", path.Base(suffix))
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return "", err
+	}
+	if len(matches) == 0 {
+		return "", fmt.Errorf("%q is not in the gradle cache", dep)
+	}
+
+	c, err := meta.ComputeFileChecksum(meta.HashAlgoSHA1, matches[0])
+	if err != nil {
+		return "", err
+	}
+	return c.Value, nil
+}
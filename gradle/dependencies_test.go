@@ -3,10 +3,17 @@
 package javagradle
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"reflect"
-	"sort"
 	"testing"
+
+	"github.com/opensbom-generator/parsers/internal/helper"
+	"github.com/opensbom-generator/parsers/meta"
 )
 
 func TestParseDependencyOutput(t *testing.T) {
@@ -65,10 +72,8 @@ func TestParseDependencyOutput(t *testing.T) {
 			"com.google.cloud.tools:appengine-plugins-core:0.9.1",
 			"com.puppycrawl.tools:checkstyle:8.18",
 		}
-		sorted := di.root
-		sort.Strings(sorted)
-		if reflect.DeepEqual(sorted, want) == false {
-			t.Fatalf("\n got: %q\nwant: %q", sorted, want)
+		if reflect.DeepEqual(di.root, want) == false {
+			t.Fatalf("\n got: %q\nwant: %q", di.root, want)
 		}
 	}
 	{
@@ -89,10 +94,31 @@ func TestParseDependencyOutput(t *testing.T) {
 			"org.glassfish:javax.json:1.0.4",
 			"org.yaml:snakeyaml:1.21",
 		}
-		sorted := di.all
-		sort.Strings(sorted)
-		if reflect.DeepEqual(sorted, want) == false {
-			t.Fatalf("\n got: %q\nwant: %q", sorted, want)
+		if reflect.DeepEqual(di.all, want) == false {
+			t.Fatalf("\n got: %q\nwant: %q", di.all, want)
+		}
+	}
+	{
+		want := map[string]meta.Scope{
+			"com.google.cloud.tools:appengine-plugins-core:0.9.1": meta.ScopeRuntime,
+			"com.puppycrawl.tools:checkstyle:8.18":                meta.ScopeBuild,
+		}
+		if reflect.DeepEqual(di.scopes, want) == false {
+			t.Fatalf("\n got: %q\nwant: %q", di.scopes, want)
+		}
+	}
+}
+
+func TestConfigurationScope(t *testing.T) {
+	for configuration, want := range map[string]meta.Scope{
+		"implementation":     meta.ScopeRuntime,
+		"runtimeClasspath":   meta.ScopeRuntime,
+		"testImplementation": meta.ScopeTest,
+		"compileOnly":        meta.ScopeBuild,
+		"checkstyle":         meta.ScopeBuild,
+	} {
+		if got := configurationScope(configuration); got != want {
+			t.Fatalf("%s\n got: %v\nwant: %v", configuration, got, want)
 		}
 	}
 }
@@ -140,7 +166,7 @@ func TestCalculateURLSuffixPlugin(t *testing.T) {
 func TestFindDownloadLocations(t *testing.T) {
 	repos := []string{"https://repo.maven.apache.org/maven2", "https://plugins.gradle.org/m2"}
 	deps := []string{"com.google.guava:guava:10.0", "com.google.cloud.tools:com.google.cloud.tools.jib.gradle.plugin:1.0.0"}
-	locs, err := findDownloadLocations(repos, deps)
+	locs, err := findDownloadLocations(context.Background(), repos, deps)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -152,3 +178,91 @@ func TestFindDownloadLocations(t *testing.T) {
 		t.Fatalf("\n got: %v\nwant: %v", locs, want)
 	}
 }
+
+func TestFindDownloadLocationsOffline(t *testing.T) {
+	ctx := helper.WithOffline(context.Background(), true)
+	locs, err := findDownloadLocations(ctx, []string{"https://repo.maven.apache.org/maven2"}, []string{"com.google.guava:guava:10.0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"com.google.guava:guava:10.0": ""}
+	if reflect.DeepEqual(locs, want) == false {
+		t.Fatalf("\n got: %v\nwant: %v", locs, want)
+	}
+}
+
+func TestCachedSHA1(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("GRADLE_USER_HOME", home)
+
+	if _, err := cachedSHA1("com.google.guava:guava:10.0"); err == nil {
+		t.Fatal("expected an error for a dependency missing from the cache")
+	}
+
+	dir := filepath.Join(home, "caches", "modules-2", "files-2.1", "com.google.guava", "guava", "10.0", "c1a3")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "guava-10.0.jar"), []byte("jar"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	sha, err := cachedSHA1("com.google.guava:guava:10.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "f92e777f4341930bad9b2422283c4680d00dbc06"; sha != want {
+		t.Fatalf("got %q, want %q", sha, want)
+	}
+}
+
+func TestGetSHA1Mirror(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/maven2/com/google/guava/guava/10.0/guava-10.0.jar.sha1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("f92e777f4341930bad9b2422283c4680d00dbc06"))
+	}))
+	defer server.Close()
+
+	client, err := helper.NewClient(helper.ClientOptions{
+		Netrc:   os.DevNull,
+		Mirrors: map[string]string{"maven": server.URL + "/maven2"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := helper.WithClient(context.Background(), client)
+
+	sha, err := getSHA1(ctx, mavenCentral+"/com/google/guava/guava/10.0/guava-10.0.jar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "f92e777f4341930bad9b2422283c4680d00dbc06"; sha != want {
+		t.Fatalf("got %q, want %q", sha, want)
+	}
+	if !remoteExists(ctx, mavenCentral+"/com/google/guava/guava/10.0/guava-10.0.jar.sha1") {
+		t.Fatal("expected the artifact to exist on the mirror")
+	}
+}
+
+func TestGetSHA1NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte("<html>not found</html>"))
+	}))
+	defer server.Close()
+
+	client, err := helper.NewClient(helper.ClientOptions{
+		Netrc:   os.DevNull,
+		Mirrors: map[string]string{"maven": server.URL + "/maven2"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := helper.WithClient(context.Background(), client)
+
+	if _, err := getSHA1(ctx, mavenCentral+"/com/google/guava/guava/10.0/guava-10.0.jar"); !errors.Is(err, errNoSHA1) {
+		t.Fatalf("got %v, want %v", err, errNoSHA1)
+	}
+}
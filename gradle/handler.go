@@ -3,20 +3,27 @@
 package javagradle
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"log"
-	"os/exec"
 	"path/filepath"
+	"sort"
 
 	"github.com/opensbom-generator/parsers/internal/helper"
 	"github.com/opensbom-generator/parsers/meta"
 	"github.com/opensbom-generator/parsers/plugin"
+	"github.com/opensbom-generator/parsers/purl"
 )
 
 type Gradle struct {
 	metadata plugin.Metadata
 	ge       gradleExec
 	basepath string
+	options  plugin.Options
+}
+
+func init() {
+	plugin.Register("Java-Gradle", func() plugin.Plugin { return New() })
 }
 
 func New() *Gradle {
@@ -26,15 +33,34 @@ func New() *Gradle {
 			Slug:       "Java-Gradle",
 			Manifest:   []string{"build.gradle", "settings.gradle"},
 			ModulePath: []string{"."},
+			ReadOnly:   true,
+			Capabilities: plugin.Capabilities{
+				Executables: []plugin.Executable{
+					{Name: "gradle", Wrapper: "gradlew"},
+					{Name: "git", Optional: true},
+				},
+				Network:          plugin.NetworkOptional,
+				MutatesWorkspace: true,
+				TransitiveGraph:  true,
+			},
 		},
 	}
 }
 
+// SetOptions configures how modules are listed
+func (m *Gradle) SetOptions(opts plugin.Options) {
+	m.options = opts
+}
+
 func (m *Gradle) GetMetadata() plugin.Metadata {
 	return m.metadata
 }
 
 func (m *Gradle) SetRootModule(path string) error {
+	return m.SetRootModuleContext(context.Background(), path)
+}
+
+func (m *Gradle) SetRootModuleContext(ctx context.Context, path string) error {
 	m.basepath = path
 	m.ge = newGradleExec(path)
 	return nil
@@ -50,28 +76,45 @@ func (m *Gradle) IsValid(path string) bool {
 }
 
 func (m *Gradle) GetVersion() (string, error) {
-	cmd := m.ge.run("--version")
-	out, err := cmd.Output()
+	return m.GetVersionContext(context.Background())
+}
+
+func (m *Gradle) GetVersionContext(ctx context.Context) (string, error) {
+	ctx = m.options.Context(ctx)
+	result, err := m.ge.run(ctx, "--version")
 	if err != nil {
 		return "", err
 	}
-	return string(out), nil
+	return string(result.Stdout), nil
 }
 
 func (m *Gradle) GetRootModule(path string) (*meta.Package, error) {
+	return m.GetRootModuleContext(context.Background(), path)
+}
+
+func (m *Gradle) GetRootModuleContext(ctx context.Context, path string) (*meta.Package, error) {
 	// this doesn't actually do anything and is not called by any
 	// orchestrator, should it still be in the interface?
 	return nil, fmt.Errorf("GetRootModule not implemented for java-gradle")
 }
 
 func (m *Gradle) ListUsedModules(path string) ([]meta.Package, error) {
+	return m.ListUsedModulesContext(context.Background(), path)
+}
+
+func (m *Gradle) ListUsedModulesContext(ctx context.Context, path string) ([]meta.Package, error) {
 	// this doesn't actually do anything and is not called by any
 	// orchestrator, should it still be in the interface?
 	return nil, fmt.Errorf("ListUsedModules not implemented for java-gradle")
 }
 
 func (m *Gradle) ListModulesWithDeps(path string, globalSettingFile string) ([]meta.Package, error) {
-	pi, err := getProjectInfo(path)
+	return m.ListModulesWithDepsContext(context.Background(), path, globalSettingFile)
+}
+
+func (m *Gradle) ListModulesWithDepsContext(ctx context.Context, path string, globalSettingFile string) ([]meta.Package, error) {
+	ctx = m.options.Context(ctx)
+	pi, err := getProjectInfo(ctx, path)
 	if err != nil {
 		return nil, err
 	}
@@ -82,49 +125,52 @@ func (m *Gradle) ListModulesWithDeps(path string, globalSettingFile string) ([]m
 			Type: "Group Id",
 			Name: pi.group,
 		},
-		Root:     true,
-		Packages: make(map[string]*meta.Package),
+		PackageURL: purl.Maven(pi.group, pi.name, pi.version),
+		Root:       true,
+		Packages:   make(map[string]*meta.Package),
 	}
 	// mediocre effort to read git info
-	origin, sha1, err := getGitInfo()
-	if err != nil {
-		rootModule.Checksum = meta.Checksum{
-			Algorithm: meta.HashAlgorithm("None"),
-			Value:     "none",
-		}
-	} else {
-		rootModule.Checksum = meta.Checksum{
+	origin, sha1, err := getGitInfo(ctx)
+	if err == nil {
+		// the commit identifies the sources, it is no digest of an artifact
+		rootModule.AddChecksum(meta.Checksum{
 			Algorithm: meta.HashAlgoSHA1,
 			Value:     sha1,
-		}
+			Synthetic: true,
+		})
 		rootModule.PackageDownloadLocation = origin
 	}
-	all, err := getDependencyModules(rootModule, path)
+	all, err := getDependencyModules(ctx, rootModule, path)
 	if err != nil {
 		return nil, err
 	}
-	return all, nil
+	return m.options.Filter(all), nil
 }
 
-func getDependencyModules(project meta.Package, path string) ([]meta.Package, error) {
+func getDependencyModules(ctx context.Context, project meta.Package, path string) ([]meta.Package, error) {
 	modsMap := map[string]*meta.Package{}
 	mods := []meta.Package{project}
 
-	deps, err := getDependencies(path)
+	deps, err := getDependencies(ctx, path)
 	if err != nil {
 		return nil, err
 	}
-	repos, err := getRepositories(path)
+	repos, err := getRepositories(ctx, path)
 	if err != nil {
 		return nil, err
 	}
-	depLoc, err := findDownloadLocations(repos, deps.all)
+	depLoc, err := findDownloadLocations(ctx, repos, deps.all)
 	if err != nil {
 		return nil, err
 	}
 
-	for dep, remote := range depLoc {
-		mod, err := generateModule(dep, remote)
+	locatedDeps := make([]string, 0, len(depLoc))
+	for dep := range depLoc {
+		locatedDeps = append(locatedDeps, dep)
+	}
+	sort.Strings(locatedDeps)
+	for _, dep := range locatedDeps {
+		mod, err := generateModule(ctx, dep, depLoc[dep])
 		if err != nil {
 			return nil, err
 		}
@@ -138,8 +184,11 @@ func getDependencyModules(project meta.Package, path string) ([]meta.Package, er
 		if !ok {
 			return nil, fmt.Errorf("could not find module for %q", rootDep)
 		}
+		// root edges carry the scope of the configuration declaring them
+		edge := *mod
+		edge.Scope = deps.scopes[rootDep]
 		// apparently the key is just thrown away, so this just has to be something unique
-		project.Packages[rootDep] = mod
+		project.Packages[rootDep] = &edge
 	}
 
 	// add transitive dependencies
@@ -157,18 +206,31 @@ func getDependencyModules(project meta.Package, path string) ([]meta.Package, er
 			mod.Packages[tdep] = tmod
 		}
 	}
+	meta.ResolveScopes(mods)
 	return mods, nil
 }
 
 // generate gradle dependency module (non-root)
-func generateModule(name, depURL string) (meta.Package, error) {
+func generateModule(ctx context.Context, name, depURL string) (meta.Package, error) {
 	mod := meta.Package{}
 	groupID, artifactID, version, err := splitDep(name)
 	if err != nil {
 		return mod, err
 	}
-	sha1, err := getSHA1(depURL)
-	if err != nil {
+	var sha1 string
+	if depURL == "" {
+		depURL = helper.NoAssertion
+		err = helper.ErrOffline
+	} else {
+		sha1, err = getSHA1(ctx, depURL)
+	}
+	if errors.Is(err, helper.ErrOffline) || errors.Is(err, errNoSHA1) {
+		// offline or unpublished, the gradle cache is the only source of
+		// the checksum
+		if sha1, err = cachedSHA1(name); err != nil {
+			helper.Report(ctx, meta.SeverityWarning, meta.CodeChecksumUnavailable, name, "no checksum of %s in the gradle cache: %v", name, err)
+		}
+	} else if err != nil {
 		return mod, err
 	}
 	mod.Supplier = meta.Supplier{
@@ -177,33 +239,38 @@ func generateModule(name, depURL string) (meta.Package, error) {
 	}
 	mod.Name = artifactID
 	mod.Version = version
+	mod.PackageURL = purl.Maven(groupID, artifactID, version)
 	mod.PackageDownloadLocation = depURL
-	mod.Checksum = meta.Checksum{
+	mod.AddChecksum(meta.Checksum{
 		Algorithm: meta.HashAlgoSHA1,
 		Value:     sha1,
-	}
+	})
 	mod.Packages = make(map[string]*meta.Package)
+	mod.Scope = meta.ScopeRuntime
 	mod.Root = false
 
 	return mod, nil
 }
 
 func (m *Gradle) HasModulesInstalled(path string) error {
+	return m.HasModulesInstalledContext(context.Background(), path)
+}
+
+func (m *Gradle) HasModulesInstalledContext(ctx context.Context, path string) error {
+	ctx = m.options.Context(ctx)
 	// check if root has gradlew wrapper script
 	if hasGradlew(path) {
 		return nil
 	}
 
 	// then check for gradle on system path
-	fname, err := exec.LookPath("gradle")
+	fname, err := helper.LookPath(ctx, "gradle")
 	if err != nil {
-		log.Println(err)
 		return err
 	}
 
 	_, err = filepath.Abs(fname)
 	if err != nil {
-		log.Println(err)
 		return err
 	}
 
@@ -5,9 +5,11 @@ package javagradle
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"fmt"
-	"os/exec"
 	"strings"
+
+	"github.com/opensbom-generator/parsers/internal/helper"
 )
 
 type projectInfo struct {
@@ -17,13 +19,12 @@ type projectInfo struct {
 }
 
 // returns name, version
-func getProjectInfo(path string) (projectInfo, error) {
-	cmd := newGradleExec(path).run("properties", "-q")
-	out, err := cmd.CombinedOutput()
+func getProjectInfo(ctx context.Context, path string) (projectInfo, error) {
+	result, err := newGradleExec(path).run(ctx, "properties", "-q")
 	if err != nil {
 		return projectInfo{}, err
 	}
-	return parseProjectInfo(out)
+	return parseProjectInfo(result.Combined())
 }
 
 func parseProjectInfo(out []byte) (projectInfo, error) {
@@ -71,12 +72,12 @@ func parseProjectInfo(out []byte) (projectInfo, error) {
 
 // origin, hash
 // perhaps this can be moved to util
-func getGitInfo() (string, string, error) {
-	sha, err := exec.Command("git", "describe", `--match=""`, "--always", "--abbrev=40", "--dirty").Output()
+func getGitInfo(ctx context.Context) (string, string, error) {
+	sha, err := helper.Output(ctx, "", "git", "describe", `--match=""`, "--always", "--abbrev=40", "--dirty")
 	if err != nil {
 		return "", "", err
 	}
-	origin, err := exec.Command("git", "config", "--get", "remote.origin.url").Output()
+	origin, err := helper.Output(ctx, "", "git", "config", "--get", "remote.origin.url")
 	if err != nil {
 		return "", "", err
 	}
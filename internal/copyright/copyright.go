@@ -0,0 +1,299 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package copyright extracts the copyright statements of packages
+package copyright
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Statement is a copyright statement: the holder and the years of the
+// copyright, which may be unknown. Present is set when the last years run
+// to the present, as in "2014-present".
+type Statement struct {
+	Years   []int
+	Present bool
+	Holder  string
+}
+
+// String returns the statement in the canonical form, e.g.
+// "Copyright (c) 2012-2014, 2016 Jane Doe", with the years merged in ranges
+func (s Statement) String() string {
+	if len(s.Years) == 0 {
+		return "Copyright (c) " + s.Holder
+	}
+
+	return fmt.Sprintf("Copyright (c) %s %s", yearRanges(s.Years, s.Present), s.Holder)
+}
+
+// Text returns the statements one per line, the way packages record their
+// copyright text
+func Text(statements []Statement) string {
+	lines := make([]string, 0, len(statements))
+	for _, s := range statements {
+		lines = append(lines, s.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+var (
+	// marker matches the words and symbols starting a statement
+	marker = regexp.MustCompile(`(?i)\bcopyrights?\b|©|\(c\)`)
+	// commentDecoration matches the comment delimiters around the lines of
+	// source headers
+	commentDecoration = regexp.MustCompile(`^\s*(?://+!?|/\*+!?|\*+|#+|;+|--+|<!--|%+|'''|"""|rem\s)?\s*|\s*(?:\*+/|-->|'''|""")\s*$`)
+	// yearPattern matches a year or a range of years at the start of a text
+	yearPattern = regexp.MustCompile(`^(\d{4})(?:\s*[-–~]\s*(\d{4}|\d{2}\b|present\b))?[\s,;:.]*`)
+	// trailer matches the end of the line following the holder
+	trailer = regexp.MustCompile(`(?i)[,;]?\s*(?:all rights reserved|some rights reserved|licensed\b|released under|distributed under|use of this source code|see the\s|see license).*$`)
+	// placeholder matches the template holders of license texts, such as
+	// [name of copyright owner] or <name of author>, but not emails
+	placeholder = regexp.MustCompile(`[\[{]|<[^@>]*>`)
+)
+
+// notHolders are the first words following "copyright" in the license
+// texts, e.g. "copyright notice" or "copyright holders", when it starts no
+// statement
+var notHolders = map[string]bool{
+	"notice": true, "notices": true, "holder": true, "holders": true, "owner": true, "owners": true,
+	"law": true, "laws": true, "and": true, "or": true, "the": true, "to": true, "of": true, "in": true,
+	"for": true, "on": true, "is": true, "may": true, "shall": true, "under": true, "statement": true,
+	"statements": true, "license": true, "licence": true, "protection": true, "claim": true,
+	"claims": true, "interest": true, "infringement": true, "disclaimer": true, "year": true,
+	"date": true, "information": true, "text": true, "file": true, "files": true, "header": true,
+}
+
+// abbreviations keep their final dot in holders followed by a sentence
+var abbreviations = map[string]bool{
+	"inc": true, "ltd": true, "co": true, "corp": true, "llc": true, "al": true, "etc": true,
+	"jr": true, "sr": true, "gmbh": true, "ag": true, "bv": true, "plc": true,
+}
+
+// maxHolder is the length above which a holder is prose, not a name
+const maxHolder = 200
+
+// Extract returns the distinct copyright statements of a text, the
+// statements of a holder merged into one
+func Extract(text string) []Statement {
+	c := &collector{}
+	c.addText(text)
+
+	return c.statements
+}
+
+// collector merges the statements found in the texts of a package
+type collector struct {
+	statements []Statement
+	index      map[string]int
+}
+
+func (c *collector) addText(text string) {
+	continued := false
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(commentDecoration.ReplaceAllString(line, ""))
+		found := false
+		for _, s := range parseLine(line, continued) {
+			c.add(s)
+			found = true
+		}
+		continued = found
+	}
+}
+
+func (c *collector) add(s Statement) {
+	key := holderKey(s.Holder)
+	if i, ok := c.index[key]; ok {
+		c.statements[i].Years = mergeYears(c.statements[i].Years, s.Years)
+		c.statements[i].Present = c.statements[i].Present || s.Present
+		return
+	}
+	if c.index == nil {
+		c.index = map[string]int{}
+	}
+	c.index[key] = len(c.statements)
+	c.statements = append(c.statements, Statement{Years: mergeYears(nil, s.Years), Present: s.Present, Holder: s.Holder})
+}
+
+// parseLine returns the statements of a line. A line continuing the ones
+// of the previous line may start with the years of another holder.
+func parseLine(line string, continued bool) []Statement {
+	locations := marker.FindAllStringIndex(line, -1)
+	if len(locations) == 0 {
+		if continued && yearPattern.MatchString(line) {
+			if s, ok := parseStatement(line, true, true); ok {
+				return []Statement{s}
+			}
+		}
+		return nil
+	}
+
+	// markers only separated by spaces, as in "Copyright (c)", start the
+	// same statement
+	starts := []int{locations[0][0]}
+	for i := 1; i < len(locations); i++ {
+		if strings.TrimSpace(line[locations[i-1][1]:locations[i][0]]) != "" {
+			starts = append(starts, locations[i][0])
+		}
+	}
+
+	statements := []Statement{}
+	for i, start := range starts {
+		end := len(line)
+		if i+1 < len(starts) {
+			end = starts[i+1]
+		}
+		if s, ok := parseStatement(line[start:end], start == 0, end == len(line)); ok {
+			statements = append(statements, s)
+		}
+	}
+
+	return statements
+}
+
+// parseStatement parses the text of a statement, which starts with its
+// markers. The word copyright is frequent in license texts, so without years
+// the statement must start its line or use a symbol, and a bare (c) needs
+// years. A statement followed by more text on its line, last being false,
+// ends with a sentence.
+func parseStatement(text string, leading, last bool) (Statement, bool) {
+	lower := strings.ToLower(text)
+	word := strings.Contains(lower, "copyright") || strings.Contains(text, "©")
+	symbol := strings.Contains(text, "©") || strings.Contains(lower, "(c)")
+	rest := strings.TrimSpace(marker.ReplaceAllString(text, " "))
+	if trailer.MatchString(rest) {
+		rest = trailer.ReplaceAllString(rest, "")
+		last = false
+	}
+
+	years := []int{}
+	present := false
+	for {
+		m := yearPattern.FindStringSubmatch(rest)
+		if m == nil {
+			break
+		}
+		years = append(years, yearRange(m[1], m[2])...)
+		present = present || m[2] == "present"
+		rest = rest[len(m[0]):]
+	}
+	rest = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(rest), "by "))
+	// the years may follow the holder too
+	for {
+		i := strings.LastIndexAny(rest, " ,")
+		if i < 0 {
+			break
+		}
+		m := yearPattern.FindStringSubmatch(rest[i+1:])
+		if m == nil || len(m[0]) != len(rest[i+1:]) {
+			break
+		}
+		years = append(years, yearRange(m[1], m[2])...)
+		present = present || m[2] == "present"
+		rest = rest[:i]
+	}
+
+	holder := cleanHolder(rest, !last)
+	switch {
+	case holder == "", len(holder) > maxHolder, placeholder.MatchString(holder):
+		return Statement{}, false
+	case len(years) > 0:
+		return Statement{Years: years, Present: present, Holder: holder}, true
+	case !word, !leading && !symbol:
+		return Statement{}, false
+	}
+	first := strings.ToLower(strings.Trim(strings.Fields(holder)[0], ",.:;"))
+	if notHolders[first] || !unicode.IsUpper([]rune(holder)[0]) {
+		return Statement{}, false
+	}
+
+	return Statement{Holder: holder}, true
+}
+
+// cleanHolder trims the separators around a holder and collapses its
+// spaces. The final dot of the holder is its own, as in "Inc.", unless the
+// holder is followed by another sentence.
+func cleanHolder(s string, sentence bool) string {
+	s = strings.Join(strings.Fields(s), " ")
+	s = strings.Trim(s, " ,;:-")
+	if strings.HasSuffix(s, ".") && sentence {
+		base := strings.TrimSuffix(s, ".")
+		words := strings.Fields(base)
+		if len(words) > 0 && !abbreviations[strings.ToLower(words[len(words)-1])] {
+			s = base
+		}
+	}
+
+	return strings.TrimSpace(s)
+}
+
+// holderKey identifies the holders written differently
+func holderKey(holder string) string {
+	return strings.TrimSuffix(strings.ToLower(holder), ".")
+}
+
+// yearRange returns the years from start to end, end being empty, two
+// digits or "present", which is only recorded by the statement
+func yearRange(start, end string) []int {
+	from, _ := strconv.Atoi(start)
+	to := from
+	switch {
+	case len(end) == 4:
+		to, _ = strconv.Atoi(end)
+	case len(end) == 2:
+		n, _ := strconv.Atoi(end)
+		to = from/100*100 + n
+	}
+	if to < from || to-from > 100 {
+		return []int{from}
+	}
+	years := make([]int, 0, to-from+1)
+	for y := from; y <= to; y++ {
+		years = append(years, y)
+	}
+
+	return years
+}
+
+// mergeYears returns the sorted union of the years
+func mergeYears(years, more []int) []int {
+	seen := map[int]bool{}
+	merged := []int{}
+	for _, y := range append(append([]int{}, years...), more...) {
+		if !seen[y] {
+			seen[y] = true
+			merged = append(merged, y)
+		}
+	}
+	sort.Ints(merged)
+
+	return merged
+}
+
+// yearRanges writes sorted years as ranges, e.g. "2012-2014, 2016", the
+// last range running to the present when present is set
+func yearRanges(years []int, present bool) string {
+	parts := []string{}
+	for i := 0; i < len(years); {
+		j := i
+		for j+1 < len(years) && years[j+1] == years[j]+1 {
+			j++
+		}
+		switch {
+		case present && j == len(years)-1:
+			parts = append(parts, fmt.Sprintf("%d-present", years[i]))
+		case j == i:
+			parts = append(parts, strconv.Itoa(years[i]))
+		default:
+			parts = append(parts, fmt.Sprintf("%d-%d", years[i], years[j]))
+		}
+		i = j + 1
+	}
+
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package copyright
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtract(t *testing.T) {
+	for _, tc := range []struct {
+		text     string
+		expected []string
+	}{
+		{"Copyright (c) 2012 Nevins Bartolomeo <nevins.bartolomeo@gmail.com>", []string{"Copyright (c) 2012 Nevins Bartolomeo <nevins.bartolomeo@gmail.com>"}},
+		{"Copyright (c) Dylan Greene", []string{"Copyright (c) Dylan Greene"}},
+		{"© 2019 Jane Doe. All rights reserved.", []string{"Copyright (c) 2019 Jane Doe"}},
+		{"(C) 2001-03 ACME Inc.", []string{"Copyright (c) 2001-2003 ACME Inc."}},
+		{"Copyright 2014, 2016 Jane Doe\nCopyright 2015 jane doe\ncopyright 2018-2019 Jane Doe.", []string{"Copyright (c) 2014-2016, 2018-2019 Jane Doe"}},
+		{"Copyright (c) 2010 The Go Authors. Copyright 2012 Google Inc.", []string{"Copyright (c) 2010 The Go Authors", "Copyright (c) 2012 Google Inc."}},
+		{"Copyright (C) 1995-1997 A\n              2004 B", []string{"Copyright (c) 1995-1997 A", "Copyright (c) 2004 B"}},
+		{"// Copyright 2009 The Go Authors. All rights reserved.\n// Use of this source code is governed by a BSD-style", []string{"Copyright (c) 2009 The Go Authors"}},
+		{" * Copyright Jane Doe 2020\n */", []string{"Copyright (c) 2020 Jane Doe"}},
+		{"Copyright (c) 2014-present Matt Zabriskie", []string{"Copyright (c) 2014-present Matt Zabriskie"}},
+		{"Copyright 2012, 2014-present Jane Doe\nCopyright 2013 Jane Doe", []string{"Copyright (c) 2012-present Jane Doe"}},
+		{"Copyright (c) Facebook, Inc. and its affiliates.", []string{"Copyright (c) Facebook, Inc. and its affiliates."}},
+		{"Copyright 2020 ACME Inc. All rights reserved.", []string{"Copyright (c) 2020 ACME Inc."}},
+		// the license texts
+		{"The above copyright notice and this permission notice shall be included", nil},
+		{"Copyright [yyyy] [name of copyright owner]", nil},
+		{"Copyright (C) <year>  <name of author>", nil},
+		{"copyright notice that is included in or attached to the work", nil},
+		{"subject to section 2(c) of the license", nil},
+	} {
+		texts := []string{}
+		for _, s := range Extract(tc.text) {
+			texts = append(texts, s.String())
+		}
+		if tc.expected == nil {
+			tc.expected = []string{}
+		}
+		assert.Equal(t, tc.expected, texts, tc.text)
+	}
+}
+
+func TestScan(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, text string) {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(text), 0o644))
+	}
+	write("LICENSE", "MIT License\n\nCopyright (c) 2020 Jane Doe\n\nThe above copyright notice ...")
+	write("NOTICE", "This product includes software developed at\nACME (https://acme.example).\nCopyright 2018 ACME Corp.")
+	write("src/main.go", "// Copyright 2021 Jane Doe\n\npackage main\n\n// Copyright 1999 Not A Header\n")
+	write("node_modules/dep/LICENSE", "Copyright (c) 2000 Dependency Author")
+
+	statements, err := Scan(dir, DefaultLimit)
+	require.NoError(t, err)
+	assert.Equal(t, "Copyright (c) 2020-2021 Jane Doe\nCopyright (c) 2018 ACME Corp.", Text(statements))
+
+	statements, err = Scan(dir, len("MIT License\n\nCopyright (c) 2020 Jane Doe"))
+	require.NoError(t, err)
+	assert.Equal(t, "Copyright (c) 2020 Jane Doe", Text(statements))
+
+	write("LICENSE", strings.Repeat("x", 100))
+	statements, err = Scan(dir, 100)
+	require.NoError(t, err)
+	assert.Empty(t, statements)
+
+	_, err = Scan(filepath.Join(dir, "missing"), DefaultLimit)
+	assert.Error(t, err)
+}
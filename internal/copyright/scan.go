@@ -0,0 +1,200 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package copyright
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+var errBinary = errors.New("binary file")
+
+// DefaultLimit is how much text, in bytes, Scan reads in a package
+const DefaultLimit = 1 << 20
+
+const (
+	// headerSize is how much of a source file is read for its header
+	headerSize = 8 << 10
+	// maxFiles caps the files listed in a package, the directories of
+	// large packages hold many more sources than needed
+	maxFiles = 2000
+)
+
+// noticePrefixes start the names of the files holding the copyright
+// statements of a package
+var noticePrefixes = []string{"license", "licence", "copying", "copyright", "notice", "authors"}
+
+// licenseDirs hold license texts, as the LICENSES directory of REUSE
+// compliant projects
+var licenseDirs = map[string]bool{"licenses": true, "licences": true}
+
+// sourceExtensions are the extensions of the source files whose header is
+// read
+var sourceExtensions = map[string]bool{
+	".go": true, ".c": true, ".h": true, ".cc": true, ".cpp": true, ".cxx": true, ".hpp": true,
+	".java": true, ".kt": true, ".scala": true, ".groovy": true, ".js": true, ".mjs": true,
+	".cjs": true, ".jsx": true, ".ts": true, ".tsx": true, ".py": true, ".rb": true, ".rs": true,
+	".php": true, ".cs": true, ".fs": true, ".swift": true, ".m": true, ".mm": true, ".pl": true,
+	".pm": true, ".sh": true, ".lua": true, ".dart": true, ".ex": true, ".exs": true, ".erl": true,
+	".hs": true, ".css": true, ".scss": true, ".vue": true,
+}
+
+// skippedDirs hold other packages or test data
+var skippedDirs = map[string]bool{
+	"node_modules": true, "vendor": true, "testdata": true, "bower_components": true,
+}
+
+// Scan returns the distinct copyright statements of the package in dir,
+// found in its license, notice and authors files and then in the headers
+// of its source files. It reads at most limit bytes.
+func Scan(dir string, limit int) ([]Statement, error) {
+	notices, sources, err := listFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &collector{}
+	left := limit
+	for _, f := range notices {
+		if left <= 0 {
+			break
+		}
+		text, err := readFile(f, left)
+		if err != nil {
+			continue
+		}
+		left -= len(text)
+		c.addText(text)
+	}
+	for _, f := range sources {
+		if left <= 0 {
+			break
+		}
+		size := headerSize
+		if left < size {
+			size = left
+		}
+		text, err := readFile(f, size)
+		if err != nil {
+			continue
+		}
+		left -= len(text)
+		c.addText(header(text))
+	}
+
+	return c.statements, nil
+}
+
+// listFiles returns the notice files of dir, the least deep first, and its
+// source files
+func listFiles(dir string) (notices, sources []string, err error) {
+	count := 0
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == dir {
+				return err
+			}
+			return nil
+		}
+		name := d.Name()
+		if d.IsDir() {
+			if path != dir && (strings.HasPrefix(name, ".") || skippedDirs[name]) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		count++
+		if count > maxFiles {
+			return filepath.SkipAll
+		}
+		// the license texts may be in a LICENSES directory too
+		switch {
+		case sourceExtensions[strings.ToLower(filepath.Ext(name))]:
+			sources = append(sources, path)
+		case isNotice(name), licenseDirs[strings.ToLower(filepath.Base(filepath.Dir(path)))]:
+			notices = append(notices, path)
+		}
+		return nil
+	})
+	sort.SliceStable(notices, func(i, j int) bool {
+		return strings.Count(notices[i], string(filepath.Separator)) < strings.Count(notices[j], string(filepath.Separator))
+	})
+
+	return notices, sources, err
+}
+
+func isNotice(name string) bool {
+	name = strings.ToLower(name)
+	for _, prefix := range noticePrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func readFile(path string, limit int) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, int64(limit)))
+	if err != nil {
+		return "", err
+	}
+	if bytes.IndexByte(data, 0) >= 0 {
+		return "", errBinary
+	}
+
+	return strings.ToValidUTF8(string(data), ""), nil
+}
+
+// header returns the comments starting a source file
+func header(text string) string {
+	lines := []string{}
+	block := ""
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case block != "":
+			if strings.Contains(trimmed, block) {
+				block = ""
+			}
+		case trimmed == "", strings.HasPrefix(trimmed, "//"), strings.HasPrefix(trimmed, "#"),
+			strings.HasPrefix(trimmed, "--"), strings.HasPrefix(trimmed, ";"), strings.HasPrefix(trimmed, "%"):
+		case strings.HasPrefix(trimmed, "/*"):
+			if !strings.Contains(trimmed[2:], "*/") {
+				block = "*/"
+			}
+		case strings.HasPrefix(trimmed, "<!--"):
+			if !strings.Contains(trimmed[4:], "-->") {
+				block = "-->"
+			}
+		case strings.HasPrefix(trimmed, `"""`), strings.HasPrefix(trimmed, "'''"):
+			if !strings.Contains(trimmed[3:], trimmed[:3]) {
+				block = trimmed[:3]
+			}
+		case strings.HasPrefix(trimmed, "<?php"), strings.HasPrefix(trimmed, "'use strict'"), strings.HasPrefix(trimmed, `"use strict"`):
+		default:
+			return strings.Join(lines, "\n")
+		}
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n")
+}
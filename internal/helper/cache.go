@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package helper
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+const defaultCacheTTL = 24 * time.Hour
+
+// cache keeps registry responses on disk. Bodies are stored once under
+// their sha256 in blobs/, index/ maps the sha256 of the method and URL of a
+// request to the blob of its response.
+type cache struct {
+	dir string
+	ttl time.Duration
+}
+
+type cacheEntry struct {
+	Method      string    `json:"method"`
+	URL         string    `json:"url"`
+	Digest      string    `json:"digest"`
+	ContentType string    `json:"contentType,omitempty"`
+	Fetched     time.Time `json:"fetched"`
+}
+
+func newCache(dir string, ttl time.Duration) *cache {
+	if dir == "" {
+		return nil
+	}
+	if ttl == 0 {
+		ttl = defaultCacheTTL
+	}
+
+	return &cache{dir: dir, ttl: ttl}
+}
+
+func cacheable(req *http.Request) bool {
+	return (req.Method == http.MethodGet || req.Method == http.MethodHead) &&
+		(req.Body == nil || req.Body == http.NoBody)
+}
+
+func (c *cache) indexPath(req *http.Request) string {
+	sum := sha256.Sum256([]byte(req.Method + " " + req.URL.String()))
+	return filepath.Join(c.dir, "index", hex.EncodeToString(sum[:])+".json")
+}
+
+func (c *cache) blobPath(digest string) string {
+	return filepath.Join(c.dir, "blobs", digest)
+}
+
+// get returns the cached response of the request, entries older than the
+// TTL are only returned when stale is true
+func (c *cache) get(req *http.Request, stale bool) (*http.Response, bool) {
+	raw, err := os.ReadFile(c.indexPath(req))
+	if err != nil {
+		return nil, false
+	}
+	entry := cacheEntry{}
+	if err := json.Unmarshal(raw, &entry); err != nil || entry.URL != req.URL.String() {
+		return nil, false
+	}
+	if !stale && c.ttl > 0 && time.Since(entry.Fetched) > c.ttl {
+		return nil, false
+	}
+
+	body, err := os.ReadFile(c.blobPath(entry.Digest))
+	if err != nil {
+		return nil, false
+	}
+	sum := sha256.Sum256(body)
+	if hex.EncodeToString(sum[:]) != entry.Digest {
+		return nil, false
+	}
+
+	header := http.Header{}
+	if entry.ContentType != "" {
+		header.Set("Content-Type", entry.ContentType)
+	}
+	header.Set("Content-Length", strconv.Itoa(len(body)))
+
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, true
+}
+
+// put stores the response and returns it with its body read back from
+// memory
+func (c *cache) put(req *http.Request, resp *http.Response) (*http.Response, error) {
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	sum := sha256.Sum256(body)
+	entry := cacheEntry{
+		Method:      req.Method,
+		URL:         req.URL.String(),
+		Digest:      hex.EncodeToString(sum[:]),
+		ContentType: resp.Header.Get("Content-Type"),
+		Fetched:     time.Now().UTC(),
+	}
+	raw, err := json.Marshal(entry)
+	if err != nil {
+		return resp, err
+	}
+
+	if err := writeFileAtomic(c.blobPath(entry.Digest), body); err != nil {
+		return resp, fmt.Errorf("writing cache: %w", err)
+	}
+	if err := writeFileAtomic(c.indexPath(req), raw); err != nil {
+		return resp, fmt.Errorf("writing cache: %w", err)
+	}
+
+	return resp, nil
+}
+
+// writeFileAtomic writes the file through a temporary file so concurrent
+// runs never read a partial file
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
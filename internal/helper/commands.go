@@ -3,9 +3,9 @@
 package helper
 
 import (
+	"context"
 	"errors"
 	"io"
-	"os/exec"
 )
 
 var errEmptyArgs = errors.New("at least one argument is required")
@@ -15,12 +15,16 @@ type CmdOptions struct {
 	Name      string
 	Args      []string
 	Directory string
+	// Context kills the command when done and carries the Runner it runs
+	// with, defaults to context.Background()
+	Context context.Context
+	// Env is added to the environment of the current process
+	Env []string
 }
 
 // Cmd ...
 type Cmd struct {
 	options CmdOptions
-	cmd     *exec.Cmd
 }
 
 // NewCmd ...
@@ -36,24 +40,36 @@ func (c *Cmd) Build() error {
 		return errEmptyArgs
 	}
 
-	// TODO: review this function
-	c.cmd = exec.Command(c.options.Name, c.options.Args...) //nolint: gosec
-	c.cmd.Dir = c.options.Directory
+	if c.options.Context == nil {
+		c.options.Context = context.Background()
+	}
 
 	return nil
 }
 
+func (c *Cmd) run() (Result, error) {
+	return Run(c.options.Context, Command{
+		Name: c.options.Name,
+		Args: c.options.Args,
+		Dir:  c.options.Directory,
+		Env:  c.options.Env,
+	})
+}
+
 // Execute ...
 func (c *Cmd) Execute(w io.Writer) error {
-	c.cmd.Stdout = w
-	return c.cmd.Run()
+	result, err := c.run()
+	if _, writeErr := w.Write(result.Stdout); writeErr != nil && err == nil {
+		err = writeErr
+	}
+	return err
 }
 
 // Execute ...
 func (c *Cmd) Output() (string, error) {
-	output, err := c.cmd.Output()
+	result, err := c.run()
 	if err != nil {
 		return "", err
 	}
-	return string(output), err
+	return string(result.Stdout), err
 }
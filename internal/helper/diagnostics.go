@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package helper
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/opensbom-generator/parsers/meta"
+)
+
+// Diagnostics collects the problems reported during a plugin call
+type Diagnostics struct {
+	mu   sync.Mutex
+	list meta.Diagnostics
+}
+
+// List returns the problems reported so far
+func (d *Diagnostics) List() meta.Diagnostics {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return append(meta.Diagnostics{}, d.list...)
+}
+
+func (d *Diagnostics) add(diagnostic meta.Diagnostic) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.list = append(d.list, diagnostic)
+}
+
+type diagnosticsKey struct{}
+
+// WithDiagnostics returns a copy of ctx collecting the problems reported
+// with it in the returned Diagnostics
+func WithDiagnostics(ctx context.Context) (context.Context, *Diagnostics) {
+	d := &Diagnostics{}
+	return context.WithValue(ctx, diagnosticsKey{}, d), d
+}
+
+// Report records a problem about pkg, an empty pkg concerns the whole
+// project. Without a collector on ctx the problem is logged.
+func Report(ctx context.Context, severity meta.Severity, code, pkg, format string, args ...interface{}) {
+	diagnostic := meta.Diagnostic{
+		Severity: severity,
+		Code:     code,
+		Package:  pkg,
+		Message:  fmt.Sprintf(format, args...),
+	}
+	if d, ok := ctx.Value(diagnosticsKey{}).(*Diagnostics); ok {
+		d.add(diagnostic)
+		return
+	}
+
+	switch severity {
+	case meta.SeverityError:
+		log.Error(diagnostic.Message)
+	case meta.SeverityWarning:
+		log.Warn(diagnostic.Message)
+	default:
+		log.Info(diagnostic.Message)
+	}
+}
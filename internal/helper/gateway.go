@@ -3,23 +3,396 @@
 package helper
 
 import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
+const (
+	defaultTimeout   = 30 * time.Second
+	defaultRetries   = 2
+	defaultBackoff   = 500 * time.Millisecond
+	defaultUserAgent = "opensbom-generator-parsers"
+)
+
+// ClientOptions configures the HTTP client shared by the plugins. Unset
+// fields are read from the environment:
+//
+//	SBOM_<ECOSYSTEM>_MIRROR     mirror of the ecosystem registry
+//	SBOM_<ECOSYSTEM>_TOKEN      bearer token sent to the mirror
+//	SBOM_<ECOSYSTEM>_USERNAME   basic auth user name sent to the mirror
+//	SBOM_<ECOSYSTEM>_PASSWORD   basic auth password sent to the mirror
+//	SBOM_CACHE_DIR              directory of the response cache
+//	SBOM_CACHE_TTL              lifetime of cached responses, e.g. 72h
+//	SBOM_CACHE_ONLY             serve every request from the cache when true
+//	NETRC                       netrc file, defaults to ~/.netrc
+//	HTTP_PROXY, HTTPS_PROXY and NO_PROXY
+//
+// where ECOSYSTEM is the upper cased purl type, e.g. SBOM_PYPI_MIRROR.
+type ClientOptions struct {
+	// Mirrors replaces the default registry of an ecosystem, keyed by purl
+	// type, e.g. "pypi": "https://pypi.example.com/pypi"
+	Mirrors map[string]string
+	// Credentials authenticates the requests sent to a host, keyed by host
+	// name. Hosts without credentials fall back to the netrc file.
+	Credentials map[string]Credentials
+	// Netrc is the path of the netrc file
+	Netrc string
+	// Proxy is the URL of the proxy all requests go through
+	Proxy     string
+	UserAgent string
+	Timeout   time.Duration
+	// Retries is the number of times a request failing with a network
+	// error, 429 or 5xx status is sent again, a negative value disables
+	// retries
+	Retries int
+	// Backoff is the delay before the first retry, doubled on each retry
+	Backoff time.Duration
+	// CacheDir is where successful GET and HEAD responses are kept, the
+	// cache is disabled when empty
+	CacheDir string
+	// CacheTTL is how long cached responses are used before being fetched
+	// again, defaults to a day, a negative value never expires them
+	CacheTTL time.Duration
+	// CacheOnly never reaches the network: requests are served from the
+	// cache, expired or not, and fail with ErrOffline when not cached
+	CacheOnly bool
+}
+
+// Credentials authenticates a request, a token is sent as a bearer token
+// and takes precedence over the user name and password
+type Credentials struct {
+	Token    string
+	Username string
+	Password string
+}
+
+// Client is the HTTP client used for every request made by the plugins
 type Client struct {
-	HTTP *http.Client
+	HTTP    *http.Client
+	options ClientOptions
+	netrc   map[string]Credentials
+	cache   *cache
 }
 
-// NewClient
-// todo: complete proper client settings
-func NewClient() *Client {
+// NewClient returns a client configured by opts and the environment
+func NewClient(opts ClientOptions) (*Client, error) {
+	opts = withEnvironment(opts)
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if opts.Proxy != "" {
+		proxy, err := url.Parse(opts.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("parsing proxy url: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	netrc, err := readNetrc(opts.Netrc)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Client{
 		HTTP: &http.Client{
-			Timeout: time.Second * 5,
+			Timeout:   opts.Timeout,
+			Transport: transport,
 		},
+		options: opts,
+		netrc:   netrc,
+		cache:   newCache(opts.CacheDir, opts.CacheTTL),
+	}, nil
+}
+
+func withEnvironment(opts ClientOptions) ClientOptions {
+	mirrors := map[string]string{}
+	credentials := map[string]Credentials{}
+	for _, env := range os.Environ() {
+		key, value, _ := strings.Cut(env, "=")
+		if !strings.HasPrefix(key, "SBOM_") || !strings.HasSuffix(key, "_MIRROR") || value == "" {
+			continue
+		}
+		ecosystem := strings.TrimSuffix(strings.TrimPrefix(key, "SBOM_"), "_MIRROR")
+		mirrors[strings.ToLower(ecosystem)] = value
+	}
+	for ecosystem, mirror := range opts.Mirrors {
+		mirrors[ecosystem] = mirror
+	}
+
+	for ecosystem, mirror := range mirrors {
+		u, err := url.Parse(mirror)
+		if err != nil || u.Hostname() == "" {
+			continue
+		}
+		prefix := "SBOM_" + strings.ToUpper(ecosystem) + "_"
+		creds := Credentials{
+			Token:    os.Getenv(prefix + "TOKEN"),
+			Username: os.Getenv(prefix + "USERNAME"),
+			Password: os.Getenv(prefix + "PASSWORD"),
+		}
+		if creds != (Credentials{}) {
+			credentials[u.Hostname()] = creds
+		}
+	}
+	for host, creds := range opts.Credentials {
+		credentials[host] = creds
+	}
+
+	opts.Mirrors = mirrors
+	opts.Credentials = credentials
+	if opts.Netrc == "" {
+		opts.Netrc = os.Getenv("NETRC")
+	}
+	if opts.Netrc == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			opts.Netrc = filepath.Join(home, ".netrc")
+		}
+	}
+	if opts.CacheDir == "" {
+		opts.CacheDir = os.Getenv("SBOM_CACHE_DIR")
+	}
+	if ttl, err := time.ParseDuration(os.Getenv("SBOM_CACHE_TTL")); opts.CacheTTL == 0 && err == nil {
+		opts.CacheTTL = ttl
+	}
+	if only, err := strconv.ParseBool(os.Getenv("SBOM_CACHE_ONLY")); !opts.CacheOnly && err == nil {
+		opts.CacheOnly = only
+	}
+	if opts.UserAgent == "" {
+		opts.UserAgent = defaultUserAgent
+	}
+	if opts.Timeout == 0 {
+		opts.Timeout = defaultTimeout
+	}
+	if opts.Retries == 0 {
+		opts.Retries = defaultRetries
+	}
+	if opts.Backoff == 0 {
+		opts.Backoff = defaultBackoff
+	}
+
+	return opts
+}
+
+// readNetrc returns the credentials of the netrc file keyed by host, the
+// default entry is keyed by an empty host
+func readNetrc(path string) (map[string]Credentials, error) {
+	entries := map[string]Credentials{}
+	if path == "" {
+		return entries, nil
+	}
+
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading netrc: %w", err)
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	sc.Split(bufio.ScanWords)
+	var host *string
+	current := Credentials{}
+	flush := func() {
+		if host != nil {
+			entries[*host] = current
+		}
+		host, current = nil, Credentials{}
+	}
+	for sc.Scan() {
+		switch sc.Text() {
+		case "machine":
+			flush()
+			if sc.Scan() {
+				name := sc.Text()
+				host = &name
+			}
+		case "default":
+			flush()
+			name := ""
+			host = &name
+		case "login":
+			if sc.Scan() {
+				current.Username = sc.Text()
+			}
+		case "password":
+			if sc.Scan() {
+				current.Password = sc.Text()
+			}
+		}
+	}
+	flush()
+
+	return entries, sc.Err()
+}
+
+type clientKey struct{}
+
+var (
+	defaultClient     *Client
+	defaultClientOnce sync.Once
+)
+
+// WithClient returns a copy of ctx carrying the client
+func WithClient(ctx context.Context, c *Client) context.Context {
+	if c == nil {
+		return ctx
 	}
+
+	return context.WithValue(ctx, clientKey{}, c)
+}
+
+// ClientFrom returns the client carried by ctx or a client configured from
+// the environment
+func ClientFrom(ctx context.Context) *Client {
+	if c, ok := ctx.Value(clientKey{}).(*Client); ok {
+		return c
+	}
+
+	defaultClientOnce.Do(func() {
+		c, err := NewClient(ClientOptions{})
+		if err != nil {
+			// an unreadable netrc file leaves requests unauthenticated
+			opts := withEnvironment(ClientOptions{})
+			c = &Client{
+				HTTP:    &http.Client{Timeout: defaultTimeout},
+				options: opts,
+				cache:   newCache(opts.CacheDir, opts.CacheTTL),
+			}
+		}
+		defaultClient = c
+	})
+
+	return defaultClient
+}
+
+// MirrorURL returns rawURL on the mirror configured for the ecosystem, when
+// rawURL points to base, the default registry of the ecosystem
+func (c *Client) MirrorURL(ecosystem, base, rawURL string) string {
+	mirror, ok := c.options.Mirrors[ecosystem]
+	if !ok {
+		return rawURL
+	}
+
+	base = strings.TrimSuffix(base, "/")
+	if !strings.HasPrefix(rawURL, base) {
+		return rawURL
+	}
+	rest := strings.TrimPrefix(rawURL, base)
+	if rest != "" && !strings.HasPrefix(rest, "/") {
+		return rawURL
+	}
+
+	return strings.TrimSuffix(mirror, "/") + rest
+}
+
+// Do sends the request, authenticated with the credentials of its host.
+// Requests without a body failing with a network error, 429 or 5xx status
+// are retried with an exponential backoff. With a cache directory, GET and
+// HEAD requests are answered from the cache while the entry is fresh, and
+// from expired entries as well when offline or in cache-only mode.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	offline := c.Offline(req.Context())
+	useCache := c.cache != nil && cacheable(req)
+	if useCache {
+		if resp, ok := c.cache.get(req, offline); ok {
+			return resp, nil
+		}
+	}
+	if offline {
+		if c.cache != nil {
+			return nil, fmt.Errorf("%w: %s is not cached", ErrOffline, req.URL)
+		}
+		return nil, ErrOffline
+	}
+
+	resp, err := c.send(req)
+	if err != nil || !useCache || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+
+	// failing to write the cache only costs a fetch on the next run
+	cached, err := c.cache.put(req, resp)
+	if cached == nil {
+		return nil, err
+	}
+	return cached, nil
+}
+
+// Offline reports whether requests sent with ctx are kept off the network
+func (c *Client) Offline(ctx context.Context) bool {
+	return IsOffline(ctx) || c.options.CacheOnly
+}
+
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if req.Header.Get("User-Agent") == "" {
+		req.Header.Set("User-Agent", c.options.UserAgent)
+	}
+	c.authenticate(req)
+
+	backoff := c.options.Backoff
+	for attempt := 0; ; attempt++ {
+		resp, err := c.HTTP.Do(req)
+		if attempt >= c.options.Retries || !retryable(resp, err) || (req.Body != nil && req.Body != http.NoBody) || ctx.Err() != nil {
+			return resp, err
+		}
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+func (c *Client) authenticate(req *http.Request) {
+	if req.Header.Get("Authorization") != "" {
+		return
+	}
+
+	creds, ok := c.options.Credentials[req.URL.Hostname()]
+	if !ok {
+		creds, ok = c.netrc[req.URL.Hostname()]
+	}
+	if !ok {
+		creds, ok = c.netrc[""]
+	}
+	if !ok {
+		return
+	}
+
+	switch {
+	case creds.Token != "":
+		req.Header.Set("Authorization", "Bearer "+creds.Token)
+	case creds.Username != "":
+		req.SetBasicAuth(creds.Username, creds.Password)
+	}
+}
+
+func retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
 }
 
 // IsValidURL ...
@@ -33,8 +406,12 @@ func (c *Client) ParseURL(uri string) *url.URL {
 }
 
 // CheckURL ...
-func (c *Client) CheckURL(url string) bool {
-	r, err := c.HTTP.Get(url)
+func (c *Client) CheckURL(ctx context.Context, url string) bool {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return false
+	}
+	r, err := c.Do(req)
 	if err != nil {
 		return false
 	}
@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package helper
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientRetries(t *testing.T) {
+	calls, agent := 0, ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		agent = r.UserAgent()
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c, err := NewClient(ClientOptions{Netrc: os.DevNull, UserAgent: "test-agent", Backoff: time.Millisecond})
+	require.NoError(t, err)
+	assert.True(t, c.CheckURL(context.Background(), server.URL))
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, "test-agent", agent)
+
+	calls = 0
+	c, err = NewClient(ClientOptions{Netrc: os.DevNull, Retries: -1})
+	require.NoError(t, err)
+	assert.False(t, c.CheckURL(context.Background(), server.URL))
+	assert.Equal(t, 1, calls)
+}
+
+func TestClientAuthentication(t *testing.T) {
+	var auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+	}))
+	defer server.Close()
+	host := serverHost(t, server)
+
+	netrc := filepath.Join(t.TempDir(), "netrc")
+	require.NoError(t, os.WriteFile(netrc, []byte("machine "+host+"\n  login user\n  password secret\n"), 0o600))
+
+	c, err := NewClient(ClientOptions{Netrc: netrc})
+	require.NoError(t, err)
+	require.True(t, c.CheckURL(context.Background(), server.URL))
+	assert.Equal(t, "Basic dXNlcjpzZWNyZXQ=", auth)
+
+	c, err = NewClient(ClientOptions{Netrc: netrc, Credentials: map[string]Credentials{host: {Token: "abc"}}})
+	require.NoError(t, err)
+	require.True(t, c.CheckURL(context.Background(), server.URL))
+	assert.Equal(t, "Bearer abc", auth)
+
+	t.Setenv("SBOM_PYPI_MIRROR", server.URL+"/pypi")
+	t.Setenv("SBOM_PYPI_TOKEN", "from-env")
+	c, err = NewClient(ClientOptions{Netrc: os.DevNull})
+	require.NoError(t, err)
+	require.True(t, c.CheckURL(context.Background(), server.URL))
+	assert.Equal(t, "Bearer from-env", auth)
+}
+
+func TestMirrorURL(t *testing.T) {
+	c, err := NewClient(ClientOptions{
+		Netrc:   os.DevNull,
+		Mirrors: map[string]string{"nuget": "http://localhost:8080/nuget/"},
+	})
+	require.NoError(t, err)
+
+	base := "https://api.nuget.org/v3-flatcontainer/"
+	assert.Equal(t, "http://localhost:8080/nuget/a/1.0.0/a.nuspec", c.MirrorURL("nuget", base, base+"a/1.0.0/a.nuspec"))
+	assert.Equal(t, "https://api.nuget.org/v3-flatcontainer-other/a", c.MirrorURL("nuget", base, "https://api.nuget.org/v3-flatcontainer-other/a"))
+	assert.Equal(t, base+"a", c.MirrorURL("pypi", base, base+"a"))
+
+	ctx := WithClient(context.Background(), c)
+	assert.Same(t, c, ClientFrom(ctx))
+	assert.NotSame(t, c, ClientFrom(context.Background()))
+}
+
+func serverHost(t *testing.T, server *httptest.Server) string {
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	return u.Hostname()
+}
+
+func TestClientCache(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name":"a"}`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	get := func(c *Client, path string) (string, error) {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+path, nil)
+		require.NoError(t, err)
+		resp, err := c.Do(req)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+		body, err := io.ReadAll(resp.Body)
+		return string(body), err
+	}
+
+	c, err := NewClient(ClientOptions{Netrc: os.DevNull, CacheDir: dir})
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		body, err := get(c, "/a")
+		require.NoError(t, err)
+		assert.Equal(t, `{"name":"a"}`, body)
+	}
+	assert.Equal(t, 1, calls)
+
+	// bodies are stored under their digest
+	sum := sha256.Sum256([]byte(`{"name":"a"}`))
+	assert.FileExists(t, filepath.Join(dir, "blobs", hex.EncodeToString(sum[:])))
+
+	expired, err := NewClient(ClientOptions{Netrc: os.DevNull, CacheDir: dir, CacheTTL: time.Nanosecond})
+	require.NoError(t, err)
+	_, err = get(expired, "/a")
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	cacheOnly, err := NewClient(ClientOptions{Netrc: os.DevNull, CacheDir: dir, CacheTTL: time.Nanosecond, CacheOnly: true})
+	require.NoError(t, err)
+	body, err := get(cacheOnly, "/a")
+	require.NoError(t, err)
+	assert.Equal(t, `{"name":"a"}`, body)
+	_, err = get(cacheOnly, "/b")
+	assert.ErrorIs(t, err, ErrOffline)
+	assert.Equal(t, 2, calls)
+}
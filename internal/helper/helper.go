@@ -3,56 +3,13 @@
 package helper
 
 import (
-	"encoding/json"
-	"fmt"
 	"os"
-	"path/filepath"
-	"regexp"
 	"strings"
 
-	"github.com/go-enry/go-license-detector/v4/licensedb"
+	"github.com/opensbom-generator/parsers/internal/copyright"
 	"github.com/opensbom-generator/parsers/internal/license"
-
-	log "github.com/sirupsen/logrus"
-)
-
-const copyrightLookup = "copyright"
-
-// copyright matching level of preferences
-const (
-	matchLevel1 = iota
-	matchLevel2
-	matchLevel3
 )
 
-var re *regexp.Regexp
-
-func init() {
-	re = regexp.MustCompile(`^\d{4}$|^\d{4}-\d{4}$|^\(c\)$`)
-}
-
-type FileInfo struct {
-	Path string `json:"path,omitempty"`
-}
-
-type copyrightMap map[int]string
-
-func (c copyrightMap) get() string {
-	if c[matchLevel1] != "" {
-		return c[matchLevel1]
-	}
-
-	if c[matchLevel2] != "" {
-		return c[matchLevel2]
-	}
-
-	if c[matchLevel3] != "" {
-		return c[matchLevel3]
-	}
-
-	return ""
-}
-
 // Exists ...
 func Exists(filepath string) bool {
 	if _, err := os.Stat(filepath); os.IsNotExist(err) {
@@ -62,30 +19,11 @@ func Exists(filepath string) bool {
 	return true
 }
 
-// GetLicenses ...
-func GetLicenses(modulePath string) (*license.License, error) {
-	if modulePath != "" {
-		licenses := licensedb.Analyse(modulePath)
-		for i := range licenses {
-			for j := range licenses[i].Matches {
-				// returns the first element, the best match
-				return &license.License{ID: licenses[i].Matches[j].License,
-					Name:          licenses[i].Matches[j].License,
-					ExtractedText: extractLicenseContent(modulePath, licenses[i].Matches[j].File),
-					Comments:      "",
-					File:          licenses[i].Matches[j].File}, nil
-			}
-		}
-	}
-	return nil, fmt.Errorf("could not detect license for %s", modulePath)
-}
-
-// LicenseExist ...
+// LicenseSPDXExists reports whether the license is on the SPDX list,
+// regardless of case and including the deprecated identifiers
 func LicenseSPDXExists(licenseID string) bool {
-	if _, ok := license.DB[licenseID]; !ok {
-		return false
-	}
-	return true
+	_, ok := license.Resolve(licenseID)
+	return ok
 }
 
 // BuildModuleName ...
@@ -99,98 +37,34 @@ func BuildModuleName(path, replacePath, dirReplace string) string {
 	return path
 }
 
-// BuildLicenseDeclared ...
-// todo build rules to generate LicenseDeclated
-func BuildLicenseDeclared(license string) string {
-	if LicenseSPDXExists(license) {
-		return license
-	}
-
-	return fmt.Sprintf("LicenseRef-%s", license)
+// BuildLicenseDeclared returns the canonical SPDX expression of a declared
+// license, see license.Normalize
+func BuildLicenseDeclared(value string) string {
+	return license.Normalize(value)
 }
 
-// BuildLicenseConcluded ...
-// todo build rules to generate LicenseConcluded
-func BuildLicenseConcluded(license string) string {
-	if LicenseSPDXExists(license) {
-		return license
-	}
+// BuildLicenseConcluded returns the canonical SPDX expression of a concluded
+// license, see license.Normalize
+func BuildLicenseConcluded(value string) string {
+	return license.Normalize(value)
+}
 
-	return fmt.Sprintf("LicenseRef-%s", license)
+// GetCopyright returns the distinct copyright statements of a license
+// text, one per line
+func GetCopyright(content string) string {
+	return copyright.Text(copyright.Extract(content))
 }
 
-// todo: figure out how to extract only required text
-func extractLicenseContent(path, filename string) string {
-	bytes, err := os.ReadFile(fmt.Sprintf("%s/%s", path, filename))
+// ScanCopyright returns the distinct copyright statements found in the
+// license, notice and authors files and the source headers of the package
+// in dir, one per line
+func ScanCopyright(dir string) string {
+	statements, err := copyright.Scan(dir, copyright.DefaultLimit)
 	if err != nil {
-		log.Errorf("Could not read license file: %v", err)
 		return ""
 	}
 
-	// extract license required segment
-	return string(bytes)
-}
-
-// GetCopyright parses the license file found at plugin module or vendor folder
-// Extract the text found starting with the keyword 'Copyright (c)' and until the newline
-func GetCopyright(content string) string {
-	cr := make(copyrightMap, 0)
-	// split by paragraph
-	paragraphs := strings.Split(content, "\n\n")
-	for _, p := range paragraphs {
-		if !strings.Contains(strings.ToLower(p), copyrightLookup) {
-			continue
-		}
-
-		lines := strings.Split(p, "\n")
-		if len(lines) == 0 {
-			continue
-		}
-
-		line := strings.TrimSpace(lines[0])
-		tokens := strings.Fields(line)
-		if len(tokens) == 0 {
-			continue
-		}
-
-		if strings.EqualFold(copyrightLookup, tokens[0]) {
-			cr[matchLevel2] = line
-			if re.MatchString(tokens[1]) {
-				cr[matchLevel1] = line
-			}
-		}
-
-		for _, l := range lines {
-			if strings.HasPrefix(strings.TrimSpace(strings.ToLower(l)), copyrightLookup) {
-				cr[matchLevel3] = strings.TrimSpace(l)
-				break
-			}
-		}
-	}
-
-	return cr.get()
-}
-
-// BuildManifestContent builds a content with directory tree
-func BuildManifestContent(path string) []byte {
-	manifest := []FileInfo{}
-	err := filepath.Walk(path, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return nil
-		}
-		manifest = append(manifest, FileInfo{
-			Path: path,
-		})
-		return nil
-	})
-
-	if err == nil && len(manifest) > 0 {
-		manifestBytes, err := json.Marshal(manifest)
-		if err == nil {
-			return manifestBytes
-		}
-	}
-	return nil
+	return copyright.Text(statements)
 }
 
 func RemoveURLProtocol(url string) string {
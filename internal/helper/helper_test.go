@@ -3,11 +3,13 @@
 package helper
 
 import (
+	"context"
 	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/opensbom-generator/parsers/internal/license"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/spdx/spdx-sbom-generator/pkg/reader"
@@ -37,3 +39,64 @@ func getPath() string {
 
 	return path
 }
+
+func TestWithOffline(t *testing.T) {
+	ctx := context.Background()
+	assert.False(t, IsOffline(ctx))
+	assert.NoError(t, CheckOnline(ctx))
+	assert.Equal(t, ctx, WithOffline(ctx, false))
+
+	offline := WithOffline(ctx, true)
+	assert.True(t, IsOffline(offline))
+	assert.ErrorIs(t, CheckOnline(offline), ErrOffline)
+	c, err := NewClient(ClientOptions{})
+	assert.NoError(t, err)
+	assert.False(t, c.CheckURL(offline, "https://example.com"))
+}
+
+func TestWithReadOnly(t *testing.T) {
+	ctx := context.Background()
+	assert.False(t, IsReadOnly(ctx))
+	assert.NoError(t, CheckWritable(ctx, "writing"))
+	assert.Equal(t, ctx, WithReadOnly(ctx, false))
+
+	readOnly := WithReadOnly(ctx, true)
+	assert.True(t, IsReadOnly(readOnly))
+	assert.ErrorIs(t, CheckWritable(readOnly, "writing"), ErrReadOnly)
+	assert.False(t, IsOffline(readOnly))
+}
+
+func TestBuildLicenseDeclared(t *testing.T) {
+	tests := map[string]string{
+		"":                              "",
+		"MIT":                           "MIT",
+		"mit or apache-2.0":             "MIT OR Apache-2.0",
+		"MIT/Apache-2.0":                "MIT OR Apache-2.0",
+		"GPL-2.0":                       "GPL-2.0-only",
+		"GPL-2.0+":                      "GPL-2.0-or-later",
+		"GPL v2 or later":               "GPL-2.0-or-later",
+		"Apache 2":                      "Apache-2.0",
+		"Apache License, Version 2.0":   "Apache-2.0",
+		"BSD":                           "BSD-3-Clause",
+		"MIT AND (ISC AND Zlib)":        "MIT AND ISC AND Zlib",
+		"(MIT OR ISC) AND BSD-2-Clause": "(MIT OR ISC) AND BSD-2-Clause",
+		"GPL-2.0-only with classpath-exception-2.0": "GPL-2.0-only WITH Classpath-exception-2.0",
+		"Apache-1.0+":           "Apache-1.0+",
+		"My Own License":        "LicenseRef-My-Own-License",
+		"MIT OR My Own License": "MIT OR LicenseRef-My-Own-License",
+	}
+	for value, expected := range tests {
+		assert.Equal(t, expected, BuildLicenseDeclared(value), value)
+	}
+
+	assert.True(t, LicenseSPDXExists("apache-2.0"))
+	assert.True(t, LicenseSPDXExists("GPL-2.0"))
+	assert.False(t, LicenseSPDXExists("Apache 2"))
+}
+
+func TestWithLicenseThreshold(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, float32(license.DefaultThreshold), LicenseThreshold(ctx))
+	assert.Equal(t, ctx, WithLicenseThreshold(ctx, 0))
+	assert.Equal(t, float32(0.95), LicenseThreshold(WithLicenseThreshold(ctx, 0.95)))
+}
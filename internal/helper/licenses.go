@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package helper
+
+import (
+	"context"
+	"strings"
+
+	"github.com/opensbom-generator/parsers/internal/license"
+	"github.com/opensbom-generator/parsers/meta"
+)
+
+type licenseThresholdKey struct{}
+
+// WithLicenseThreshold returns a copy of ctx keeping the detected licenses
+// whose confidence, from 0 to 1, is at least threshold. A zero threshold
+// keeps the default one.
+func WithLicenseThreshold(ctx context.Context, threshold float32) context.Context {
+	if threshold <= 0 {
+		return ctx
+	}
+
+	return context.WithValue(ctx, licenseThresholdKey{}, threshold)
+}
+
+// LicenseThreshold returns the confidence threshold of the license detection
+func LicenseThreshold(ctx context.Context) float32 {
+	if threshold, ok := ctx.Value(licenseThresholdKey{}).(float32); ok {
+		return threshold
+	}
+
+	return license.DefaultThreshold
+}
+
+// DetectLicenses returns every license found in the license files of dir
+// with the threshold of ctx
+func DetectLicenses(ctx context.Context, dir string) (*license.Detection, error) {
+	return license.Detect(dir, LicenseThreshold(ctx))
+}
+
+// SetLicenses sets the licenses of pkg from the expression declared in its
+// metadata and the licenses detected in the license files of dir, which may
+// be empty when the package is not on disk. The declared license is kept as
+// is, the concluded one merges both and their conflicts are noted in the
+// license comments. It returns the detection, nil when no license file
+// matched.
+func SetLicenses(ctx context.Context, pkg *meta.Package, declared, dir string) *license.Detection {
+	pkg.LicenseDeclared = license.Normalize(declared)
+
+	var detected *license.Detection
+	comments := []string{}
+	expression := ""
+	if dir != "" {
+		d, err := DetectLicenses(ctx, dir)
+		if err == nil {
+			detected = d
+			expression = d.Expression()
+			pkg.OtherLicense = append(pkg.OtherLicense, d.OtherLicenses()...)
+		} else if pkg.LicenseDeclared == "" {
+			Report(ctx, meta.SeverityWarning, meta.CodeLicenseNotDetected, pkg.Name, "could not detect license of %s: %v", pkg.Name, err)
+		}
+	} else if pkg.LicenseDeclared == "" {
+		Report(ctx, meta.SeverityWarning, meta.CodeLicenseNotDetected, pkg.Name, "could not detect license of %s", pkg.Name)
+	}
+
+	concluded, conflict := license.Reconcile(pkg.LicenseDeclared, expression)
+	pkg.LicenseConcluded = concluded
+	if conflict != "" {
+		Report(ctx, meta.SeverityWarning, meta.CodeLicenseConflict, pkg.Name, "conflicting licenses of %s: %s", pkg.Name, conflict)
+		comments = append(comments, conflict)
+	}
+	if detected != nil {
+		comments = append(comments, detected.Evidence())
+	}
+	pkg.CommentsLicense = strings.Join(comments, "\n")
+
+	return detected
+}
+
+// PackageJSONLicense returns the license declared in a decoded package.json:
+// the license field, an SPDX expression or a {"type": ...} object in older
+// packages, or the deprecated licenses list, whose licenses are to choose
+// from. "SEE LICENSE IN <file>" declares no expression, only the license
+// files tell it.
+func PackageJSONLicense(manifest map[string]interface{}) string {
+	values := []string{}
+	add := func(value interface{}) {
+		if l, ok := value.(map[string]interface{}); ok {
+			value = l["type"]
+		}
+		l, ok := value.(string)
+		if !ok || strings.HasPrefix(strings.ToUpper(l), "SEE LICENSE IN") {
+			return
+		}
+		values = append(values, l)
+	}
+	add(manifest["license"])
+	if licenses, ok := manifest["licenses"].([]interface{}); ok {
+		for _, l := range licenses {
+			add(l)
+		}
+	}
+
+	return license.Or(values...)
+}
@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package helper
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opensbom-generator/parsers/meta"
+)
+
+func TestSetLicenses(t *testing.T) {
+	text, err := os.ReadFile(filepath.Join("..", "..", "LICENSE"))
+	require.NoError(t, err)
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "LICENSE"), text, 0o644))
+
+	ctx, diagnostics := WithDiagnostics(context.Background())
+	pkg := &meta.Package{Name: "a"}
+	detected := SetLicenses(ctx, pkg, "Apache 2", dir)
+	require.NotNil(t, detected)
+	assert.Equal(t, "Apache-2.0", pkg.LicenseDeclared)
+	assert.Equal(t, "Apache-2.0", pkg.LicenseConcluded)
+	assert.Equal(t, detected.Evidence(), pkg.CommentsLicense)
+	assert.Empty(t, diagnostics.List())
+
+	pkg = &meta.Package{Name: "b"}
+	SetLicenses(ctx, pkg, "MIT", dir)
+	assert.Equal(t, "MIT", pkg.LicenseDeclared)
+	assert.Equal(t, "MIT AND Apache-2.0", pkg.LicenseConcluded)
+	assert.True(t, strings.HasPrefix(pkg.CommentsLicense, "the package declares MIT but its license files contain Apache-2.0\n"))
+
+	pkg = &meta.Package{Name: "c"}
+	assert.Nil(t, SetLicenses(ctx, pkg, "", t.TempDir()))
+	assert.Empty(t, pkg.LicenseConcluded)
+
+	pkg = &meta.Package{Name: "d"}
+	assert.Nil(t, SetLicenses(ctx, pkg, "BSD", ""))
+	assert.Equal(t, "BSD-3-Clause", pkg.LicenseConcluded)
+	assert.Empty(t, pkg.CommentsLicense)
+
+	codes := []string{}
+	for _, d := range diagnostics.List() {
+		codes = append(codes, d.Package+" "+d.Code)
+	}
+	assert.Equal(t, []string{"b " + meta.CodeLicenseConflict, "c " + meta.CodeLicenseNotDetected}, codes)
+}
+
+func TestPackageJSONLicense(t *testing.T) {
+	for _, tc := range []struct {
+		manifest map[string]interface{}
+		expected string
+	}{
+		{map[string]interface{}{}, ""},
+		{map[string]interface{}{"license": "(MIT OR Apache-2.0)"}, "MIT OR Apache-2.0"},
+		{map[string]interface{}{"license": map[string]interface{}{"type": "ISC", "url": "https://opensource.org/licenses/ISC"}}, "ISC"},
+		{map[string]interface{}{"licenses": []interface{}{map[string]interface{}{"type": "MIT"}, "Apache 2"}}, "MIT OR Apache-2.0"},
+		{map[string]interface{}{"license": "SEE LICENSE IN LICENSE.txt"}, ""},
+	} {
+		assert.Equal(t, tc.expected, PackageJSONLicense(tc.manifest), "%v", tc.manifest)
+	}
+}
@@ -0,0 +1,41 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package helper
+
+import (
+	"context"
+	"errors"
+)
+
+// NoAssertion is reported for the fields that cannot be resolved
+const NoAssertion = "NOASSERTION"
+
+// ErrOffline is returned instead of reaching the network in offline mode
+var ErrOffline = errors.New("network access is disabled in offline mode")
+
+type offlineKey struct{}
+
+// WithOffline returns a copy of ctx forbidding network access when offline
+// is true
+func WithOffline(ctx context.Context, offline bool) context.Context {
+	if !offline {
+		return ctx
+	}
+
+	return context.WithValue(ctx, offlineKey{}, true)
+}
+
+// IsOffline reports whether ctx forbids network access
+func IsOffline(ctx context.Context) bool {
+	offline, _ := ctx.Value(offlineKey{}).(bool)
+	return offline
+}
+
+// CheckOnline returns ErrOffline when ctx forbids network access
+func CheckOnline(ctx context.Context) error {
+	if IsOffline(ctx) {
+		return ErrOffline
+	}
+
+	return nil
+}
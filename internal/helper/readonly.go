@@ -0,0 +1,41 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package helper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrReadOnly is returned instead of modifying the scanned project or
+// installing packages in read-only mode
+var ErrReadOnly = errors.New("modifying the project is forbidden in read-only mode")
+
+type readOnlyKey struct{}
+
+// WithReadOnly returns a copy of ctx forbidding changes to the scanned
+// project when readOnly is true
+func WithReadOnly(ctx context.Context, readOnly bool) context.Context {
+	if !readOnly {
+		return ctx
+	}
+
+	return context.WithValue(ctx, readOnlyKey{}, true)
+}
+
+// IsReadOnly reports whether ctx forbids changes to the scanned project
+func IsReadOnly(ctx context.Context) bool {
+	readOnly, _ := ctx.Value(readOnlyKey{}).(bool)
+	return readOnly
+}
+
+// CheckWritable returns ErrReadOnly, describing the forbidden action, when
+// ctx forbids changes to the scanned project
+func CheckWritable(ctx context.Context, action string) error {
+	if IsReadOnly(ctx) {
+		return fmt.Errorf("%w: %s", ErrReadOnly, action)
+	}
+
+	return nil
+}
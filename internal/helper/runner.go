@@ -0,0 +1,356 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package helper
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ErrNoFixture is returned when a Replayer has no fixture for a command
+var ErrNoFixture = errors.New("no fixture recorded for the command")
+
+// Command is a process started by a plugin
+type Command struct {
+	Name string
+	Args []string
+	// Dir is the working directory, the current one when empty
+	Dir string
+	// Env is added to the environment of the current process
+	Env []string
+	// Files lists the files the command writes, their content is recorded
+	// along with its output
+	Files []string
+}
+
+func (c Command) String() string {
+	return strings.Join(append([]string{c.Name}, c.Args...), " ")
+}
+
+// Result is the outcome of a command
+type Result struct {
+	Stdout   []byte
+	Stderr   []byte
+	ExitCode int
+}
+
+// Combined returns the output of the command followed by its errors
+func (r Result) Combined() []byte {
+	return append(append([]byte{}, r.Stdout...), r.Stderr...)
+}
+
+// ExitError is returned for commands exiting with a non zero code
+type ExitError struct {
+	Command  string
+	ExitCode int
+	Stderr   []byte
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("%s: exit status %d", e.Command, e.ExitCode)
+}
+
+// Runner starts the commands of the plugins. Run returns an *ExitError when
+// the command exits with a non zero code.
+type Runner interface {
+	Run(ctx context.Context, cmd Command) (Result, error)
+	LookPath(file string) (string, error)
+}
+
+// ExecRunner runs commands on the host
+type ExecRunner struct{}
+
+// Run runs the command and waits for it to exit
+func (ExecRunner) Run(ctx context.Context, cmd Command) (Result, error) {
+	c := exec.CommandContext(ctx, cmd.Name, cmd.Args...) //nolint: gosec
+	c.Dir = cmd.Dir
+	if len(cmd.Env) > 0 {
+		c.Env = append(os.Environ(), cmd.Env...)
+	}
+	var stdout, stderr bytes.Buffer
+	c.Stdout = &stdout
+	c.Stderr = &stderr
+
+	err := c.Run()
+	result := Result{Stdout: stdout.Bytes(), Stderr: stderr.Bytes()}
+	if ctx.Err() != nil {
+		return result, ctx.Err()
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		result.ExitCode = exitErr.ExitCode()
+		return result, &ExitError{Command: cmd.String(), ExitCode: result.ExitCode, Stderr: result.Stderr}
+	}
+
+	return result, err
+}
+
+// LookPath searches for an executable in the directories of PATH
+func (ExecRunner) LookPath(file string) (string, error) {
+	return exec.LookPath(file)
+}
+
+// fixture is a recorded command, or a recorded executable lookup when
+// LookPath is set
+type fixture struct {
+	Name     string   `json:"name"`
+	Args     []string `json:"args,omitempty"`
+	Dir      string   `json:"dir,omitempty"`
+	Env      []string `json:"env,omitempty"`
+	LookPath bool     `json:"lookPath,omitempty"`
+	Stdout   string   `json:"stdout,omitempty"`
+	Stderr   string   `json:"stderr,omitempty"`
+	ExitCode int      `json:"exitCode,omitempty"`
+	// Error is set when the command could not start
+	Error string   `json:"error,omitempty"`
+	Files []string `json:"files,omitempty"`
+	Path  string   `json:"path,omitempty"`
+}
+
+// newFixture returns the fixture of the command, with root and the files
+// written by the command replaced by placeholders so fixtures do not depend
+// on where they were recorded
+func newFixture(cmd Command, root string) fixture {
+	normalize := func(s string) string {
+		for i, file := range cmd.Files {
+			s = strings.ReplaceAll(s, file, fmt.Sprintf("{file%d}", i))
+		}
+		return replaceRoot(s, root)
+	}
+
+	f := fixture{Name: normalize(cmd.Name), Dir: normalize(cmd.Dir)}
+	for _, arg := range cmd.Args {
+		f.Args = append(f.Args, normalize(arg))
+	}
+	for _, env := range cmd.Env {
+		f.Env = append(f.Env, normalize(env))
+	}
+
+	return f
+}
+
+// rootPlaceholder replaces the project root in the fixtures
+const rootPlaceholder = "{root}"
+
+// replaceRoot replaces root by the placeholder in the output of a command
+func replaceRoot(s, root string) string {
+	if root == "" {
+		return s
+	}
+	return strings.ReplaceAll(s, root, rootPlaceholder)
+}
+
+// expandRoot replaces the placeholder by root in a recorded output
+func expandRoot(s, root string) string {
+	if root == "" {
+		return s
+	}
+	return strings.ReplaceAll(s, rootPlaceholder, root)
+}
+
+func (f fixture) key() string {
+	raw, _ := json.Marshal([]interface{}{f.Name, f.Args, f.Dir, f.Env, f.LookPath})
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:8])
+}
+
+// result returns the recorded result, with the placeholder replaced by root
+func (f fixture) result(root string) (Result, error) {
+	result := Result{Stdout: []byte(expandRoot(f.Stdout, root)), Stderr: []byte(expandRoot(f.Stderr, root)), ExitCode: f.ExitCode}
+	switch {
+	case f.Error != "":
+		return result, errors.New(f.Error)
+	case f.ExitCode != 0:
+		return result, &ExitError{Command: strings.Join(append([]string{f.Name}, f.Args...), " "), ExitCode: f.ExitCode, Stderr: result.Stderr}
+	}
+
+	return result, nil
+}
+
+// Recorder runs the commands with Runner and saves each invocation as a
+// JSON fixture in Dir, ready to be served by a Replayer
+type Recorder struct {
+	Runner Runner
+	Dir    string
+	// Root is replaced by a placeholder in the recorded commands and their
+	// output, it is usually the directory of the project being parsed
+	Root string
+}
+
+// Run runs and records the command
+func (r *Recorder) Run(ctx context.Context, cmd Command) (Result, error) {
+	result, err := r.Runner.Run(ctx, cmd)
+
+	f := newFixture(cmd, r.Root)
+	f.Stdout = replaceRoot(string(result.Stdout), r.Root)
+	f.Stderr = replaceRoot(string(result.Stderr), r.Root)
+	f.ExitCode = result.ExitCode
+	var exitErr *ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		f.Error = err.Error()
+	}
+	for _, file := range cmd.Files {
+		content, _ := os.ReadFile(file)
+		f.Files = append(f.Files, replaceRoot(string(content), r.Root))
+	}
+
+	if saveErr := r.save(f); saveErr != nil {
+		return result, saveErr
+	}
+
+	return result, err
+}
+
+// LookPath looks up and records the executable
+func (r *Recorder) LookPath(file string) (string, error) {
+	path, err := r.Runner.LookPath(file)
+
+	f := fixture{Name: file, LookPath: true, Path: path}
+	if err != nil {
+		f.Error = err.Error()
+	}
+	if saveErr := r.save(f); saveErr != nil {
+		return path, saveErr
+	}
+
+	return path, err
+}
+
+func (r *Recorder) save(f fixture) error {
+	raw, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s-%s.json", filepath.Base(f.Name), f.key())
+	return writeFileAtomic(filepath.Join(r.Dir, name), append(raw, '\n'))
+}
+
+// Replayer serves the fixtures of a Recorder instead of running commands.
+// Fixtures are matched on their content, not their file name, so they can
+// also be written by hand.
+type Replayer struct {
+	Dir string
+	// Root replaces the placeholder of the Recorder
+	Root string
+
+	once     sync.Once
+	fixtures map[string]fixture
+	err      error
+}
+
+func (r *Replayer) load() error {
+	r.once.Do(func() {
+		r.fixtures = map[string]fixture{}
+		paths, err := filepath.Glob(filepath.Join(r.Dir, "*.json"))
+		if err != nil {
+			r.err = err
+			return
+		}
+		for _, path := range paths {
+			raw, err := os.ReadFile(path)
+			if err != nil {
+				r.err = err
+				return
+			}
+			f := fixture{}
+			if err := json.Unmarshal(raw, &f); err != nil {
+				r.err = fmt.Errorf("reading fixture %s: %w", path, err)
+				return
+			}
+			r.fixtures[f.key()] = f
+		}
+	})
+
+	return r.err
+}
+
+// Run returns the recorded result of the command and writes back the files
+// it produced
+func (r *Replayer) Run(ctx context.Context, cmd Command) (Result, error) {
+	if err := ctx.Err(); err != nil {
+		return Result{}, err
+	}
+	if err := r.load(); err != nil {
+		return Result{}, err
+	}
+
+	f, ok := r.fixtures[newFixture(cmd, r.Root).key()]
+	if !ok {
+		return Result{}, fmt.Errorf("%w: %s", ErrNoFixture, cmd)
+	}
+	for i, content := range f.Files {
+		if i < len(cmd.Files) {
+			if err := os.WriteFile(cmd.Files[i], []byte(expandRoot(content, r.Root)), 0o600); err != nil {
+				return Result{}, err
+			}
+		}
+	}
+
+	return f.result(r.Root)
+}
+
+// LookPath returns the recorded location of the executable
+func (r *Replayer) LookPath(file string) (string, error) {
+	if err := r.load(); err != nil {
+		return "", err
+	}
+
+	f, ok := r.fixtures[fixture{Name: file, LookPath: true}.key()]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrNoFixture, file)
+	}
+	if f.Error != "" {
+		return "", errors.New(f.Error)
+	}
+
+	return f.Path, nil
+}
+
+type runnerKey struct{}
+
+// WithRunner returns a copy of ctx carrying the runner
+func WithRunner(ctx context.Context, r Runner) context.Context {
+	if r == nil {
+		return ctx
+	}
+
+	return context.WithValue(ctx, runnerKey{}, r)
+}
+
+// RunnerFrom returns the runner carried by ctx or an ExecRunner
+func RunnerFrom(ctx context.Context) Runner {
+	if r, ok := ctx.Value(runnerKey{}).(Runner); ok {
+		return r
+	}
+
+	return ExecRunner{}
+}
+
+// Run runs the command with the runner carried by ctx
+func Run(ctx context.Context, cmd Command) (Result, error) {
+	return RunnerFrom(ctx).Run(ctx, cmd)
+}
+
+// Output runs the command with the runner carried by ctx and returns its
+// standard output
+func Output(ctx context.Context, dir string, name string, args ...string) ([]byte, error) {
+	result, err := Run(ctx, Command{Name: name, Args: args, Dir: dir})
+	return result.Stdout, err
+}
+
+// LookPath searches for an executable with the runner carried by ctx
+func LookPath(ctx context.Context, file string) (string, error) {
+	return RunnerFrom(ctx).LookPath(file)
+}
@@ -0,0 +1,142 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package helper
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRunner answers every command with the same result
+type fakeRunner struct {
+	result Result
+	err    error
+	calls  int
+}
+
+func (f *fakeRunner) Run(ctx context.Context, cmd Command) (Result, error) {
+	f.calls++
+	for _, file := range cmd.Files {
+		if err := os.WriteFile(file, []byte("written by "+cmd.Name), 0o600); err != nil {
+			return Result{}, err
+		}
+	}
+
+	return f.result, f.err
+}
+
+func (f *fakeRunner) LookPath(file string) (string, error) {
+	f.calls++
+	return "/usr/bin/" + file, nil
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	fixtures := t.TempDir()
+	recordRoot, replayRoot := t.TempDir(), t.TempDir()
+	fake := &fakeRunner{result: Result{Stdout: []byte("1.2.3\n")}}
+
+	record := func(root string) Command {
+		return Command{
+			Name:  "tool",
+			Args:  []string{"list", "--output=" + filepath.Join(root, "out.txt")},
+			Dir:   root,
+			Files: []string{filepath.Join(root, "out.txt")},
+		}
+	}
+
+	recorder := &Recorder{Runner: fake, Dir: fixtures, Root: recordRoot}
+	ctx := WithRunner(context.Background(), recorder)
+	out, err := Output(ctx, recordRoot, "tool", "--version")
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.3\n", string(out))
+	_, err = Run(ctx, record(recordRoot))
+	require.NoError(t, err)
+	path, err := LookPath(ctx, "tool")
+	require.NoError(t, err)
+	assert.Equal(t, "/usr/bin/tool", path)
+	assert.Equal(t, 3, fake.calls)
+
+	// the fixtures are replayed in another directory without running anything
+	replayer := &Replayer{Dir: fixtures, Root: replayRoot}
+	ctx = WithRunner(context.Background(), replayer)
+	out, err = Output(ctx, replayRoot, "tool", "--version")
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.3\n", string(out))
+
+	cmd := record(replayRoot)
+	_, err = Run(ctx, cmd)
+	require.NoError(t, err)
+	content, err := os.ReadFile(cmd.Files[0])
+	require.NoError(t, err)
+	assert.Equal(t, "written by tool", string(content))
+
+	path, err = LookPath(ctx, "tool")
+	require.NoError(t, err)
+	assert.Equal(t, "/usr/bin/tool", path)
+	assert.Equal(t, 3, fake.calls)
+
+	_, err = Output(ctx, replayRoot, "tool", "--help")
+	assert.True(t, errors.Is(err, ErrNoFixture))
+	_, err = LookPath(ctx, "other")
+	assert.True(t, errors.Is(err, ErrNoFixture))
+}
+
+func TestRecordRootInOutput(t *testing.T) {
+	fixtures := t.TempDir()
+	recordRoot, replayRoot := t.TempDir(), t.TempDir()
+	fake := &fakeRunner{result: Result{Stdout: []byte(`{"manifest": "` + filepath.Join(recordRoot, "Cargo.toml") + `"}`)}}
+
+	recorder := &Recorder{Runner: fake, Dir: fixtures, Root: recordRoot}
+	_, err := Output(WithRunner(context.Background(), recorder), recordRoot, "tool", "metadata")
+	require.NoError(t, err)
+
+	// the fixture holds no path of the machine it was recorded on
+	paths, err := filepath.Glob(filepath.Join(fixtures, "*.json"))
+	require.NoError(t, err)
+	require.Len(t, paths, 1)
+	raw, err := os.ReadFile(paths[0])
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), recordRoot)
+
+	out, err := Output(WithRunner(context.Background(), &Replayer{Dir: fixtures, Root: replayRoot}), replayRoot, "tool", "metadata")
+	require.NoError(t, err)
+	assert.Equal(t, `{"manifest": "`+filepath.Join(replayRoot, "Cargo.toml")+`"}`, string(out))
+}
+
+func TestReplayExitCode(t *testing.T) {
+	fixtures := t.TempDir()
+	fake := &fakeRunner{
+		result: Result{Stderr: []byte("boom\n"), ExitCode: 2},
+		err:    &ExitError{Command: "tool fail", ExitCode: 2},
+	}
+
+	recorder := &Recorder{Runner: fake, Dir: fixtures}
+	_, err := Run(WithRunner(context.Background(), recorder), Command{Name: "tool", Args: []string{"fail"}})
+	require.Error(t, err)
+
+	result, err := Run(WithRunner(context.Background(), &Replayer{Dir: fixtures}), Command{Name: "tool", Args: []string{"fail"}})
+	exitErr := &ExitError{}
+	require.True(t, errors.As(err, &exitErr))
+	assert.Equal(t, 2, exitErr.ExitCode)
+	assert.Equal(t, "boom\n", string(exitErr.Stderr))
+	assert.Equal(t, 2, result.ExitCode)
+}
+
+func TestExecRunner(t *testing.T) {
+	if _, err := (ExecRunner{}).LookPath("sh"); err != nil {
+		t.Skip("sh is not installed")
+	}
+
+	result, err := Run(context.Background(), Command{Name: "sh", Args: []string{"-c", "echo out; echo err >&2; exit 3"}})
+	exitErr := &ExitError{}
+	require.True(t, errors.As(err, &exitErr))
+	assert.Equal(t, 3, exitErr.ExitCode)
+	assert.Equal(t, "out\n", string(result.Stdout))
+	assert.Equal(t, "out\nerr\n", string(result.Combined()))
+}
@@ -0,0 +1,242 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package license
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/go-enry/go-license-detector/v4/licensedb"
+	"github.com/go-enry/go-license-detector/v4/licensedb/filer"
+)
+
+// DefaultThreshold is the confidence above which the text of a license file
+// is taken as a license
+const DefaultThreshold = 0.85
+
+// ErrNotDetected is returned when no license is found above the threshold
+var ErrNotDetected = errors.New("no license detected")
+
+// Match is a license found in a file
+type Match struct {
+	License    string
+	Confidence float32
+	// File is relative to the directory the detection ran in
+	File string
+}
+
+// Detection holds the licenses found in the license files of a directory
+type Detection struct {
+	Dir string
+	// Matches are every license found above the threshold, the most
+	// confident first. A file usually matches several similar licenses.
+	Matches []Match
+}
+
+var (
+	// identifierPattern matches the SPDX-License-Identifier tags of source
+	// files, up to the end of the line or of the comment
+	identifierPattern = regexp.MustCompile(`(?m)SPDX-License-Identifier:[ \t]*(.*?)[ \t]*(?:\*/|-->|#\}|$)`)
+	// licenseFilePattern matches the names of the files holding a license
+	// text, e.g. LICENSE, LICENSE-MIT.txt or COPYING
+	licenseFilePattern = regexp.MustCompile(`(?i)^(un)?licen[cs]e|^copying|^copyright`)
+)
+
+// IsLicenseFile reports whether the name is the one of a license text file
+func IsLicenseFile(name string) bool {
+	return licenseFilePattern.MatchString(path.Base(name))
+}
+
+// FileLicenses returns the identifiers of the licenses found in a file: the
+// ones of its SPDX-License-Identifier tags and, for license files, the
+// license its text matches.
+func FileLicenses(name string, content []byte) []string {
+	found := map[string]bool{}
+	for _, m := range identifierPattern.FindAllSubmatch(content, -1) {
+		for _, id := range expressionLicenses(string(m[1])) {
+			found[id] = true
+		}
+	}
+
+	if IsLicenseFile(name) {
+		best, confidence := "", float32(DefaultThreshold)
+		for id, c := range licensedb.InvestigateLicenseText(content) {
+			if c > confidence || (c == confidence && id < best) {
+				best, confidence = id, c
+			}
+		}
+		if best != "" {
+			found[best] = true
+		}
+	}
+
+	ids := make([]string, 0, len(found))
+	for id := range found {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
+
+// expressionLicenses returns the licenses of an expression, without the
+// operators and the exceptions
+func expressionLicenses(expression string) []string {
+	ids := []string{}
+	tokens := strings.Fields(strings.NewReplacer("(", " ", ")", " ").Replace(expression))
+	for i := 0; i < len(tokens); i++ {
+		switch strings.ToUpper(tokens[i]) {
+		case "AND", "OR":
+			continue
+		case "WITH":
+			i++
+			continue
+		}
+		ids = append(ids, tokens[i])
+	}
+
+	return ids
+}
+
+// Detect returns the licenses found in the license files of dir, and in its
+// README when it has none, whose confidence is at least threshold. A zero
+// threshold uses DefaultThreshold.
+func Detect(dir string, threshold float32) (*Detection, error) {
+	if threshold <= 0 {
+		threshold = DefaultThreshold
+	}
+
+	fs, err := filer.FromDirectory(dir)
+	if err != nil {
+		return nil, err
+	}
+	found, err := licensedb.Detect(fs)
+	if err != nil {
+		return nil, fmt.Errorf("%w in %s: %v", ErrNotDetected, dir, err)
+	}
+
+	d := &Detection{Dir: dir}
+	for id, m := range found {
+		for file, confidence := range m.Files {
+			if confidence >= threshold {
+				d.Matches = append(d.Matches, Match{License: id, Confidence: confidence, File: file})
+			}
+		}
+	}
+	if len(d.Matches) == 0 {
+		return nil, fmt.Errorf("%w in %s above a confidence of %.2f", ErrNotDetected, dir, threshold)
+	}
+	sort.Slice(d.Matches, func(i, j int) bool {
+		a, b := d.Matches[i], d.Matches[j]
+		if a.Confidence != b.Confidence {
+			return a.Confidence > b.Confidence
+		}
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		return a.License < b.License
+	})
+
+	return d, nil
+}
+
+// Best returns the most confident match of every file, the most confident
+// first
+func (d *Detection) Best() []Match {
+	best := []Match{}
+	seen := map[string]bool{}
+	for _, m := range d.Matches {
+		if !seen[m.File] {
+			seen[m.File] = true
+			best = append(best, m)
+		}
+	}
+
+	return best
+}
+
+// Expression returns the canonical expression of the licenses found: the
+// AND of the best license of every file, as nothing tells whether the
+// package may be used under one of them only
+func (d *Detection) Expression() string {
+	operands := []*Expression{}
+	seen := map[string]bool{}
+	for _, m := range d.Best() {
+		if seen[m.License] {
+			continue
+		}
+		seen[m.License] = true
+		e, err := parse(m.License, true)
+		if err != nil {
+			e = &Expression{ID: licenseRef(m.License)}
+		}
+		operands = append(operands, e)
+	}
+	if len(operands) == 0 {
+		return ""
+	}
+
+	return join(OperatorAND, operands).String()
+}
+
+// Evidence describes every match, one per line, to be kept as a comment
+// on the licenses of the package
+func (d *Detection) Evidence() string {
+	lines := make([]string, 0, len(d.Matches))
+	for _, m := range d.Matches {
+		lines = append(lines, fmt.Sprintf("%s: %s (confidence %.2f)", m.File, m.License, m.Confidence))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// License returns the license of the most confident match, with the text of
+// its file, and the Expression of the detection as ID
+func (d *Detection) License() *License {
+	best := d.Matches[0]
+	expression := d.Expression()
+	return &License{
+		ID:            expression,
+		Name:          expression,
+		ExtractedText: d.text(best.File),
+		Comments:      d.Evidence(),
+		File:          best.File,
+	}
+}
+
+// OtherLicenses returns the licenses found that are not on the SPDX list,
+// with the LicenseRef of the Expression as ID and the text of their file
+func (d *Detection) OtherLicenses() []License {
+	others := []License{}
+	seen := map[string]bool{}
+	for _, m := range d.Best() {
+		if _, ok := Lookup(m.License); ok || seen[m.License] {
+			continue
+		}
+		seen[m.License] = true
+		others = append(others, License{
+			ID:            licenseRef(m.License),
+			Name:          m.License,
+			ExtractedText: d.text(m.File),
+			File:          m.File,
+		})
+	}
+
+	return others
+}
+
+// text returns the content of a file of the directory, empty when it cannot
+// be read
+func (d *Detection) text(file string) string {
+	content, err := os.ReadFile(filepath.Join(d.Dir, filepath.FromSlash(file)))
+	if err != nil {
+		return ""
+	}
+	return string(content)
+}
@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package license
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetect(t *testing.T) {
+	dir := t.TempDir()
+	copyFile(t, filepath.Join("..", "..", "LICENSE"), filepath.Join(dir, "LICENSE-APACHE"))
+	copyFile(t, filepath.Join("..", "..", "swift", "test", "LICENSE.txt"), filepath.Join(dir, "LICENSE-MIT"))
+
+	d, err := Detect(dir, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "Apache-2.0 AND MIT", d.Expression())
+	assert.Equal(t, []string{"LICENSE-APACHE", "LICENSE-MIT"}, []string{d.Best()[0].File, d.Best()[1].File})
+	assert.Contains(t, d.Evidence(), "LICENSE-MIT: MIT (confidence 0.98)")
+	assert.Empty(t, d.OtherLicenses())
+
+	l := d.License()
+	assert.Equal(t, "Apache-2.0 AND MIT", l.ID)
+	assert.Equal(t, "LICENSE-APACHE", l.File)
+	assert.Contains(t, l.ExtractedText, "Apache License")
+	assert.Equal(t, d.Evidence(), l.Comments)
+
+	// the similar licenses matched with a lower confidence are left out
+	d, err = Detect(dir, 0.95)
+	require.NoError(t, err)
+	assert.Len(t, d.Matches, 2)
+
+	_, err = Detect(dir, 0.999)
+	assert.ErrorIs(t, err, ErrNotDetected)
+	_, err = Detect(t.TempDir(), 0)
+	assert.ErrorIs(t, err, ErrNotDetected)
+}
+
+func copyFile(t *testing.T, src, dst string) {
+	content, err := os.ReadFile(src)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(dst, content, 0o600))
+}
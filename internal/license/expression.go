@@ -0,0 +1,529 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package license
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Operators of the license expressions
+const (
+	OperatorAND = "AND"
+	OperatorOR  = "OR"
+)
+
+const (
+	licenseRefPrefix  = "LicenseRef-"
+	documentRefPrefix = "DocumentRef-"
+)
+
+// Expression is a parsed SPDX license expression: either a license, with
+// its ID, or the AND or OR of its operands
+type Expression struct {
+	Operator string
+	Operands []*Expression
+	ID       string
+	// OrLater is the + suffix, for licenses other than the GNU ones which
+	// have -or-later identifiers
+	OrLater   bool
+	Exception string
+}
+
+// versionPattern matches the version words of license names, e.g. v2 or 2.0
+var versionPattern = regexp.MustCompile(`^v?(\d+(?:\.\d+)*?)(?:\.0)*$`)
+
+// aliases map the names licenses are commonly declared with to their
+// identifiers. The keys are normalized by aliasKey.
+var aliases = map[string]string{
+	"apache":            "Apache-2.0",
+	"apache 2":          "Apache-2.0",
+	"apache software":   "Apache-2.0",
+	"apache software 2": "Apache-2.0",
+	"asl 2":             "Apache-2.0",
+	"apache 1":          "Apache-1.0",
+	"apache 1.1":        "Apache-1.1",
+	"expat":             "MIT",
+	"mit expat":         "MIT",
+	"mit style":         "MIT",
+	// BSD alone most often means the 3-clause license
+	"bsd":                           "BSD-3-Clause",
+	"bsd style":                     "BSD-3-Clause",
+	"bsd 3":                         "BSD-3-Clause",
+	"bsd 3 clause":                  "BSD-3-Clause",
+	"3 clause bsd":                  "BSD-3-Clause",
+	"new bsd":                       "BSD-3-Clause",
+	"revised bsd":                   "BSD-3-Clause",
+	"modified bsd":                  "BSD-3-Clause",
+	"bsd 2":                         "BSD-2-Clause",
+	"bsd 2 clause":                  "BSD-2-Clause",
+	"2 clause bsd":                  "BSD-2-Clause",
+	"simplified bsd":                "BSD-2-Clause",
+	"freebsd":                       "BSD-2-Clause",
+	"gpl 2":                         "GPL-2.0-only",
+	"gplv2":                         "GPL-2.0-only",
+	"gnu gpl 2":                     "GPL-2.0-only",
+	"gnu gplv2":                     "GPL-2.0-only",
+	"gnu general public 2":          "GPL-2.0-only",
+	"gpl 3":                         "GPL-3.0-only",
+	"gplv3":                         "GPL-3.0-only",
+	"gnu gpl 3":                     "GPL-3.0-only",
+	"gnu gplv3":                     "GPL-3.0-only",
+	"gnu general public 3":          "GPL-3.0-only",
+	"lgpl 2":                        "LGPL-2.0-only",
+	"lgplv2":                        "LGPL-2.0-only",
+	"gnu library general public 2":  "LGPL-2.0-only",
+	"lgpl 2.1":                      "LGPL-2.1-only",
+	"lgplv2.1":                      "LGPL-2.1-only",
+	"gnu lgpl 2.1":                  "LGPL-2.1-only",
+	"gnu lesser general public 2.1": "LGPL-2.1-only",
+	"lgpl 3":                        "LGPL-3.0-only",
+	"lgplv3":                        "LGPL-3.0-only",
+	"gnu lgpl 3":                    "LGPL-3.0-only",
+	"gnu lesser general public 3":   "LGPL-3.0-only",
+	"agpl 3":                        "AGPL-3.0-only",
+	"agplv3":                        "AGPL-3.0-only",
+	"gnu agpl 3":                    "AGPL-3.0-only",
+	"gnu affero general public 3":   "AGPL-3.0-only",
+	"mpl 1.1":                       "MPL-1.1",
+	"mozilla public 1.1":            "MPL-1.1",
+	"mpl 2":                         "MPL-2.0",
+	"mozilla public 2":              "MPL-2.0",
+	"epl 1":                         "EPL-1.0",
+	"eclipse public 1":              "EPL-1.0",
+	"epl 2":                         "EPL-2.0",
+	"eclipse public 2":              "EPL-2.0",
+	"cc0":                           "CC0-1.0",
+	"cc0 1":                         "CC0-1.0",
+	"boost":                         "BSL-1.0",
+	"boost software":                "BSL-1.0",
+	"bsl 1":                         "BSL-1.0",
+	"psf":                           "PSF-2.0",
+	"psf 2":                         "PSF-2.0",
+	"python software foundation":    "PSF-2.0",
+	"artistic 2":                    "Artistic-2.0",
+}
+
+// aliasFillers are the words left out of the alias keys
+var aliasFillers = map[string]bool{
+	"the": true, "license": true, "licence": true, "licensed": true, "version": true,
+}
+
+// orLaterSuffixes are the spellings of + in license names
+var orLaterSuffixes = []string{" or later", " or newer", " or any later version"}
+
+// Parse parses a valid SPDX license expression. Identifiers are matched
+// regardless of case and deprecated ones are replaced, e.g. "gpl-2.0+ or mit"
+// is parsed as "GPL-2.0-or-later OR MIT".
+func Parse(s string) (*Expression, error) {
+	return parse(s, false)
+}
+
+// Normalize returns the canonical expression of a declared license, which
+// may be a free form name such as "Apache 2" or "MIT/Apache-2.0". Licenses
+// that are not on the SPDX list are turned into LicenseRefs.
+func Normalize(s string) string {
+	s = strings.TrimSpace(s)
+	switch s {
+	case "":
+		return ""
+	case "NOASSERTION", "NONE":
+		return s
+	}
+
+	e, err := parse(s, true)
+	if err != nil {
+		return licenseRef(s)
+	}
+	return e.String()
+}
+
+// Or returns the canonical expression of the disjunction of the declared
+// licenses, as in package manifests listing several licenses to choose from
+func Or(values ...string) string {
+	operands := []*Expression{}
+	for _, value := range values {
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+		e, err := parse(value, true)
+		if err != nil {
+			e = &Expression{ID: licenseRef(value)}
+		}
+		operands = append(operands, e)
+	}
+	if len(operands) == 0 {
+		return ""
+	}
+
+	return join(OperatorOR, operands).String()
+}
+
+// Resolve returns the identifier on the SPDX list matching id regardless of
+// case. Deprecated identifiers resolve to the expression replacing them.
+func Resolve(id string) (string, bool) {
+	l, ok := Lookup(id)
+	if !ok {
+		return "", false
+	}
+	if replacement, ok := replacements[l.ID]; ok && l.Deprecated {
+		return replacement, true
+	}
+
+	return l.ID, true
+}
+
+// String returns the canonical form of the expression: the operators in
+// upper case and parentheses only where AND contains OR
+func (e *Expression) String() string {
+	if e.Operator == "" {
+		s := e.ID
+		if e.OrLater {
+			s += "+"
+		}
+		if e.Exception != "" {
+			s += " WITH " + e.Exception
+		}
+		return s
+	}
+
+	parts := make([]string, 0, len(e.Operands))
+	for _, operand := range e.Operands {
+		s := operand.String()
+		if e.Operator == OperatorAND && operand.Operator == OperatorOR {
+			s = "(" + s + ")"
+		}
+		parts = append(parts, s)
+	}
+
+	return strings.Join(parts, " "+e.Operator+" ")
+}
+
+// Licenses returns the identifiers of the licenses of the expression, in
+// order and without duplicates
+func (e *Expression) Licenses() []string {
+	ids := []string{}
+	for _, l := range e.leaves() {
+		ids = append(ids, l.ID)
+	}
+
+	return ids
+}
+
+// leaves returns the licenses of the expression, with their + and
+// exception, in order and without duplicate identifiers
+func (e *Expression) leaves() []*Expression {
+	leaves := []*Expression{}
+	seen := map[string]bool{}
+	var walk func(e *Expression)
+	walk = func(e *Expression) {
+		if e.Operator == "" {
+			if !seen[e.ID] {
+				seen[e.ID] = true
+				leaves = append(leaves, e)
+			}
+			return
+		}
+		for _, operand := range e.Operands {
+			walk(operand)
+		}
+	}
+	walk(e)
+
+	return leaves
+}
+
+// join returns the operands combined with the operator, flattening the
+// operands using the same operator
+func join(operator string, operands []*Expression) *Expression {
+	if len(operands) == 1 {
+		return operands[0]
+	}
+
+	e := &Expression{Operator: operator}
+	for _, operand := range operands {
+		if operand.Operator == operator {
+			e.Operands = append(e.Operands, operand.Operands...)
+			continue
+		}
+		e.Operands = append(e.Operands, operand)
+	}
+	return e
+}
+
+// parser is a recursive descent parser of license expressions. A lenient
+// parser accepts license names of several words, / as OR and licenses that
+// are not on the SPDX list.
+type parser struct {
+	tokens  []string
+	pos     int
+	lenient bool
+}
+
+func parse(s string, lenient bool) (*Expression, error) {
+	replacer := strings.NewReplacer("(", " ( ", ")", " ) ")
+	if lenient {
+		replacer = strings.NewReplacer("(", " ( ", ")", " ) ", "/", " OR ")
+	}
+	p := &parser{tokens: strings.Fields(replacer.Replace(s)), lenient: lenient}
+	if len(p.tokens) == 0 {
+		return nil, fmt.Errorf("empty license expression")
+	}
+
+	e, err := p.or()
+	if err != nil {
+		return nil, fmt.Errorf("invalid license expression %q: %w", s, err)
+	}
+	if t := p.peek(); t != "" {
+		return nil, fmt.Errorf("invalid license expression %q: unexpected %q", s, t)
+	}
+
+	return e, nil
+}
+
+func (p *parser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *parser) or() (*Expression, error) {
+	return p.operation(OperatorOR, p.and)
+}
+
+func (p *parser) and() (*Expression, error) {
+	return p.operation(OperatorAND, p.with)
+}
+
+// operation parses the operands of operator, parsed by next as they bind
+// tighter
+func (p *parser) operation(operator string, next func() (*Expression, error)) (*Expression, error) {
+	operands := []*Expression{}
+	for {
+		e, err := next()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, e)
+		if !strings.EqualFold(p.peek(), operator) {
+			return join(operator, operands), nil
+		}
+		p.pos++
+	}
+}
+
+func (p *parser) with() (*Expression, error) {
+	e, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(p.peek(), "WITH") {
+		return e, nil
+	}
+	p.pos++
+
+	if e.Operator != "" || e.Exception != "" {
+		return nil, fmt.Errorf("WITH must follow a single license")
+	}
+	words := p.words()
+	if len(words) == 0 {
+		return nil, fmt.Errorf("missing exception after WITH")
+	}
+	name := strings.Join(words, " ")
+	exception, ok := LookupException(sanitize(name))
+	if !ok {
+		if !p.lenient {
+			return nil, fmt.Errorf("unknown exception %q", name)
+		}
+		exception.ID = sanitize(name)
+	}
+	e.Exception = exception.ID
+
+	return e, nil
+}
+
+func (p *parser) primary() (*Expression, error) {
+	switch t := p.peek(); {
+	case t == "(":
+		p.pos++
+		e, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, fmt.Errorf("missing )")
+		}
+		p.pos++
+		return e, nil
+	case t == "":
+		return nil, fmt.Errorf("missing license")
+	case t == ")" || isOperator(t):
+		return nil, fmt.Errorf("unexpected %q", t)
+	}
+
+	return p.license(strings.Join(p.words(), " "))
+}
+
+// words returns the words of a license or exception name: a single one for
+// a strict parser, the ones up to the next operator otherwise
+func (p *parser) words() []string {
+	words := []string{}
+	for t := p.peek(); t != "" && t != "(" && t != ")"; t = p.peek() {
+		if isOperator(t) {
+			// the or of "GPL v2 or later" is part of the name
+			next := ""
+			if p.pos+1 < len(p.tokens) {
+				next = strings.ToLower(p.tokens[p.pos+1])
+			}
+			if !p.lenient || len(words) == 0 || !strings.EqualFold(t, OperatorOR) ||
+				(next != "later" && next != "newer" && next != "any") {
+				break
+			}
+		}
+		words = append(words, t)
+		p.pos++
+		if !p.lenient {
+			break
+		}
+	}
+
+	return words
+}
+
+func (p *parser) license(name string) (*Expression, error) {
+	orLater := false
+	if p.lenient {
+		lower := strings.ToLower(name)
+		for _, suffix := range orLaterSuffixes {
+			if strings.HasSuffix(lower, suffix) {
+				name, orLater = name[:len(name)-len(suffix)], true
+				break
+			}
+		}
+	}
+	if strings.HasSuffix(name, "+") {
+		name, orLater = strings.TrimSuffix(name, "+"), true
+	}
+
+	if hasRefPrefix(name) {
+		if p.lenient {
+			return &Expression{ID: licenseRef(name)}, nil
+		}
+		return &Expression{ID: name}, nil
+	}
+
+	// deprecated identifiers such as GPL-2.0+ include the suffix
+	if orLater {
+		if l, ok := Lookup(name + "+"); ok {
+			return listed(l, false)
+		}
+	}
+	if l, ok := Lookup(name); ok {
+		return listed(l, orLater)
+	}
+	if !p.lenient {
+		return nil, fmt.Errorf("unknown license %q", name)
+	}
+	// "MIT License" or "BSD 3-Clause" are identifiers once normalized
+	key := aliasKey(name)
+	if l, ok := Lookup(strings.ReplaceAll(key, " ", "-")); ok {
+		return listed(l, orLater)
+	}
+	if id, ok := aliases[key]; ok {
+		return withOrLater(&Expression{ID: id}, orLater), nil
+	}
+
+	if orLater {
+		name += "+"
+	}
+	return &Expression{ID: licenseRef(name)}, nil
+}
+
+// listed returns the expression of a license of the list, deprecated ones
+// are replaced when the list has a replacement
+func listed(l Info, orLater bool) (*Expression, error) {
+	replacement, ok := replacements[l.ID]
+	if !ok || !l.Deprecated {
+		return withOrLater(&Expression{ID: l.ID}, orLater), nil
+	}
+
+	e, err := parse(replacement, false)
+	if err != nil {
+		return nil, err
+	}
+	return withOrLater(e, orLater), nil
+}
+
+// withOrLater applies the + suffix to a license, the GNU licenses have an
+// identifier of their own
+func withOrLater(e *Expression, orLater bool) *Expression {
+	if !orLater || e.Operator != "" || strings.HasSuffix(e.ID, "-or-later") {
+		return e
+	}
+	if strings.HasSuffix(e.ID, "-only") {
+		base := strings.TrimSuffix(e.ID, "-only")
+		if _, ok := licenses[base+"-or-later"]; ok {
+			e.ID = base + "-or-later"
+			return e
+		}
+	}
+	e.OrLater = true
+	return e
+}
+
+func isOperator(t string) bool {
+	return strings.EqualFold(t, OperatorAND) || strings.EqualFold(t, OperatorOR) || strings.EqualFold(t, "WITH")
+}
+
+func hasRefPrefix(s string) bool {
+	lower := strings.ToLower(s)
+	return strings.HasPrefix(lower, strings.ToLower(licenseRefPrefix)) ||
+		strings.HasPrefix(lower, strings.ToLower(documentRefPrefix))
+}
+
+// aliasKey normalizes a license name: lower case words without the filler
+// words and with the versions stripped of v and trailing .0, e.g.
+// "The Apache License, Version 2.0" becomes "apache 2"
+func aliasKey(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return r == ' ' || r == '\t' || r == ',' || r == '_' || r == '-'
+	})
+	key := make([]string, 0, len(words))
+	for _, w := range words {
+		if aliasFillers[w] {
+			continue
+		}
+		if m := versionPattern.FindStringSubmatch(w); m != nil {
+			w = m[1]
+		}
+		key = append(key, w)
+	}
+
+	return strings.Join(key, " ")
+}
+
+// licenseRef returns the LicenseRef of a license that is not on the SPDX
+// list, with the characters not allowed in identifiers replaced
+func licenseRef(name string) string {
+	name = strings.TrimSpace(name)
+	if strings.HasPrefix(strings.ToLower(name), strings.ToLower(documentRefPrefix)) {
+		return name
+	}
+	if strings.HasPrefix(strings.ToLower(name), strings.ToLower(licenseRefPrefix)) {
+		name = name[len(licenseRefPrefix):]
+	}
+	return licenseRefPrefix + sanitize(name)
+}
+
+// sanitize replaces the characters that are not allowed in identifiers
+func sanitize(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z', '0' <= r && r <= '9', r == '.', r == '-':
+			return r
+		}
+		return '-'
+	}, s)
+}
@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build ignore
+
+// gen writes list_data.go from the JSON files of the SPDX license list data
+// found in the data directory. To update the list, replace the files with
+// the ones of a release of https://github.com/spdx/license-list-data and run
+// go generate.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"sort"
+)
+
+type licenseList struct {
+	Version  string `json:"licenseListVersion"`
+	Licenses []struct {
+		ID          string `json:"licenseId"`
+		Name        string `json:"name"`
+		Deprecated  bool   `json:"isDeprecatedLicenseId"`
+		OSIApproved bool   `json:"isOsiApproved"`
+		FSFLibre    bool   `json:"isFsfLibre"`
+	} `json:"licenses"`
+}
+
+type exceptionList struct {
+	Version    string `json:"licenseListVersion"`
+	Exceptions []struct {
+		ID         string `json:"licenseExceptionId"`
+		Name       string `json:"name"`
+		Deprecated bool   `json:"isDeprecatedLicenseId"`
+	} `json:"exceptions"`
+}
+
+func main() {
+	licenses := licenseList{}
+	if err := readJSON("data/licenses.json", &licenses); err != nil {
+		log.Fatal(err)
+	}
+	exceptions := exceptionList{}
+	if err := readJSON("data/exceptions.json", &exceptions); err != nil {
+		log.Fatal(err)
+	}
+	if licenses.Version != exceptions.Version {
+		log.Fatalf("license list %s and exception list %s differ", licenses.Version, exceptions.Version)
+	}
+	sort.Slice(licenses.Licenses, func(i, j int) bool { return licenses.Licenses[i].ID < licenses.Licenses[j].ID })
+	sort.Slice(exceptions.Exceptions, func(i, j int) bool { return exceptions.Exceptions[i].ID < exceptions.Exceptions[j].ID })
+
+	b := &bytes.Buffer{}
+	fmt.Fprintf(b, "// SPDX-License-Identifier: Apache-2.0\n\n")
+	fmt.Fprintf(b, "// Code generated by gen.go from the SPDX license list data; DO NOT EDIT.\n\n")
+	fmt.Fprintf(b, "package license\n\n")
+	fmt.Fprintf(b, "const listVersion = %q\n\n", licenses.Version)
+	fmt.Fprintf(b, "var licenses = map[string]Info{\n")
+	for _, l := range licenses.Licenses {
+		fmt.Fprintf(b, "%q: {ID: %q, Name: %q, Deprecated: %t, OSIApproved: %t, FSFLibre: %t},\n",
+			l.ID, l.ID, l.Name, l.Deprecated, l.OSIApproved, l.FSFLibre)
+	}
+	fmt.Fprintf(b, "}\n\n")
+	fmt.Fprintf(b, "var exceptions = map[string]Exception{\n")
+	for _, e := range exceptions.Exceptions {
+		fmt.Fprintf(b, "%q: {ID: %q, Name: %q, Deprecated: %t},\n", e.ID, e.ID, e.Name, e.Deprecated)
+	}
+	fmt.Fprintf(b, "}\n")
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("list_data.go", src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func readJSON(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	return nil
+}
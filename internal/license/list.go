@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package license
+
+import (
+	"sort"
+	"strings"
+)
+
+//go:generate go run gen.go
+
+// Info is a license of the SPDX license list
+type Info struct {
+	ID          string
+	Name        string
+	Deprecated  bool
+	OSIApproved bool
+	FSFLibre    bool
+}
+
+// Exception is a license exception of the SPDX license list, the identifiers
+// allowed after WITH
+type Exception struct {
+	ID         string
+	Name       string
+	Deprecated bool
+}
+
+var (
+	// lowerLicenses and lowerExceptions index the identifiers in lower case,
+	// as they are matched regardless of case
+	lowerLicenses   = lowerIndex(licenses)
+	lowerExceptions = lowerIndex(exceptions)
+)
+
+func lowerIndex[T any](m map[string]T) map[string]string {
+	index := make(map[string]string, len(m))
+	for id := range m {
+		index[strings.ToLower(id)] = id
+	}
+	return index
+}
+
+// ListVersion returns the version of the SPDX license list the data was
+// generated from
+func ListVersion() string {
+	return listVersion
+}
+
+// Lookup returns the license of the list with the identifier, matched
+// regardless of case. Deprecated identifiers are found too.
+func Lookup(id string) (Info, bool) {
+	l, ok := licenses[lowerLicenses[strings.ToLower(strings.TrimSpace(id))]]
+	return l, ok
+}
+
+// IsDeprecated reports whether the identifier is a deprecated one of the list
+func IsDeprecated(id string) bool {
+	l, ok := Lookup(id)
+	return ok && l.Deprecated
+}
+
+// IsOSIApproved reports whether the license is approved by the Open Source
+// Initiative
+func IsOSIApproved(id string) bool {
+	l, ok := Lookup(id)
+	return ok && l.OSIApproved
+}
+
+// LookupException returns the exception of the list with the identifier,
+// matched regardless of case
+func LookupException(id string) (Exception, bool) {
+	e, ok := exceptions[lowerExceptions[strings.ToLower(strings.TrimSpace(id))]]
+	return e, ok
+}
+
+// Exceptions returns the exceptions of the list sorted by identifier
+func Exceptions() []Exception {
+	list := make([]Exception, 0, len(exceptions))
+	for _, e := range exceptions {
+		list = append(list, e)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+
+	return list
+}
@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package license
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookup(t *testing.T) {
+	l, ok := Lookup("apache-2.0")
+	assert.True(t, ok)
+	assert.Equal(t, Info{ID: "Apache-2.0", Name: "Apache License 2.0", OSIApproved: true, FSFLibre: true}, l)
+
+	_, ok = Lookup("Apache 2")
+	assert.False(t, ok)
+
+	assert.True(t, IsDeprecated("GPL-2.0"))
+	assert.True(t, IsDeprecated("gpl-2.0+"))
+	assert.False(t, IsDeprecated("GPL-2.0-only"))
+	assert.False(t, IsDeprecated("unknown"))
+
+	assert.True(t, IsOSIApproved("MIT"))
+	assert.False(t, IsOSIApproved("CC-BY-4.0"))
+	assert.NotEmpty(t, ListVersion())
+}
+
+func TestExceptions(t *testing.T) {
+	e, ok := LookupException("llvm-exception")
+	assert.True(t, ok)
+	assert.Equal(t, "LLVM-exception", e.ID)
+	_, ok = LookupException("MIT")
+	assert.False(t, ok)
+
+	list := Exceptions()
+	assert.Len(t, list, len(exceptions))
+	for i := 1; i < len(list); i++ {
+		assert.Less(t, list[i-1].ID, list[i].ID)
+	}
+}
+
+func TestReplacements(t *testing.T) {
+	for id, replacement := range replacements {
+		assert.True(t, IsDeprecated(id), id)
+		_, err := Parse(replacement)
+		assert.NoError(t, err, id)
+	}
+	for id := range licenses {
+		if IsDeprecated(id) {
+			_, ok := replacements[id]
+			assert.True(t, ok, "no replacement for %s", id)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package license
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Reconcile returns the license concluded from the expression a package
+// declares in its metadata and the one detected in its license files, and
+// describes their conflict when they disagree.
+//
+// The declared expression is concluded when the license files contain no
+// other license, which keeps the choice of its OR operators. Licenses found
+// only in the files are added with AND, as they apply too.
+func Reconcile(declared, detected string) (concluded string, conflict string) {
+	switch {
+	case detected == "":
+		return declared, ""
+	case declared == "":
+		return detected, ""
+	}
+
+	d, err := Parse(declared)
+	if err != nil {
+		return detected, fmt.Sprintf("the declared license %q is not a valid expression", declared)
+	}
+	t, err := Parse(detected)
+	if err != nil {
+		return declared, ""
+	}
+
+	inDeclared := map[string]bool{}
+	for _, id := range d.Licenses() {
+		inDeclared[family(id)] = true
+	}
+	found := t.leaves()
+	undeclared := []*Expression{}
+	names := []string{}
+	for _, l := range found {
+		if !inDeclared[family(l.ID)] {
+			undeclared = append(undeclared, l)
+			names = append(names, l.String())
+		}
+	}
+	if len(undeclared) == 0 {
+		return declared, ""
+	}
+
+	concluded = join(OperatorAND, append([]*Expression{d}, undeclared...)).String()
+	if len(undeclared) == len(found) {
+		return concluded, fmt.Sprintf("the package declares %s but its license files contain %s", declared, detected)
+	}
+	return concluded, fmt.Sprintf("the license files also contain %s, which the package does not declare", strings.Join(names, ", "))
+}
+
+// family returns the identifier of a GNU license without its -only or
+// -or-later suffix: both have the same text, only the notices of the
+// source files tell them apart, so the license files cannot contradict
+// the declared one
+func family(id string) string {
+	if strings.HasSuffix(id, "-only") {
+		return strings.TrimSuffix(id, "-only")
+	}
+
+	return strings.TrimSuffix(id, "-or-later")
+}
@@ -0,0 +1,31 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package license
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReconcile(t *testing.T) {
+	for _, tc := range []struct {
+		declared, detected string
+		concluded          string
+		conflict           string
+	}{
+		{"", "", "", ""},
+		{"MIT", "", "MIT", ""},
+		{"", "Apache-2.0", "Apache-2.0", ""},
+		{"MIT OR Apache-2.0", "Apache-2.0 AND MIT", "MIT OR Apache-2.0", ""},
+		{"GPL-2.0-or-later WITH Classpath-exception-2.0", "GPL-2.0-only", "GPL-2.0-or-later WITH Classpath-exception-2.0", ""},
+		{"MIT", "Apache-2.0", "MIT AND Apache-2.0", "the package declares MIT but its license files contain Apache-2.0"},
+		{"MIT OR Apache-2.0", "MIT AND BSD-3-Clause", "(MIT OR Apache-2.0) AND BSD-3-Clause", "the license files also contain BSD-3-Clause, which the package does not declare"},
+		{"MIT", "LicenseRef-Custom", "MIT AND LicenseRef-Custom", "the package declares MIT but its license files contain LicenseRef-Custom"},
+		{"MIT/Apache", "MIT", "MIT", `the declared license "MIT/Apache" is not a valid expression`},
+	} {
+		concluded, conflict := Reconcile(tc.declared, tc.detected)
+		assert.Equal(t, tc.concluded, concluded, "%s / %s", tc.declared, tc.detected)
+		assert.Equal(t, tc.conflict, conflict, "%s / %s", tc.declared, tc.detected)
+	}
+}
@@ -4,127 +4,72 @@ package javamaven
 
 import (
 	"bufio"
+	"context"
 	"encoding/xml"
+	"fmt"
 	"io"
-	"log"
 	"os"
-	"os/exec"
 	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/opensbom-generator/parsers/meta"
+	"github.com/opensbom-generator/parsers/purl"
 	"github.com/vifraa/gopom"
 
 	"github.com/opensbom-generator/parsers/internal/helper"
+	"github.com/opensbom-generator/parsers/internal/license"
 )
 
 // RepositoryURL is the repository url
 var RepositoryURL = "https://mvnrepository.com/artifact/"
 
-// captures os.Stdout data and writes buffers
-func stdOutCapture() func() (string, error) {
-	readFromPipe, writeToPipe, err := os.Pipe()
-	if err != nil {
-		panic(err)
-	}
-
-	done := make(chan error, 1)
-
-	save := os.Stdout
-	os.Stdout = writeToPipe
-
-	var buffer strings.Builder
-
-	go func() {
-		_, err := io.Copy(&buffer, readFromPipe)
-		readFromPipe.Close()
-		done <- err
-	}()
-
-	return func() (string, error) {
-		os.Stdout = save
-		writeToPipe.Close()
-		err := <-done
-		return buffer.String(), err
-	}
-}
-
-func getDependencyList() ([]string, error) {
-	done := stdOutCapture()
-	var err error
-
-	// TODO: refactor this part extract the exec to a package and reuse it
-	cmd1 := exec.Command("mvn", "-o", "dependency:list")
-	cmd2 := exec.Command("grep", ":.*:.*:.*")
-	cmd3 := exec.Command("cut", "-d]", "-f2-")
-	cmd4 := exec.Command("sort", "-u")
-	cmd2.Stdin, err = cmd1.StdoutPipe()
-	if err != nil {
-		return nil, err
-	}
-
-	cmd3.Stdin, err = cmd2.StdoutPipe()
-	if err != nil {
-		return nil, err
-	}
-
-	cmd4.Stdin, err = cmd3.StdoutPipe()
-	if err != nil {
-		return nil, err
-	}
-	cmd4.Stdout = os.Stdout
-	err = cmd4.Start()
-	if err != nil {
-		return nil, err
-	}
-
-	err = cmd3.Start()
-	if err != nil {
-		return nil, err
-	}
-
-	err = cmd2.Start()
-	if err != nil {
-		return nil, err
-	}
-
-	err = cmd1.Run()
+// getDependencyList returns the sorted, unique dependency lines of
+// "mvn dependency:list", without their log level prefix
+func getDependencyList(ctx context.Context) ([]string, error) {
+	result, err := helper.Run(ctx, helper.Command{Name: "mvn", Args: []string{"-o", "dependency:list"}})
 	if err != nil {
 		return nil, err
 	}
 
-	err = cmd2.Wait()
-	if err != nil {
-		return nil, err
-	}
-
-	err = cmd3.Wait()
-	if err != nil {
-		return nil, err
+	seen := map[string]bool{}
+	lines := []string{}
+	for _, line := range strings.Split(string(result.Stdout), "\n") {
+		if strings.Count(line, ":") < 3 {
+			continue
+		}
+		if _, rest, ok := strings.Cut(line, "]"); ok {
+			line = rest
+		}
+		if !seen[line] {
+			seen[line] = true
+			lines = append(lines, line)
+		}
 	}
+	sort.Strings(lines)
 
-	err = cmd4.Wait()
-	if err != nil {
-		return nil, err
-	}
+	// keeps the trailing empty line of the former shell pipeline output
+	return append(lines, ""), nil
+}
 
-	capturedOutput, err := done()
-	if err != nil {
-		return nil, err
+// pomLicense returns the license declared in a pom, the licenses listed are
+// to choose from
+func pomLicense(project gopom.Project) string {
+	names := []string{}
+	for _, l := range project.Licenses {
+		names = append(names, l.Name)
 	}
 
-	s := strings.Split(capturedOutput, "\n")
-	return s, err
+	return license.Or(names...)
 }
 
-func updateLicenseInformationToModule(mod *meta.Package) {
-	licensePkg, err := helper.GetLicenses(".")
-	if err == nil {
-		mod.LicenseDeclared = helper.BuildLicenseDeclared(licensePkg.ID)
-		mod.LicenseConcluded = helper.BuildLicenseConcluded(licensePkg.ID)
-		mod.Copyright = helper.GetCopyright(licensePkg.ExtractedText)
-		mod.CommentsLicense = licensePkg.Comments
+// updateLicenseInformationToModule sets the licenses and the copyright of a
+// module, those found in dir when the module is on disk
+func updateLicenseInformationToModule(ctx context.Context, mod *meta.Package, declared string, dir string) {
+	helper.SetLicenses(ctx, mod, declared, dir)
+	if dir != "" {
+		mod.Copyright = helper.ScanCopyright(dir)
 	}
 }
 
@@ -178,7 +123,7 @@ func updatePackageDownloadLocation(groupID string, project gopom.Project, mod *m
 	}
 }
 
-func convertProjectLevelPackageToModule(project gopom.Project) meta.Package {
+func convertProjectLevelPackageToModule(ctx context.Context, project gopom.Project, fpath string) meta.Package {
 	// package to module
 	var modName string
 	if len(project.Name) == 0 {
@@ -209,18 +154,22 @@ func convertProjectLevelPackageToModule(project gopom.Project) meta.Package {
 	mod.Name = modName
 	mod.Version = modVersion
 	mod.Packages = map[string]*meta.Package{}
-	mod.Checksum = meta.Checksum{
-		Algorithm: meta.HashAlgoSHA1,
-		Value:     readCheckSum(modName),
-	}
+	// the project is not built yet, there is no artifact to hash
+	mod.AddChecksum(meta.Checksum{Algorithm: meta.HashAlgoSHA1, Content: []byte(modName), Synthetic: true})
 	mod.Root = true
 	updatePackageSuppier(project, &mod, project.Developers)
 	updatePackageDownloadLocation(project.GroupID, project, &mod, project.DistributionManagement)
-	updateLicenseInformationToModule(&mod)
+	updateLicenseInformationToModule(ctx, &mod, pomLicense(project), fpath)
 	if len(project.URL) > 0 {
-		mod.PackageURL = project.URL
+		mod.PackageHomePage = project.URL
 	}
 
+	groupID := project.GroupID
+	if len(groupID) == 0 {
+		groupID = project.Parent.GroupID
+	}
+	mod.PackageURL = purl.Maven(groupID, strings.TrimSpace(project.ArtifactID), modVersion)
+
 	return mod
 }
 
@@ -242,26 +191,39 @@ func findInPlugins(slice []gopom.Plugin, val string) bool {
 	return false
 }
 
-func createModule(groupID string, name string, version string, project gopom.Project) meta.Package {
+// dependencyScope maps a maven dependency scope to a meta.Scope
+func dependencyScope(scope string) meta.Scope {
+	switch strings.TrimSpace(scope) {
+	case "test":
+		return meta.ScopeTest
+	case "provided", "system":
+		return meta.ScopeBuild
+	}
+	return meta.ScopeRuntime
+}
+
+func createModule(ctx context.Context, groupID string, name string, version string, project gopom.Project) meta.Package {
 	var mod meta.Package
 	modVersion := version
 	if strings.HasPrefix(version, "$") {
 		version1 := strings.TrimLeft(strings.TrimRight(version, "}"), "${")
 		modVersion = project.Properties.Entries[version1]
 	}
+	if groupID == "${project.groupId}" {
+		groupID = project.GroupID
+	}
 
 	name = path.Base(name)
 	name = strings.TrimSpace(name)
 	mod.Name = strings.ReplaceAll(name, " ", "-")
 	mod.Version = modVersion
+	mod.PackageURL = purl.Maven(groupID, name, modVersion)
 	mod.Packages = map[string]*meta.Package{}
-	mod.Checksum = meta.Checksum{
-		Algorithm: meta.HashAlgoSHA1,
-		Value:     readCheckSum(name),
-	}
+	mod.AddChecksum(repositoryChecksum(groupID, mod.Name, modVersion))
 	updatePackageSuppier(project, &mod, project.Developers)
 	updatePackageDownloadLocation(groupID, project, &mod, project.DistributionManagement)
-	updateLicenseInformationToModule(&mod)
+	// the pom read is the one of the project, not of the dependency
+	updateLicenseInformationToModule(ctx, &mod, "", artifactDirectory(groupID, mod.Name, modVersion))
 	return mod
 }
 
@@ -271,7 +233,6 @@ func readAndLoadPomFile(fpath string) (gopom.Project, error) {
 	filePath := fpath + "/pom.xml"
 	pomFile, err := os.Open(filePath)
 	if err != nil {
-		log.Println(err)
 		return project, err
 	}
 
@@ -289,8 +250,7 @@ func readAndLoadPomFile(fpath string) (gopom.Project, error) {
 
 	// Load project from string
 	if err := xml.Unmarshal(pomData, &project); err != nil {
-		log.Printf("unable to unmarshal pom file. Reason: %v", err)
-		return project, err
+		return project, fmt.Errorf("unable to unmarshal pom file: %w", err)
 	}
 
 	return project, nil
@@ -306,7 +266,7 @@ func getModule(modules []meta.Package, name string) (meta.Package, error) {
 }
 
 // If parent pom.xml has modules information in it, go to individual modules pom.xml
-func convertPkgModulesToModule(existingModules []meta.Package, fpath string, moduleName string, parentPom gopom.Project) ([]meta.Package, error) {
+func convertPkgModulesToModule(ctx context.Context, existingModules []meta.Package, fpath string, moduleName string, parentPom gopom.Project) ([]meta.Package, error) {
 	var modules []meta.Package
 	filePath := fpath + "/" + moduleName
 	project, err := readAndLoadPomFile(filePath)
@@ -314,7 +274,7 @@ func convertPkgModulesToModule(existingModules []meta.Package, fpath string, mod
 		return []meta.Package{}, err
 	}
 
-	parentMod := convertProjectLevelPackageToModule(project)
+	parentMod := convertProjectLevelPackageToModule(ctx, project, filePath)
 	parentMod.Root = false
 	modules = append(modules, parentMod)
 
@@ -326,7 +286,8 @@ func convertPkgModulesToModule(existingModules []meta.Package, fpath string, mod
 		if !found {
 			found1 = findInDependency(parentPom.DependencyManagement.Dependencies, name)
 			if !found1 {
-				mod := createModule(element.GroupID, name, element.Version, project)
+				mod := createModule(ctx, element.GroupID, name, element.Version, project)
+				mod.Scope = dependencyScope(element.Scope)
 				modules = append(modules, mod)
 				parentMod.Packages[mod.Name] = &mod
 			}
@@ -348,7 +309,8 @@ func convertPkgModulesToModule(existingModules []meta.Package, fpath string, mod
 		if !found {
 			found1 = findInPlugins(parentPom.Build.PluginManagement.Plugins, name)
 			if !found1 {
-				mod := createModule(element.GroupID, name, element.Version, project)
+				mod := createModule(ctx, element.GroupID, name, element.Version, project)
+				mod.Scope = meta.ScopeBuild
 				modules = append(modules, mod)
 				parentMod.Packages[mod.Name] = &mod
 			}
@@ -364,26 +326,28 @@ func convertPkgModulesToModule(existingModules []meta.Package, fpath string, mod
 	return modules, nil
 }
 
-func convertPOMReaderToModules(fpath string, lookForDepenent bool) ([]meta.Package, error) {
+func convertPOMReaderToModules(ctx context.Context, fpath string, lookForDepenent bool) ([]meta.Package, error) {
 	modules := make([]meta.Package, 0)
 	project, err := readAndLoadPomFile(fpath)
 	if err != nil {
 		return []meta.Package{}, err
 	}
-	parentMod := convertProjectLevelPackageToModule(project)
+	parentMod := convertProjectLevelPackageToModule(ctx, project, fpath)
 	parentMod.Root = true
 	modules = append(modules, parentMod)
 
 	// iterate over dependencyManagement
 	for _, dependencyManagement := range project.DependencyManagement.Dependencies {
-		mod := createModule(dependencyManagement.GroupID, dependencyManagement.ArtifactID, dependencyManagement.Version, project)
+		mod := createModule(ctx, dependencyManagement.GroupID, dependencyManagement.ArtifactID, dependencyManagement.Version, project)
+		mod.Scope = dependencyScope(dependencyManagement.Scope)
 		modules = append(modules, mod)
 		parentMod.Packages[mod.Name] = &mod
 	}
 
 	// iterate over dependencies
 	for _, dep := range project.Dependencies {
-		mod := createModule(dep.GroupID, dep.ArtifactID, dep.Version, project)
+		mod := createModule(ctx, dep.GroupID, dep.ArtifactID, dep.Version, project)
+		mod.Scope = dependencyScope(dep.Scope)
 		modules = append(modules, mod)
 		parentMod.Packages[mod.Name] = &mod
 	}
@@ -392,7 +356,8 @@ func convertPOMReaderToModules(fpath string, lookForDepenent bool) ([]meta.Packa
 	for _, plugin := range project.Build.Plugins {
 		// If plugin has groupId, skip here. Plugin details will be available at PluginManagement
 		if len(plugin.GroupID) == 0 {
-			mod := createModule(plugin.GroupID, plugin.ArtifactID, plugin.Version, project)
+			mod := createModule(ctx, plugin.GroupID, plugin.ArtifactID, plugin.Version, project)
+			mod.Scope = meta.ScopeBuild
 			modules = append(modules, mod)
 			parentMod.Packages[mod.Name] = &mod
 		}
@@ -400,15 +365,15 @@ func convertPOMReaderToModules(fpath string, lookForDepenent bool) ([]meta.Packa
 
 	// iterate over PluginManagement
 	for _, plugin := range project.Build.PluginManagement.Plugins {
-		mod := createModule(plugin.GroupID, plugin.ArtifactID, plugin.Version, project)
+		mod := createModule(ctx, plugin.GroupID, plugin.ArtifactID, plugin.Version, project)
+		mod.Scope = meta.ScopeBuild
 		modules = append(modules, mod)
 		parentMod.Packages[mod.Name] = &mod
 	}
 
-	dependencyList, err := getDependencyList()
+	dependencyList, err := getDependencyList(ctx)
 	if err != nil {
-		log.Println("error in getting mvn dependency list and parsing it")
-		return modules, err
+		return modules, fmt.Errorf("getting mvn dependency list: %w", err)
 	}
 
 	// Add additional dependency from mvn dependency list to pom.xml dependency list
@@ -442,7 +407,7 @@ func convertPOMReaderToModules(fpath string, lookForDepenent bool) ([]meta.Packa
 		if !found {
 			groupID := strings.Split(dependencyList[i], ":")[0]
 			version := strings.Split(dependencyList[i], ":")[3]
-			mod := createModule(strings.TrimSpace(groupID), dependencyItem, version, project)
+			mod := createModule(ctx, strings.TrimSpace(groupID), dependencyItem, version, project)
 			modules = append(modules, mod)
 			parentMod.Packages[mod.Name] = &mod
 		}
@@ -452,9 +417,10 @@ func convertPOMReaderToModules(fpath string, lookForDepenent bool) ([]meta.Packa
 	if lookForDepenent {
 		// iterate over Modules
 		for _, module := range project.Modules {
-			additionalModules, err := convertPkgModulesToModule(modules, fpath, module, project)
+			additionalModules, err := convertPkgModulesToModule(ctx, modules, fpath, module, project)
 			if err != nil {
 				// continue reading other module pom.xml file
+				helper.Report(ctx, meta.SeverityError, meta.CodeInvalidManifest, module, "reading the pom.xml of module %s: %v", module, err)
 				continue
 			}
 			modules = append(modules, additionalModules...)
@@ -464,29 +430,29 @@ func convertPOMReaderToModules(fpath string, lookForDepenent bool) ([]meta.Packa
 	return modules, nil
 }
 
-func getTransitiveDependencyList(workingDir string, globalSettingFile string) (map[string][]string, error) {
-	path := filepath.Join(os.TempDir(), "JavaMavenTDTreeOutput.txt")
-	os.Remove(path)
+func getTransitiveDependencyList(ctx context.Context, workingDir string, globalSettingFile string) (map[string][]string, error) {
+	dir, err := os.MkdirTemp("", "spdx-maven-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "JavaMavenTDTreeOutput.txt")
 
-	var command *exec.Cmd
+	args := []string{"dependency:tree", "-DoutputType=dot", "-DappendOutput=true", "-DoutputFile=" + path}
 	if len(globalSettingFile) > 0 {
-		globalSettingOption := "-gs=" + globalSettingFile
-		command = exec.Command("mvn", "dependency:tree", globalSettingOption, "-DoutputType=dot", "-DappendOutput=true", "-DoutputFile="+path)
-	} else {
-		command = exec.Command("mvn", "dependency:tree", "-DoutputType=dot", "-DappendOutput=true", "-DoutputFile="+path) //nolint: gosec
+		args = append(args, "-gs="+globalSettingFile)
+	}
+	if helper.IsOffline(ctx) {
+		args = append(args, "-o")
 	}
-	command.Dir = workingDir
-	out, err := command.CombinedOutput()
+	result, err := helper.Run(ctx, helper.Command{Name: "mvn", Args: args, Dir: workingDir, Files: []string{path}})
 	if err != nil {
-		log.Println(" dependency tree execution failure:", err)
-		log.Print(string(out))
+		helper.Report(ctx, meta.SeverityError, meta.CodeCommandFailed, "", "mvn dependency:tree failed: %s", result.Combined())
 		return nil, err
 	}
-	log.Println(" dependency tree executed successfully:")
 
 	tdList, err := readAndgetTransitiveDependencyList(path)
 	if err != nil {
-		log.Println(" readAndgetTransitiveDependencyList() failure:", err)
 		return nil, err
 	}
 
@@ -497,7 +463,6 @@ func readAndgetTransitiveDependencyList(path string) (map[string][]string, error
 	file, err := os.Open(path)
 
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
 
@@ -558,48 +523,39 @@ func handlePkgs(text []string, tdList map[string][]string) {
 	}
 }
 
-func buildDependenciesGraph(modules []meta.Package, tdList map[string][]string) {
-	moduleMap := map[string]meta.Package{}
-	moduleIndex := map[string]int{}
-
-	for idx, module := range modules {
-		moduleMap[module.Name] = module
-		moduleIndex[module.Name] = idx
+// buildDependenciesGraph adds the edges of the mvn dependency tree to the
+// modules read from the pom files, each module is listed once in the result
+func buildDependenciesGraph(modules []meta.Package, tdList map[string][]string) []meta.Package {
+	graph := meta.NewGraphFromPackages(purl.TypeMaven, modules)
+	ids := map[string]meta.NodeID{}
+	for i := range modules {
+		ids[modules[i].Name] = graph.ID(&modules[i])
 	}
 
-	for i := range tdList {
-		for j := range tdList[i] {
-			if len(tdList[i][j]) > 0 {
-				moduleName := i
-				if _, ok := moduleMap[moduleName]; !ok {
-					continue
-				}
-
-				depName := tdList[i][j]
-				depModule, ok := moduleMap[depName]
-				if !ok {
-					continue
-				}
+	for name, deps := range tdList {
+		from, ok := ids[name]
+		if !ok {
+			continue
+		}
 
-				modules[moduleIndex[moduleName]].Packages[depName] = &meta.Package{
-					Name:                    depModule.Name,
-					Version:                 depModule.Version,
-					Path:                    depModule.Path,
-					LocalPath:               depModule.LocalPath,
-					Supplier:                depModule.Supplier,
-					PackageURL:              depModule.PackageURL,
-					Checksum:                depModule.Checksum,
-					PackageHomePage:         depModule.PackageHomePage,
-					PackageDownloadLocation: depModule.PackageDownloadLocation,
-					LicenseConcluded:        depModule.LicenseConcluded,
-					LicenseDeclared:         depModule.LicenseDeclared,
-					CommentsLicense:         depModule.CommentsLicense,
-					OtherLicense:            depModule.OtherLicense,
-					Copyright:               depModule.Copyright,
-					PackageComment:          depModule.PackageComment,
-					Root:                    depModule.Root,
-				}
+		for _, dep := range deps {
+			to, ok := ids[dep]
+			// keep the scope declared in the pom for direct dependencies
+			if !ok || hasEdge(graph, from, to) {
+				continue
 			}
+			_ = graph.AddEdge(from, to, meta.ScopeRuntime)
+		}
+	}
+
+	return graph.Packages()
+}
+
+func hasEdge(graph *meta.Graph, from, to meta.NodeID) bool {
+	for _, id := range graph.Dependencies(from) {
+		if id == to {
+			return true
 		}
 	}
+	return false
 }
@@ -3,11 +3,11 @@
 package javamaven
 
 import (
-	"crypto/sha1"
-	"encoding/hex"
-	"log"
-	"os/exec"
+	"context"
+	"fmt"
+	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/opensbom-generator/parsers/internal/helper"
 	"github.com/opensbom-generator/parsers/meta"
@@ -18,6 +18,11 @@ type JavaMaven struct {
 	metadata   plugin.Metadata
 	rootModule *meta.Package
 	command    *helper.Cmd
+	options    plugin.Options
+}
+
+func init() {
+	plugin.Register("Java-Maven", func() plugin.Plugin { return New() })
 }
 
 // New ...
@@ -30,10 +35,21 @@ func New() *JavaMaven {
 			// TODO: instead of vendor folder what to mention for java project
 			// Currently checking for mvn executable path in PATH variable
 			ModulePath: []string{"."},
+			ReadOnly:   true,
+			Capabilities: plugin.Capabilities{
+				Executables:     []plugin.Executable{{Name: "mvn"}},
+				Network:         plugin.NetworkOptional,
+				TransitiveGraph: true,
+			},
 		},
 	}
 }
 
+// SetOptions configures how modules are listed
+func (m *JavaMaven) SetOptions(opts plugin.Options) {
+	m.options = opts
+}
+
 // GetMetadata ...
 func (m *JavaMaven) GetMetadata() plugin.Metadata {
 	return m.metadata
@@ -41,7 +57,13 @@ func (m *JavaMaven) GetMetadata() plugin.Metadata {
 
 // SetRootModule ...
 func (m *JavaMaven) SetRootModule(path string) error {
-	module, err := m.getModule(path)
+	return m.SetRootModuleContext(context.Background(), path)
+}
+
+// SetRootModuleContext ...
+func (m *JavaMaven) SetRootModuleContext(ctx context.Context, path string) error {
+	ctx = m.options.Context(ctx)
+	module, err := m.getModule(ctx, path)
 	if err != nil {
 		return err
 	}
@@ -63,17 +85,21 @@ func (m *JavaMaven) IsValid(path string) bool {
 
 // HasModulesInstalled ...
 func (m *JavaMaven) HasModulesInstalled(path string) error {
+	return m.HasModulesInstalledContext(context.Background(), path)
+}
+
+// HasModulesInstalledContext ...
+func (m *JavaMaven) HasModulesInstalledContext(ctx context.Context, path string) error {
+	ctx = m.options.Context(ctx)
 	// TODO: How to verify is java project is build
 	// Enforcing mvn path to be set in PATH variable
-	fname, err := exec.LookPath("mvn")
+	fname, err := helper.LookPath(ctx, "mvn")
 	if err != nil {
-		log.Println(err)
 		return err
 	}
 
 	_, err = filepath.Abs(fname)
 	if err != nil {
-		log.Println(err)
 		return err
 	}
 
@@ -82,7 +108,13 @@ func (m *JavaMaven) HasModulesInstalled(path string) error {
 
 // GetVersion...
 func (m *JavaMaven) GetVersion() (string, error) {
-	err := m.buildCmd(VersionCmd, ".")
+	return m.GetVersionContext(context.Background())
+}
+
+// GetVersionContext...
+func (m *JavaMaven) GetVersionContext(ctx context.Context) (string, error) {
+	ctx = m.options.Context(ctx)
+	err := m.buildCmd(ctx, VersionCmd, ".")
 	if err != nil {
 		return "", err
 	}
@@ -92,8 +124,14 @@ func (m *JavaMaven) GetVersion() (string, error) {
 
 // GetRootModule...
 func (m *JavaMaven) GetRootModule(path string) (*meta.Package, error) {
+	return m.GetRootModuleContext(context.Background(), path)
+}
+
+// GetRootModuleContext...
+func (m *JavaMaven) GetRootModuleContext(ctx context.Context, path string) (*meta.Package, error) {
+	ctx = m.options.Context(ctx)
 	if m.rootModule == nil {
-		module, err := m.getModule(path)
+		module, err := m.getModule(ctx, path)
 		if err != nil {
 			return nil, err
 		}
@@ -106,39 +144,50 @@ func (m *JavaMaven) GetRootModule(path string) (*meta.Package, error) {
 
 // ListUsedModules...
 func (m *JavaMaven) ListUsedModules(path string) ([]meta.Package, error) {
-	modules, err := convertPOMReaderToModules(path, true)
+	return m.ListUsedModulesContext(context.Background(), path)
+}
+
+// ListUsedModulesContext...
+func (m *JavaMaven) ListUsedModulesContext(ctx context.Context, path string) ([]meta.Package, error) {
+	ctx = m.options.Context(ctx)
+	modules, err := convertPOMReaderToModules(ctx, path, true)
 
 	if err != nil {
-		log.Println(err)
 		return modules, err
 	}
+	meta.Sort(modules)
 
 	return modules, nil
 }
 
 // ListModulesWithDeps ...
 func (m *JavaMaven) ListModulesWithDeps(path string, globalSettingFile string) ([]meta.Package, error) {
-	modules, err := m.ListUsedModules(path)
+	return m.ListModulesWithDepsContext(context.Background(), path, globalSettingFile)
+}
+
+// ListModulesWithDepsContext ...
+func (m *JavaMaven) ListModulesWithDepsContext(ctx context.Context, path string, globalSettingFile string) ([]meta.Package, error) {
+	ctx = m.options.Context(ctx)
+	modules, err := m.ListUsedModulesContext(ctx, path)
 	if err != nil {
 		return nil, err
 	}
 
-	tdList, err := getTransitiveDependencyList(path, globalSettingFile)
+	tdList, err := getTransitiveDependencyList(ctx, path, globalSettingFile)
 	if err != nil {
-		log.Println("error in getting mvn transitive dependency tree and parsing it")
-		return nil, err
+		return nil, fmt.Errorf("getting mvn transitive dependency tree: %w", err)
 	}
 
-	buildDependenciesGraph(modules, tdList)
+	modules = buildDependenciesGraph(modules, tdList)
+	meta.ResolveScopes(modules)
 
-	return modules, nil
+	return m.options.Filter(modules), nil
 }
 
-func (m *JavaMaven) getModule(path string) (meta.Package, error) {
-	modules, err := convertPOMReaderToModules(path, false)
+func (m *JavaMaven) getModule(ctx context.Context, path string) (meta.Package, error) {
+	modules, err := convertPOMReaderToModules(ctx, path, false)
 
 	if err != nil {
-		log.Println(err)
 		return meta.Package{}, err
 	}
 
@@ -149,13 +198,14 @@ func (m *JavaMaven) getModule(path string) (meta.Package, error) {
 	return modules[0], nil
 }
 
-func (m *JavaMaven) buildCmd(cmd command, path string) error {
+func (m *JavaMaven) buildCmd(ctx context.Context, cmd command, path string) error {
 	cmdArgs := cmd.Parse()
 
 	command := helper.NewCmd(helper.CmdOptions{
 		Name:      cmdArgs[0],
 		Args:      cmdArgs[1:],
 		Directory: path,
+		Context:   ctx,
 	})
 
 	m.command = command
@@ -163,8 +213,51 @@ func (m *JavaMaven) buildCmd(cmd command, path string) error {
 	return command.Build()
 }
 
-func readCheckSum(content string) string {
-	h := sha1.New()
-	h.Write([]byte(content))
-	return hex.EncodeToString(h.Sum(nil))
+// localRepository returns the directory of the local maven repository
+func localRepository() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".m2", "repository")
+}
+
+// artifactDirectory returns the directory of an artifact in the local
+// repository, or "" when it is not there
+func artifactDirectory(groupID, artifactID, version string) string {
+	repository := localRepository()
+	if repository == "" || groupID == "" || version == "" {
+		return ""
+	}
+	dir := filepath.Join(repository, filepath.FromSlash(strings.ReplaceAll(groupID, ".", "/")), artifactID, version)
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return ""
+	}
+	return dir
+}
+
+// repositoryChecksum returns the SHA1 of the jar, or of the pom for
+// artifacts without one, recorded in the local repository. Artifacts that
+// were not downloaded get a synthetic checksum of their name.
+func repositoryChecksum(groupID, artifactID, version string) meta.Checksum {
+	dir := artifactDirectory(groupID, artifactID, version)
+	for _, ext := range []string{".jar.sha1", ".pom.sha1"} {
+		if dir == "" {
+			break
+		}
+		data, err := os.ReadFile(filepath.Join(dir, artifactID+"-"+version+ext))
+		if err != nil {
+			continue
+		}
+		// the file may hold the digest followed by the file name
+		fields := strings.Fields(string(data))
+		if len(fields) == 0 {
+			continue
+		}
+		if c, err := meta.NewChecksum(meta.HashAlgoSHA1, fields[0]); err == nil {
+			return c
+		}
+	}
+
+	return meta.Checksum{Algorithm: meta.HashAlgoSHA1, Content: []byte(artifactID), Synthetic: true}
 }
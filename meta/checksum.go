@@ -0,0 +1,344 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package meta
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/md4"
+	"golang.org/x/crypto/sha3"
+	"lukechampine.com/blake3"
+)
+
+// Checksum is a digest of a package. Value holds the hex encoded digest,
+// when it is empty the digest is computed from Content.
+type Checksum struct {
+	Algorithm HashAlgorithm
+	Content   []byte
+	Value     string
+	// Synthetic is true when the checksum is not a digest of the package
+	// artifact, e.g. a hash of the package name
+	Synthetic bool
+}
+
+// digestEncodings are the encodings digests are found in, tried in order
+var digestEncodings = []func(string) ([]byte, error){
+	hex.DecodeString,
+	base64.StdEncoding.DecodeString,
+	base64.RawStdEncoding.DecodeString,
+	base64.URLEncoding.DecodeString,
+	base64.RawURLEncoding.DecodeString,
+}
+
+// NewChecksum returns the checksum of an artifact whose digest is known,
+// hex or base64 encoded. The value is normalized to lower case hex, digests
+// whose size does not match the algorithm are rejected.
+func NewChecksum(algorithm HashAlgorithm, digest string) (Checksum, error) {
+	digest = strings.TrimSpace(digest)
+	size := algorithm.Size()
+	for _, decode := range digestEncodings {
+		raw, err := decode(digest)
+		if err != nil || len(raw) == 0 || (size > 0 && len(raw) != size) {
+			continue
+		}
+		return Checksum{Algorithm: algorithm, Value: hex.EncodeToString(raw)}, nil
+	}
+
+	return Checksum{}, fmt.Errorf("invalid %s digest %q", algorithm, digest)
+}
+
+// ParseChecksum parses a digest prefixed by its algorithm, as written by the
+// tools and formats around: "sha256:<hex>", "SHA256: <hex>" or an integrity
+// string "sha512-<base64>"
+func ParseChecksum(s string) (Checksum, error) {
+	s = strings.TrimSpace(s)
+	name, digest, ok := strings.Cut(s, ":")
+	if !ok {
+		checksums, err := ParseSRI(s)
+		if err != nil {
+			return Checksum{}, err
+		}
+		if len(checksums) != 1 {
+			return Checksum{}, fmt.Errorf("invalid checksum %q", s)
+		}
+		return checksums[0], nil
+	}
+
+	algorithm, err := ParseHashAlgorithm(name)
+	if err != nil {
+		return Checksum{}, err
+	}
+	return NewChecksum(algorithm, digest)
+}
+
+// SyntheticChecksum returns a SHA256 checksum of content flagged as
+// synthetic, for packages without an artifact digest
+func SyntheticChecksum(content string) Checksum {
+	return Checksum{Algorithm: HashAlgoSHA256, Content: []byte(content), Synthetic: true}
+}
+
+// ParseSRI returns the checksums of a subresource integrity string such as
+// the integrity fields of npm and yarn lock files, e.g. "sha512-<base64>".
+// Several hashes may be given separated by spaces. Besides the SHA2 hashes
+// of the specification, sha1 of old lock files and the other supported
+// algorithms are accepted, e.g. "sha3-256-<base64>".
+func ParseSRI(integrity string) ([]Checksum, error) {
+	checksums := []Checksum{}
+	for _, field := range strings.Fields(integrity) {
+		// the digest follows the last dash, base64 has none
+		i := strings.LastIndex(field, "-")
+		if i < 0 {
+			return nil, fmt.Errorf("invalid integrity %q", field)
+		}
+		algorithm, err := ParseHashAlgorithm(field[:i])
+		if err != nil || !algorithm.Supported() {
+			return nil, fmt.Errorf("%w in integrity %q", ErrUnsupportedAlgorithm, field)
+		}
+		// options may follow the digest after a question mark
+		digest, _, _ := strings.Cut(field[i+1:], "?")
+		c, err := NewChecksum(algorithm, digest)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integrity %q: %w", field, err)
+		}
+		checksums = append(checksums, c)
+	}
+
+	return checksums, nil
+}
+
+// String returns the hex encoded digest, computed from Content when Value
+// is empty. It is empty when the algorithm is not supported.
+func (c *Checksum) String() string {
+	if c.Value == "" {
+		c.Value = c.Compute(c.Content)
+	}
+	return c.Value
+}
+
+// Digest returns the hex encoded digest like String, but fails for
+// algorithms that cannot be computed
+func (c *Checksum) Digest() (string, error) {
+	if c.Value != "" {
+		return c.Value, nil
+	}
+
+	value, err := c.Algorithm.Sum(c.Content)
+	if err != nil {
+		return "", err
+	}
+	c.Value = value
+	return c.Value, nil
+}
+
+// Compute returns the hex encoded digest of content, or an empty string
+// when the algorithm is not supported
+func (c *Checksum) Compute(content []byte) string {
+	value, err := c.Algorithm.Sum(content)
+	if err != nil {
+		return ""
+	}
+	return value
+}
+
+// ComputeChecksum reads r to its end and returns its checksum
+func ComputeChecksum(algorithm HashAlgorithm, r io.Reader) (Checksum, error) {
+	h, err := algorithm.New()
+	if err != nil {
+		return Checksum{}, err
+	}
+	if _, err := io.Copy(h, r); err != nil {
+		return Checksum{}, err
+	}
+
+	return Checksum{Algorithm: algorithm, Value: hex.EncodeToString(h.Sum(nil))}, nil
+}
+
+// ComputeFileChecksum returns the checksum of the file at path, which is
+// read in chunks rather than loaded in memory
+func ComputeFileChecksum(algorithm HashAlgorithm, path string) (Checksum, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Checksum{}, err
+	}
+	defer f.Close()
+
+	return ComputeChecksum(algorithm, f)
+}
+
+// HashAlgorithm ...
+type HashAlgorithm string
+
+// The names of the algorithms are the ones of SPDX
+const (
+	HashAlgoSHA1        HashAlgorithm = "SHA1"
+	HashAlgoSHA224      HashAlgorithm = "SHA224"
+	HashAlgoSHA256      HashAlgorithm = "SHA256"
+	HashAlgoSHA384      HashAlgorithm = "SHA384"
+	HashAlgoSHA512      HashAlgorithm = "SHA512"
+	HashAlgoSHA3_256    HashAlgorithm = "SHA3-256"
+	HashAlgoSHA3_384    HashAlgorithm = "SHA3-384"
+	HashAlgoSHA3_512    HashAlgorithm = "SHA3-512"
+	HashAlgoBLAKE2b_256 HashAlgorithm = "BLAKE2b-256"
+	HashAlgoBLAKE2b_384 HashAlgorithm = "BLAKE2b-384"
+	HashAlgoBLAKE2b_512 HashAlgorithm = "BLAKE2b-512"
+	HashAlgoBLAKE3      HashAlgorithm = "BLAKE3"
+	HashAlgoMD2         HashAlgorithm = "MD2"
+	HashAlgoMD4         HashAlgorithm = "MD4"
+	HashAlgoMD5         HashAlgorithm = "MD5"
+	HashAlgoMD6         HashAlgorithm = "MD6"
+)
+
+// ErrUnsupportedAlgorithm is returned for algorithms whose digests cannot
+// be computed, such as MD2 and MD6
+var ErrUnsupportedAlgorithm = errors.New("unsupported hash algorithm")
+
+// hashes returns a new hash of the algorithms that can be computed
+var hashes = map[HashAlgorithm]func() hash.Hash{
+	HashAlgoSHA1:        sha1.New,
+	HashAlgoSHA224:      sha256.New224,
+	HashAlgoSHA256:      sha256.New,
+	HashAlgoSHA384:      sha512.New384,
+	HashAlgoSHA512:      sha512.New,
+	HashAlgoSHA3_256:    sha3.New256,
+	HashAlgoSHA3_384:    sha3.New384,
+	HashAlgoSHA3_512:    sha3.New512,
+	HashAlgoBLAKE2b_256: newBLAKE2b(blake2b.New256),
+	HashAlgoBLAKE2b_384: newBLAKE2b(blake2b.New384),
+	HashAlgoBLAKE2b_512: newBLAKE2b(blake2b.New512),
+	HashAlgoBLAKE3:      func() hash.Hash { return blake3.New(32, nil) },
+	HashAlgoMD4:         md4.New,
+	HashAlgoMD5:         md5.New,
+}
+
+// newBLAKE2b drops the error of the constructors, which only fail for keys
+// longer than 64 bytes
+func newBLAKE2b(fn func(key []byte) (hash.Hash, error)) func() hash.Hash {
+	return func() hash.Hash {
+		h, _ := fn(nil)
+		return h
+	}
+}
+
+// New returns a hash computing the digests of the algorithm
+func (a HashAlgorithm) New() (hash.Hash, error) {
+	fn, ok := hashes[a]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnsupportedAlgorithm, a)
+	}
+	return fn(), nil
+}
+
+// Size returns the size in bytes of the digests of the algorithm, or 0 when
+// it is not known
+func (a HashAlgorithm) Size() int {
+	switch a {
+	case HashAlgoMD2:
+		return 16
+	case HashAlgoMD6:
+		// the size is a parameter of MD6
+		return 0
+	}
+	h, err := a.New()
+	if err != nil {
+		return 0
+	}
+	return h.Size()
+}
+
+// Supported reports whether the digests of the algorithm can be computed
+func (a HashAlgorithm) Supported() bool {
+	_, ok := hashes[a]
+	return ok
+}
+
+// Sum returns the hex encoded digest of content
+func (a HashAlgorithm) Sum(content []byte) (string, error) {
+	h, err := a.New()
+	if err != nil {
+		return "", err
+	}
+	h.Write(content)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// ParseHashAlgorithm returns the algorithm named s, ignoring case and
+// dashes, e.g. "sha-256", "SHA256" and "sha3_256" are accepted
+func ParseHashAlgorithm(s string) (HashAlgorithm, error) {
+	normalize := strings.NewReplacer("-", "", "_", "")
+	name := normalize.Replace(strings.ToUpper(strings.TrimSpace(s)))
+	for _, a := range algorithms {
+		if normalize.Replace(strings.ToUpper(string(a))) == name {
+			return a, nil
+		}
+	}
+	return "", fmt.Errorf("%w %q", ErrUnsupportedAlgorithm, s)
+}
+
+// algorithms lists every declared algorithm
+var algorithms = []HashAlgorithm{
+	HashAlgoSHA1, HashAlgoSHA224, HashAlgoSHA256, HashAlgoSHA384, HashAlgoSHA512,
+	HashAlgoSHA3_256, HashAlgoSHA3_384, HashAlgoSHA3_512,
+	HashAlgoBLAKE2b_256, HashAlgoBLAKE2b_384, HashAlgoBLAKE2b_512, HashAlgoBLAKE3,
+	HashAlgoMD2, HashAlgoMD4, HashAlgoMD5, HashAlgoMD6,
+}
+
+// AddChecksum adds c to the checksums of the package, artifact digests
+// before synthetic ones, unless an equal checksum is already listed. The
+// deprecated Checksum field is set to the first checksum. Checksums whose
+// digest cannot be computed are left out.
+func (p *Package) AddChecksum(c Checksum) {
+	if c.Value == "" && len(c.Content) == 0 {
+		return
+	}
+
+	// keep the digest only, the content may be large
+	value, err := c.Digest()
+	if err != nil {
+		return
+	}
+	c.Content = nil
+	at := len(p.Checksums)
+	for i := range p.Checksums {
+		if p.Checksums[i].Algorithm == c.Algorithm && p.Checksums[i].String() == value {
+			return
+		}
+		if p.Checksums[i].Synthetic && !c.Synthetic && at == len(p.Checksums) {
+			at = i
+		}
+	}
+
+	p.Checksums = append(p.Checksums, Checksum{})
+	copy(p.Checksums[at+1:], p.Checksums[at:])
+	p.Checksums[at] = c
+	p.Checksum = p.Checksums[0]
+}
+
+// ArtifactChecksums returns the checksums of the package that are digests
+// of its artifact. Packages built without Checksums fall back to Checksum.
+func (p *Package) ArtifactChecksums() []Checksum {
+	checksums := p.Checksums
+	if len(checksums) == 0 {
+		checksums = []Checksum{p.Checksum}
+	}
+
+	result := []Checksum{}
+	for _, c := range checksums {
+		if !c.Synthetic && (c.Value != "" || len(c.Content) > 0) {
+			result = append(result, c)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,171 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package meta
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewChecksum(t *testing.T) {
+	c, err := NewChecksum(HashAlgoSHA1, " DA39A3EE5E6B4B0D3255BFEF95601890AFD80709\n")
+	require.NoError(t, err)
+	assert.Equal(t, Checksum{Algorithm: HashAlgoSHA1, Value: "da39a3ee5e6b4b0d3255bfef95601890afd80709"}, c)
+
+	c, err = NewChecksum(HashAlgoSHA256, "47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=")
+	require.NoError(t, err)
+	assert.Equal(t, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", c.Value)
+
+	for _, digest := range []string{"", "none", "NO ASSERTION", "da39a3ee"} {
+		_, err := NewChecksum(HashAlgoSHA1, digest)
+		assert.Error(t, err, digest)
+	}
+}
+
+func TestParseSRI(t *testing.T) {
+	checksums, err := ParseSRI("sha1-2jmj7l5rSw0yVb/vlWAYkK/YBwk= sha256-47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=?ct=application/javascript")
+	require.NoError(t, err)
+	assert.Equal(t, []Checksum{
+		{Algorithm: HashAlgoSHA1, Value: "da39a3ee5e6b4b0d3255bfef95601890afd80709"},
+		{Algorithm: HashAlgoSHA256, Value: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+	}, checksums)
+
+	_, err = ParseSRI("md2-1B2M2Y8AsgTpgAmY7PhCfg==")
+	assert.ErrorIs(t, err, ErrUnsupportedAlgorithm)
+	_, err = ParseSRI("sha512-not base64")
+	assert.Error(t, err)
+}
+
+func TestAddChecksum(t *testing.T) {
+	p := Package{}
+	p.AddChecksum(Checksum{})
+	assert.Empty(t, p.Checksums)
+
+	p.AddChecksum(SyntheticChecksum("left-pad"))
+	assert.True(t, p.Checksum.Synthetic)
+	assert.Nil(t, p.Checksum.Content)
+
+	sha1 := Checksum{Algorithm: HashAlgoSHA1, Value: "da39a3ee5e6b4b0d3255bfef95601890afd80709"}
+	p.AddChecksum(sha1)
+	p.AddChecksum(Checksum{Algorithm: HashAlgoSHA1, Content: []byte{}, Value: sha1.Value})
+	sha512 := Checksum{Algorithm: HashAlgoSHA512, Value: "cf83e1357eefb8bd"}
+	p.AddChecksum(sha512)
+
+	require.Len(t, p.Checksums, 3)
+	assert.Equal(t, sha1, p.Checksums[0])
+	assert.Equal(t, sha512, p.Checksums[1])
+	assert.True(t, p.Checksums[2].Synthetic)
+	assert.Equal(t, sha1, p.Checksum)
+
+	assert.Equal(t, []Checksum{sha1, sha512}, p.ArtifactChecksums())
+}
+
+func TestArtifactChecksums(t *testing.T) {
+	legacy := Package{Checksum: Checksum{Algorithm: HashAlgoSHA1, Value: "abc"}}
+	assert.Equal(t, []Checksum{legacy.Checksum}, legacy.ArtifactChecksums())
+
+	assert.Empty(t, (&Package{}).ArtifactChecksums())
+}
+
+func TestHashAlgorithmSum(t *testing.T) {
+	for algorithm, want := range map[HashAlgorithm]string{
+		HashAlgoSHA1:        "a9993e364706816aba3e25717850c26c9cd0d89d",
+		HashAlgoSHA224:      "23097d223405d8228642a477bda255b32aadbce4bda0b3f7e36c9da7",
+		HashAlgoSHA256:      "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad",
+		HashAlgoSHA384:      "cb00753f45a35e8bb5a03d699ac65007272c32ab0eded1631a8b605a43ff5bed8086072ba1e7cc2358baeca134c825a7",
+		HashAlgoSHA512:      "ddaf35a193617abacc417349ae20413112e6fa4e89a97ea20a9eeee64b55d39a2192992a274fc1a836ba3c23a3feebbd454d4423643ce80e2a9ac94fa54ca49f",
+		HashAlgoSHA3_256:    "3a985da74fe225b2045c172d6bd390bd855f086e3e9d525b46bfe24511431532",
+		HashAlgoSHA3_384:    "ec01498288516fc926459f58e2c6ad8df9b473cb0fc08c2596da7cf0e49be4b298d88cea927ac7f539f1edf228376d25",
+		HashAlgoSHA3_512:    "b751850b1a57168a5693cd924b6b096e08f621827444f70d884f5d0240d2712e10e116e9192af3c91a7ec57647e3934057340b4cf408d5a56592f8274eec53f0",
+		HashAlgoBLAKE2b_256: "bddd813c634239723171ef3fee98579b94964e3bb1cb3e427262c8c068d52319",
+		HashAlgoBLAKE2b_384: "6f56a82c8e7ef526dfe182eb5212f7db9df1317e57815dbda46083fc30f54ee6c66ba83be64b302d7cba6ce15bb556f4",
+		HashAlgoBLAKE2b_512: "ba80a53f981c4d0d6a2797b69f12f6e94c212f14685ac4b74b12bb6fdbffa2d17d87c5392aab792dc252d5de4533cc9518d38aa8dbf1925ab92386edd4009923",
+		HashAlgoBLAKE3:      "6437b3ac38465133ffb63b75273a8db548c558465d79db03fd359c6cd5bd9d85",
+		HashAlgoMD4:         "a448017aaf21d8525fc10ae87aa6729d",
+		HashAlgoMD5:         "900150983cd24fb0d6963f7d28e17f72",
+	} {
+		got, err := algorithm.Sum([]byte("abc"))
+		require.NoError(t, err, algorithm)
+		assert.Equal(t, want, got, algorithm)
+		assert.Equal(t, len(want)/2, algorithm.Size(), algorithm)
+
+		c := Checksum{Algorithm: algorithm, Content: []byte("abc")}
+		assert.Equal(t, want, c.String(), algorithm)
+	}
+
+	for _, algorithm := range []HashAlgorithm{HashAlgoMD2, HashAlgoMD6, "None"} {
+		_, err := algorithm.Sum([]byte("abc"))
+		assert.ErrorIs(t, err, ErrUnsupportedAlgorithm, algorithm)
+		c := Checksum{Algorithm: algorithm, Content: []byte("abc")}
+		assert.Empty(t, c.String(), "no SHA1 in place of %s", algorithm)
+		_, err = c.Digest()
+		assert.ErrorIs(t, err, ErrUnsupportedAlgorithm, algorithm)
+	}
+
+	p := Package{}
+	p.AddChecksum(Checksum{Algorithm: HashAlgoMD6, Content: []byte("abc")})
+	assert.Empty(t, p.Checksums)
+}
+
+func TestComputeChecksum(t *testing.T) {
+	c, err := ComputeChecksum(HashAlgoSHA256, strings.NewReader("abc"))
+	require.NoError(t, err)
+	assert.Equal(t, Checksum{Algorithm: HashAlgoSHA256, Value: "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"}, c)
+
+	path := filepath.Join(t.TempDir(), "abc.txt")
+	require.NoError(t, os.WriteFile(path, []byte("abc"), 0o600))
+	c, err = ComputeFileChecksum(HashAlgoBLAKE3, path)
+	require.NoError(t, err)
+	assert.Equal(t, "6437b3ac38465133ffb63b75273a8db548c558465d79db03fd359c6cd5bd9d85", c.Value)
+
+	_, err = ComputeFileChecksum(HashAlgoSHA1, filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+	_, err = ComputeChecksum(HashAlgoMD2, strings.NewReader("abc"))
+	assert.ErrorIs(t, err, ErrUnsupportedAlgorithm)
+}
+
+func TestParseHashAlgorithm(t *testing.T) {
+	for s, want := range map[string]HashAlgorithm{
+		"sha256":      HashAlgoSHA256,
+		"SHA-256":     HashAlgoSHA256,
+		"sha3_256":    HashAlgoSHA3_256,
+		"blake2b-512": HashAlgoBLAKE2b_512,
+		" BLAKE3 ":    HashAlgoBLAKE3,
+		"md6":         HashAlgoMD6,
+	} {
+		got, err := ParseHashAlgorithm(s)
+		require.NoError(t, err, s)
+		assert.Equal(t, want, got, s)
+	}
+
+	_, err := ParseHashAlgorithm("whirlpool")
+	assert.ErrorIs(t, err, ErrUnsupportedAlgorithm)
+}
+
+func TestParseChecksum(t *testing.T) {
+	sha256 := Checksum{Algorithm: HashAlgoSHA256, Value: "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"}
+	for _, s := range []string{
+		"sha256:ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad",
+		"SHA256: BA7816BF8F01CFEA414140DE5DAE2223B00361A396177A9CB410FF61F20015AD",
+		"sha256-ungWv48Bz+pBQUDeXa4iI7ADYaOWF3qctBD/YfIAFa0=",
+		"sha-256:ungWv48Bz-pBQUDeXa4iI7ADYaOWF3qctBD_YfIAFa0",
+	} {
+		c, err := ParseChecksum(s)
+		require.NoError(t, err, s)
+		assert.Equal(t, sha256, c, s)
+	}
+
+	c, err := ParseChecksum("sha3-256-Ophdp0/iJbIEXBcta9OQvYVfCG4+nVJbRr/iRRFDFTI=")
+	require.NoError(t, err)
+	assert.Equal(t, Checksum{Algorithm: HashAlgoSHA3_256, Value: "3a985da74fe225b2045c172d6bd390bd855f086e3e9d525b46bfe24511431532"}, c)
+
+	for _, s := range []string{"", "sha256:abc", "sha1-x sha1-y", "whirlpool:00"} {
+		_, err := ParseChecksum(s)
+		assert.Error(t, err, s)
+	}
+}
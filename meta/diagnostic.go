@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package meta
+
+import "fmt"
+
+// Severity tells how much a problem affects the packages returned by a
+// plugin
+type Severity string
+
+const (
+	// SeverityError problems leave packages or dependencies out
+	SeverityError Severity = "error"
+	// SeverityWarning problems leave fields of a package incomplete
+	SeverityWarning Severity = "warning"
+	// SeverityInfo notes do not change the packages
+	SeverityInfo Severity = "info"
+)
+
+// Codes identify the kind of a diagnostic
+const (
+	CodeMissingDependency   = "missing-dependency"
+	CodeMissingMetadata     = "missing-metadata"
+	CodeMissingDistInfo     = "missing-dist-info"
+	CodeLicenseNotDetected  = "license-not-detected"
+	CodeLicenseConflict     = "license-conflict"
+	CodeChecksumUnavailable = "checksum-unavailable"
+	CodeRegistryUnavailable = "registry-unavailable"
+	CodeCommandFailed       = "command-failed"
+	CodeInvalidManifest     = "invalid-manifest"
+)
+
+// Diagnostic is a problem met while listing packages
+type Diagnostic struct {
+	Severity Severity `json:"severity"`
+	Code     string   `json:"code"`
+	// Package is the name of the package concerned, empty when the problem
+	// concerns the whole project
+	Package string `json:"package,omitempty"`
+	Message string `json:"message"`
+}
+
+func (d Diagnostic) String() string {
+	if d.Package == "" {
+		return fmt.Sprintf("%s %s: %s", d.Severity, d.Code, d.Message)
+	}
+
+	return fmt.Sprintf("%s %s %s: %s", d.Severity, d.Code, d.Package, d.Message)
+}
+
+// Diagnostics is the list of problems reported by a plugin call
+type Diagnostics []Diagnostic
+
+// Packages returns the names of the packages with a problem of at least
+// the given severity
+func (ds Diagnostics) Packages(min Severity) []string {
+	seen := map[string]bool{}
+	names := []string{}
+	for _, d := range ds {
+		if d.Package == "" || seen[d.Package] || d.Severity.rank() < min.rank() {
+			continue
+		}
+		seen[d.Package] = true
+		names = append(names, d.Package)
+	}
+
+	return names
+}
+
+// HasErrors reports whether a diagnostic has the error severity
+func (ds Diagnostics) HasErrors() bool {
+	for _, d := range ds {
+		if d.Severity == SeverityError {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s Severity) rank() int {
+	switch s {
+	case SeverityError:
+		return 2
+	case SeverityWarning:
+		return 1
+	}
+
+	return 0
+}
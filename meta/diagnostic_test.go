@@ -0,0 +1,26 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiagnosticsPackages(t *testing.T) {
+	diagnostics := Diagnostics{
+		{Severity: SeverityInfo, Code: CodeMissingMetadata, Package: "a"},
+		{Severity: SeverityWarning, Code: CodeLicenseNotDetected, Package: "b"},
+		{Severity: SeverityError, Code: CodeMissingDependency, Package: "c"},
+		{Severity: SeverityError, Code: CodeCommandFailed},
+		{Severity: SeverityWarning, Code: CodeMissingDistInfo, Package: "b"},
+	}
+
+	assert.Equal(t, []string{"a", "b", "c"}, diagnostics.Packages(SeverityInfo))
+	assert.Equal(t, []string{"b", "c"}, diagnostics.Packages(SeverityWarning))
+	assert.Equal(t, []string{"c"}, diagnostics.Packages(SeverityError))
+	assert.True(t, diagnostics.HasErrors())
+	assert.False(t, diagnostics[:2].HasErrors())
+	assert.Equal(t, "warning license-not-detected b: ", diagnostics[1].String())
+}
@@ -0,0 +1,193 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package meta
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/opensbom-generator/parsers/internal/license"
+)
+
+// File is a file of a package
+type File struct {
+	// Path is relative to the package directory and slash separated
+	Path string `json:"path"`
+	// Checksums are the SHA1 and SHA256 digests of the file
+	Checksums []Checksum `json:"checksums"`
+	// Licenses are the identifiers of the licenses found in the file
+	Licenses []string `json:"licenses,omitempty"`
+}
+
+// VerificationCode is the SPDX package verification code: the SHA1 of the
+// sorted SHA1 digests of the files of the package
+type VerificationCode struct {
+	Value string `json:"value"`
+	// ExcludedFiles are the files left out of the computation
+	ExcludedFiles []string `json:"excludedFiles,omitempty"`
+}
+
+// DefaultFileExclude are the patterns excluded when FileOptions.Exclude is
+// nil: version control metadata and the directories holding other packages
+var DefaultFileExclude = []string{".git", ".hg", ".svn", "node_modules"}
+
+// FileOptions configures how the files of a package are analyzed
+type FileOptions struct {
+	// Exclude are the files and directories to leave out. A pattern without
+	// a slash matches names, one with a slash matches paths relative to the
+	// package directory. nil means DefaultFileExclude.
+	Exclude []string
+	// Licenses detects the licenses found in the files, which is slow on
+	// large packages
+	Licenses bool
+}
+
+// licenseHead is the number of bytes of a file searched for licenses, enough
+// for the header of a source file or a license text
+const licenseHead = 64 << 10
+
+// AnalyzeFiles lists the files found in the LocalPath of the package and
+// computes its verification code. Packages without a LocalPath are left as
+// they are.
+func (p *Package) AnalyzeFiles(opts FileOptions) error {
+	if p.LocalPath == "" {
+		return nil
+	}
+
+	files, code, err := ListFiles(p.LocalPath, opts)
+	if err != nil {
+		return err
+	}
+	p.Files = files
+	p.VerificationCode = &code
+
+	return nil
+}
+
+// ListFiles returns the files under dir sorted by path, with the
+// verification code computed over them
+func ListFiles(dir string, opts FileOptions) ([]File, VerificationCode, error) {
+	exclude := opts.Exclude
+	if exclude == nil {
+		exclude = DefaultFileExclude
+	}
+
+	files := []File{}
+	code := VerificationCode{}
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil || rel == "." {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if excluded(rel, exclude) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			code.ExcludedFiles = append(code.ExcludedFiles, rel)
+			return nil
+		}
+		// symbolic links and special files have no content of their own
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		f, err := analyzeFile(p, rel, opts.Licenses)
+		if err != nil {
+			return err
+		}
+		files = append(files, f)
+		return nil
+	})
+	if err != nil {
+		return nil, VerificationCode{}, err
+	}
+
+	sums := make([]string, 0, len(files))
+	for i := range files {
+		sums = append(sums, files[i].Checksums[0].Value)
+	}
+	sort.Strings(sums)
+	h := sha1.New()
+	io.WriteString(h, strings.Join(sums, ""))
+	code.Value = hex.EncodeToString(h.Sum(nil))
+
+	return files, code, nil
+}
+
+// excluded reports whether a path relative to the package directory matches
+// one of the patterns
+func excluded(rel string, patterns []string) bool {
+	for _, pattern := range patterns {
+		target := path.Base(rel)
+		if strings.Contains(pattern, "/") {
+			target = rel
+		}
+		if ok, _ := path.Match(pattern, target); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// analyzeFile hashes the file in a single read, keeping its head for the
+// license detection
+func analyzeFile(p, rel string, licenses bool) (File, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return File{}, err
+	}
+	defer f.Close()
+
+	h1, h256 := sha1.New(), sha256.New()
+	head := &headWriter{max: licenseHead}
+	w := io.MultiWriter(h1, h256)
+	if licenses {
+		w = io.MultiWriter(h1, h256, head)
+	}
+	if _, err := io.Copy(w, f); err != nil {
+		return File{}, err
+	}
+
+	file := File{
+		Path: rel,
+		Checksums: []Checksum{
+			{Algorithm: HashAlgoSHA1, Value: hex.EncodeToString(h1.Sum(nil))},
+			{Algorithm: HashAlgoSHA256, Value: hex.EncodeToString(h256.Sum(nil))},
+		},
+	}
+	if licenses {
+		file.Licenses = license.FileLicenses(rel, head.buf)
+	}
+
+	return file, nil
+}
+
+// headWriter keeps the first max bytes written to it
+type headWriter struct {
+	buf []byte
+	max int
+}
+
+func (h *headWriter) Write(p []byte) (int, error) {
+	if n := h.max - len(h.buf); n > 0 {
+		if len(p) < n {
+			n = len(p)
+		}
+		h.buf = append(h.buf, p[:n]...)
+	}
+	return len(p), nil
+}
@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package meta
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const mitLicense = `MIT License
+
+Copyright (c) 2023 Example
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+`
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0o600))
+	}
+
+	return dir
+}
+
+func TestListFiles(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"main.go":                   "// SPDX-License-Identifier: Apache-2.0 OR MIT\npackage main\n",
+		"LICENSE":                   mitLicense,
+		"lib/util.js":               "/* SPDX-License-Identifier: BSD-3-Clause */\n",
+		"lib/package.spdx":          "",
+		"node_modules/dep/index.js": "",
+		".git/HEAD":                 "ref: refs/heads/main\n",
+	})
+
+	files, code, err := ListFiles(dir, FileOptions{Exclude: append([]string{"*.spdx"}, DefaultFileExclude...), Licenses: true})
+	require.NoError(t, err)
+
+	paths := []string{}
+	for _, f := range files {
+		paths = append(paths, f.Path)
+		require.Len(t, f.Checksums, 2)
+		assert.Equal(t, HashAlgoSHA1, f.Checksums[0].Algorithm)
+		assert.Equal(t, HashAlgoSHA256, f.Checksums[1].Algorithm)
+	}
+	assert.Equal(t, []string{"LICENSE", "lib/util.js", "main.go"}, paths)
+	assert.Equal(t, []string{"MIT"}, files[0].Licenses)
+	assert.Equal(t, []string{"BSD-3-Clause"}, files[1].Licenses)
+	assert.Equal(t, []string{"Apache-2.0", "MIT"}, files[2].Licenses)
+	assert.Equal(t, "ae565f6b6bab9e58273b21f627ada351c61544cd", files[2].Checksums[0].Value)
+
+	assert.Equal(t, []string{"lib/package.spdx"}, code.ExcludedFiles)
+	assert.Equal(t, "f3ebd3b2fa60796c1b76572cc51f276e246ef5ed", code.Value)
+}
+
+func TestAnalyzeFiles(t *testing.T) {
+	p := Package{Name: "remote"}
+	require.NoError(t, p.AnalyzeFiles(FileOptions{}))
+	assert.Nil(t, p.VerificationCode)
+
+	// the verification code of no file is the SHA1 of the empty string
+	p.LocalPath = writeFiles(t, map[string]string{"node_modules/dep/index.js": ""})
+	require.NoError(t, p.AnalyzeFiles(FileOptions{}))
+	assert.Empty(t, p.Files)
+	assert.Equal(t, &VerificationCode{Value: "da39a3ee5e6b4b0d3255bfef95601890afd80709"}, p.VerificationCode)
+
+	p.LocalPath = filepath.Join(p.LocalPath, "missing")
+	assert.Error(t, p.AnalyzeFiles(FileOptions{}))
+}
@@ -0,0 +1,429 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package meta
+
+import (
+	"fmt"
+	"sort"
+)
+
+// NodeID identifies a package in a Graph
+type NodeID struct {
+	Ecosystem string
+	Name      string
+	Version   string
+}
+
+func (id NodeID) String() string {
+	return id.Ecosystem + ":" + id.Name + "@" + id.Version
+}
+
+// Edge is a dependency between two nodes of a Graph, Scope tells how From
+// uses To
+type Edge struct {
+	From  NodeID
+	To    NodeID
+	Scope Scope
+}
+
+// Graph holds every package of a project once, keyed by ecosystem, name and
+// version, along with the dependencies between them. Nodes and edges keep the
+// order in which they were added. A Graph is not safe for concurrent use.
+type Graph struct {
+	ecosystem string
+	nodes     map[NodeID]*Package
+	order     []NodeID
+	edges     map[NodeID][]Edge
+}
+
+// NewGraph returns an empty graph for the given ecosystem
+func NewGraph(ecosystem string) *Graph {
+	return &Graph{
+		ecosystem: ecosystem,
+		nodes:     map[NodeID]*Package{},
+		edges:     map[NodeID][]Edge{},
+	}
+}
+
+// NewGraphFromPackages builds a graph out of the packages returned by a
+// plugin. Nested Packages maps become edges; an edge pointing to a version
+// missing from the list is matched by name when the list has a single
+// version of that package, as edges often carry the requested range instead
+// of the resolved version.
+func NewGraphFromPackages(ecosystem string, pkgs []Package) *Graph {
+	g := NewGraph(ecosystem)
+	byName := map[string]NodeID{}
+	ambiguous := map[string]bool{}
+	for i := range pkgs {
+		id := g.AddNode(pkgs[i])
+		if prev, ok := byName[id.Name]; ok && prev != id {
+			ambiguous[id.Name] = true
+		}
+		byName[id.Name] = id
+	}
+
+	// top level packages go first, their edges are the most complete ones
+	type step struct {
+		id  NodeID
+		pkg *Package
+	}
+	queue := make([]step, 0, len(pkgs))
+	for i := range pkgs {
+		queue = append(queue, step{id: g.ID(&pkgs[i]), pkg: &pkgs[i]})
+	}
+	visited := map[NodeID]bool{}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if visited[current.id] {
+			continue
+		}
+		visited[current.id] = true
+
+		for _, name := range sortedKeys(current.pkg.Packages) {
+			child := current.pkg.Packages[name]
+			if child == nil {
+				continue
+			}
+
+			to := g.ID(child)
+			if _, ok := g.nodes[to]; !ok {
+				if id, ok := byName[child.Name]; ok && !ambiguous[child.Name] {
+					to = id
+				} else {
+					// the edge scope does not apply to the node
+					node := *child
+					node.Scope = ""
+					to = g.AddNode(node)
+				}
+			}
+
+			scope := child.Scope
+			if scope == "" {
+				scope = ScopeRuntime
+			}
+			g.addEdge(current.id, to, scope)
+			queue = append(queue, step{id: to, pkg: child})
+		}
+	}
+
+	return g
+}
+
+// ID returns the identifier the package has in the graph
+func (g *Graph) ID(p *Package) NodeID {
+	return NodeID{Ecosystem: g.ecosystem, Name: p.Name, Version: p.Version}
+}
+
+// AddNode adds the package to the graph and returns its identifier. Adding
+// a package that is already known fills in the fields the node is missing.
+// The Packages map of p is ignored, dependencies are added with AddEdge.
+func (g *Graph) AddNode(p Package) NodeID {
+	id := g.ID(&p)
+	if node, ok := g.nodes[id]; ok {
+		merge(node, &p)
+		return id
+	}
+
+	p.Packages = nil
+	g.nodes[id] = &p
+	g.order = append(g.order, id)
+	return id
+}
+
+// AddEdge records that from depends on to with the given scope. When the
+// edge already exists a runtime scope replaces a non runtime one.
+func (g *Graph) AddEdge(from, to NodeID, scope Scope) error {
+	for _, id := range []NodeID{from, to} {
+		if _, ok := g.nodes[id]; !ok {
+			return fmt.Errorf("unknown node %s", id)
+		}
+	}
+
+	g.addEdge(from, to, scope)
+	return nil
+}
+
+func (g *Graph) addEdge(from, to NodeID, scope Scope) {
+	for i := range g.edges[from] {
+		if g.edges[from][i].To != to {
+			continue
+		}
+		if scope.IsRuntime() && !g.edges[from][i].Scope.IsRuntime() {
+			g.edges[from][i].Scope = scope
+		}
+		return
+	}
+
+	g.edges[from] = append(g.edges[from], Edge{From: from, To: to, Scope: scope})
+}
+
+// Node returns the package stored for the identifier
+func (g *Graph) Node(id NodeID) (*Package, bool) {
+	p, ok := g.nodes[id]
+	return p, ok
+}
+
+// Len returns the number of nodes in the graph
+func (g *Graph) Len() int {
+	return len(g.order)
+}
+
+// Nodes returns the identifiers of all nodes
+func (g *Graph) Nodes() []NodeID {
+	return append([]NodeID{}, g.order...)
+}
+
+// Roots returns the identifiers of the root packages
+func (g *Graph) Roots() []NodeID {
+	roots := []NodeID{}
+	for _, id := range g.order {
+		if g.nodes[id].Root {
+			roots = append(roots, id)
+		}
+	}
+
+	return roots
+}
+
+// Edges returns the dependencies of the node
+func (g *Graph) Edges(id NodeID) []Edge {
+	return append([]Edge{}, g.edges[id]...)
+}
+
+// Dependencies returns the identifiers of the packages the node depends on
+func (g *Graph) Dependencies(id NodeID) []NodeID {
+	deps := make([]NodeID, 0, len(g.edges[id]))
+	for _, e := range g.edges[id] {
+		deps = append(deps, e.To)
+	}
+
+	return deps
+}
+
+// Dependents returns the identifiers of the packages depending on the node
+func (g *Graph) Dependents(id NodeID) []NodeID {
+	dependents := []NodeID{}
+	for _, from := range g.order {
+		for _, e := range g.edges[from] {
+			if e.To == id {
+				dependents = append(dependents, from)
+				break
+			}
+		}
+	}
+
+	return dependents
+}
+
+// Walk visits the nodes reachable from the roots breadth first, each node
+// once along with its distance from the closest root. Graphs without roots
+// are walked from the nodes nothing depends on. Walk stops at the first
+// error returned by fn.
+func (g *Graph) Walk(fn func(id NodeID, depth int) error) error {
+	start := g.Roots()
+	if len(start) == 0 {
+		for _, id := range g.order {
+			if len(g.Dependents(id)) == 0 {
+				start = append(start, id)
+			}
+		}
+	}
+
+	type step struct {
+		id    NodeID
+		depth int
+	}
+	queue := make([]step, 0, len(start))
+	seen := map[NodeID]bool{}
+	for _, id := range start {
+		queue = append(queue, step{id: id})
+		seen[id] = true
+	}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if err := fn(current.id, current.depth); err != nil {
+			return err
+		}
+
+		for _, e := range g.edges[current.id] {
+			if !seen[e.To] {
+				seen[e.To] = true
+				queue = append(queue, step{id: e.To, depth: current.depth + 1})
+			}
+		}
+	}
+
+	return nil
+}
+
+// Cycles returns the dependency cycles of the graph. Each cycle lists the
+// nodes involved in the order they were added to the graph.
+func (g *Graph) Cycles() [][]NodeID {
+	// Tarjan's strongly connected components
+	index := map[NodeID]int{}
+	low := map[NodeID]int{}
+	onStack := map[NodeID]bool{}
+	stack := []NodeID{}
+	components := [][]NodeID{}
+
+	var connect func(id NodeID)
+	connect = func(id NodeID) {
+		index[id] = len(index)
+		low[id] = index[id]
+		stack = append(stack, id)
+		onStack[id] = true
+
+		for _, e := range g.edges[id] {
+			if _, ok := index[e.To]; !ok {
+				connect(e.To)
+				if low[e.To] < low[id] {
+					low[id] = low[e.To]
+				}
+			} else if onStack[e.To] && index[e.To] < low[id] {
+				low[id] = index[e.To]
+			}
+		}
+
+		if low[id] != index[id] {
+			return
+		}
+
+		component := []NodeID{}
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == id {
+				break
+			}
+		}
+		if len(component) > 1 || g.dependsOn(id, id) {
+			components = append(components, component)
+		}
+	}
+
+	for _, id := range g.order {
+		if _, ok := index[id]; !ok {
+			connect(id)
+		}
+	}
+
+	position := make(map[NodeID]int, len(g.order))
+	for i, id := range g.order {
+		position[id] = i
+	}
+	for _, component := range components {
+		sort.Slice(component, func(i, j int) bool {
+			return position[component[i]] < position[component[j]]
+		})
+	}
+	sort.Slice(components, func(i, j int) bool {
+		return position[components[i][0]] < position[components[j][0]]
+	})
+
+	return components
+}
+
+// HasCycle reports whether the graph has at least one dependency cycle
+func (g *Graph) HasCycle() bool {
+	return len(g.Cycles()) > 0
+}
+
+func (g *Graph) dependsOn(from, to NodeID) bool {
+	for _, e := range g.edges[from] {
+		if e.To == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Packages returns the graph as the list of packages plugins return. Every
+// node is listed once with its direct dependencies, the edges being copies
+// of the packages they point to without dependencies of their own, so the
+// result has no cycle even when the graph has. Edges carry the edge scope,
+// the map key is the dependency name, or name@version when a package
+// depends on several versions of the same name.
+func (g *Graph) Packages() []Package {
+	views := make(map[NodeID]*Package, len(g.order))
+	for _, id := range g.order {
+		view := *g.nodes[id]
+		view.Packages = map[string]*Package{}
+		views[id] = &view
+	}
+
+	for _, id := range g.order {
+		for _, e := range g.edges[id] {
+			dep := *views[e.To]
+			dep.Packages = nil
+			dep.Scope = e.Scope
+
+			name := dep.Name
+			if _, taken := views[id].Packages[name]; taken {
+				name = dep.Name + "@" + dep.Version
+			}
+			views[id].Packages[name] = &dep
+		}
+	}
+
+	pkgs := make([]Package, 0, len(g.order))
+	for _, id := range g.order {
+		pkgs = append(pkgs, *views[id])
+	}
+
+	return pkgs
+}
+
+// merge fills the empty fields of dst with the ones of src
+func merge(dst, src *Package) {
+	fill := func(d *string, s string) {
+		if *d == "" {
+			*d = s
+		}
+	}
+	fill(&dst.Path, src.Path)
+	fill(&dst.LocalPath, src.LocalPath)
+	fill(&dst.PackageURL, src.PackageURL)
+	fill(&dst.PackageHomePage, src.PackageHomePage)
+	fill(&dst.PackageDownloadLocation, src.PackageDownloadLocation)
+	fill(&dst.LicenseConcluded, src.LicenseConcluded)
+	fill(&dst.LicenseDeclared, src.LicenseDeclared)
+	fill(&dst.CommentsLicense, src.CommentsLicense)
+	fill(&dst.Copyright, src.Copyright)
+	fill(&dst.PackageComment, src.PackageComment)
+
+	if dst.Supplier.Name == "" {
+		dst.Supplier = src.Supplier
+	}
+	if dst.Checksum.Value == "" && dst.Checksum.Content == nil {
+		dst.Checksum = src.Checksum
+	}
+	for _, c := range src.Checksums {
+		dst.AddChecksum(c)
+	}
+	if len(dst.OtherLicense) == 0 {
+		dst.OtherLicense = src.OtherLicense
+	}
+	if dst.VerificationCode == nil {
+		dst.Files, dst.VerificationCode = src.Files, src.VerificationCode
+	}
+	if dst.Scope == "" || src.Scope == ScopeRuntime {
+		dst.Scope = src.Scope
+	}
+	dst.Root = dst.Root || src.Root
+}
+
+func sortedKeys(m map[string]*Package) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
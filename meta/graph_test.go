@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package meta
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGraphFromPackages(t *testing.T) {
+	g := NewGraphFromPackages("npm", scopedPackages())
+
+	// the "^1.0.0" edge resolves to the listed shared@1.0.0
+	require.Equal(t, 6, g.Len())
+
+	root := NodeID{Ecosystem: "npm", Name: "root"}
+	lib := NodeID{Ecosystem: "npm", Name: "lib", Version: "1.0.0"}
+	lint := NodeID{Ecosystem: "npm", Name: "lint", Version: "2.0.0"}
+	shared := NodeID{Ecosystem: "npm", Name: "shared", Version: "1.0.0"}
+	parser := NodeID{Ecosystem: "npm", Name: "parser", Version: "3.0.0"}
+
+	assert.Equal(t, []NodeID{root}, g.Roots())
+	assert.Equal(t, []Edge{
+		{From: root, To: lib, Scope: ScopeRuntime},
+		{From: root, To: lint, Scope: ScopeDevelopment},
+	}, g.Edges(root))
+	assert.Equal(t, []NodeID{parser, shared}, g.Dependencies(lint))
+	assert.Equal(t, []NodeID{lib, lint}, g.Dependents(shared))
+	assert.False(t, g.HasCycle())
+
+	// edge scopes stay on the edges
+	node, ok := g.Node(lint)
+	require.True(t, ok)
+	assert.Equal(t, Scope(""), node.Scope)
+}
+
+func TestGraphAddNode(t *testing.T) {
+	g := NewGraph("golang")
+	id := g.AddNode(Package{Name: "a", Version: "1.0.0", PackageURL: "pkg:golang/a@1.0.0"})
+	same := g.AddNode(Package{Name: "a", Version: "1.0.0", LicenseDeclared: "MIT", PackageURL: "stale"})
+
+	assert.Equal(t, id, same)
+	assert.Equal(t, 1, g.Len())
+	node, _ := g.Node(id)
+	assert.Equal(t, "pkg:golang/a@1.0.0", node.PackageURL)
+	assert.Equal(t, "MIT", node.LicenseDeclared)
+
+	require.Error(t, g.AddEdge(id, NodeID{Name: "missing"}, ScopeRuntime))
+}
+
+func TestGraphAddEdge(t *testing.T) {
+	g := NewGraph("cargo")
+	a := g.AddNode(Package{Name: "a", Root: true})
+	b := g.AddNode(Package{Name: "b"})
+
+	require.NoError(t, g.AddEdge(a, b, ScopeDevelopment))
+	require.NoError(t, g.AddEdge(a, b, ScopeRuntime))
+	require.NoError(t, g.AddEdge(a, b, ScopeBuild))
+
+	assert.Equal(t, []Edge{{From: a, To: b, Scope: ScopeRuntime}}, g.Edges(a))
+}
+
+func TestGraphCycles(t *testing.T) {
+	g := NewGraph("maven")
+	root := g.AddNode(Package{Name: "root", Root: true})
+	a := g.AddNode(Package{Name: "a"})
+	b := g.AddNode(Package{Name: "b"})
+	c := g.AddNode(Package{Name: "c"})
+	self := g.AddNode(Package{Name: "self"})
+
+	require.NoError(t, g.AddEdge(root, a, ScopeRuntime))
+	require.NoError(t, g.AddEdge(a, b, ScopeRuntime))
+	require.NoError(t, g.AddEdge(b, c, ScopeRuntime))
+	require.NoError(t, g.AddEdge(c, a, ScopeRuntime))
+	require.NoError(t, g.AddEdge(root, self, ScopeRuntime))
+	require.NoError(t, g.AddEdge(self, self, ScopeRuntime))
+
+	assert.True(t, g.HasCycle())
+	assert.Equal(t, [][]NodeID{{a, b, c}, {self}}, g.Cycles())
+
+	depths := map[NodeID]int{}
+	require.NoError(t, g.Walk(func(id NodeID, depth int) error {
+		depths[id] = depth
+		return nil
+	}))
+	assert.Equal(t, map[NodeID]int{root: 0, a: 1, self: 1, b: 2, c: 3}, depths)
+
+	stop := errors.New("stop")
+	assert.Equal(t, stop, g.Walk(func(NodeID, int) error { return stop }))
+}
+
+func TestGraphPackages(t *testing.T) {
+	g := NewGraph("pypi")
+	root := g.AddNode(Package{Name: "root", Root: true})
+	a := g.AddNode(Package{Name: "a", Version: "1.0.0", Scope: ScopeRuntime})
+	b := g.AddNode(Package{Name: "b", Version: "2.0.0", Scope: ScopeTest})
+	require.NoError(t, g.AddEdge(root, a, ScopeRuntime))
+	require.NoError(t, g.AddEdge(root, b, ScopeTest))
+	require.NoError(t, g.AddEdge(a, b, ScopeRuntime))
+	require.NoError(t, g.AddEdge(b, a, ScopeRuntime))
+
+	pkgs := g.Packages()
+	require.Len(t, pkgs, 3)
+	assert.Equal(t, []string{"root", "a", "b"}, []string{pkgs[0].Name, pkgs[1].Name, pkgs[2].Name})
+
+	// edges carry their own scope and stop at one level, the dependencies
+	// of b are the ones of the top level b
+	assert.Equal(t, ScopeTest, pkgs[0].Packages["b"].Scope)
+	assert.Equal(t, ScopeTest, pkgs[2].Scope)
+	assert.Nil(t, pkgs[0].Packages["b"].Packages)
+	assert.Equal(t, "a", pkgs[2].Packages["a"].Name)
+
+	// a and b depend on each other, the view has no cycle
+	_, err := json.Marshal(pkgs)
+	require.NoError(t, err)
+
+	// the view round trips
+	again := NewGraphFromPackages("pypi", pkgs)
+	assert.Equal(t, g.Nodes(), again.Nodes())
+	for _, id := range g.Nodes() {
+		assert.Equal(t, g.Edges(id), again.Edges(id))
+	}
+}
@@ -3,12 +3,7 @@
 package meta
 
 import (
-	"crypto/sha1"
-	"crypto/sha256"
-	"crypto/sha512"
-	"encoding/hex"
 	"fmt"
-	"hash"
 	"strings"
 
 	"github.com/opensbom-generator/parsers/internal/license"
@@ -16,23 +11,35 @@ import (
 
 // Package is the package abstraction that the parsers return
 type Package struct {
-	Version                 string `json:"version,omitempty"`
-	Name                    string `json:"name"`
-	Path                    string `json:"path,omitempty"`
-	LocalPath               string `json:"dir"`
-	Supplier                Supplier
-	PackageURL              string `json:"purl"`
-	Checksum                Checksum
-	PackageHomePage         string `json:"homePage"`
-	PackageDownloadLocation string `json:"downloadLocation"`
-	LicenseConcluded        string `json:"licenseConcluded"`
-	LicenseDeclared         string `json:"licenseDeclared"`
-	CommentsLicense         string `json:"licenseComments"`
+	Version    string `json:"version,omitempty"`
+	Name       string `json:"name"`
+	Path       string `json:"path,omitempty"`
+	LocalPath  string `json:"dir"`
+	Supplier   Supplier
+	PackageURL string `json:"purl"`
+	// Checksum is the first of Checksums.
+	//
+	// Deprecated: use Checksums, which lists every digest of the package,
+	// and AddChecksum.
+	Checksum Checksum
+	// Checksums are the digests of the package artifact, followed by the
+	// synthetic checksums of packages without a known digest
+	Checksums               []Checksum `json:"checksums,omitempty"`
+	PackageHomePage         string     `json:"homePage"`
+	PackageDownloadLocation string     `json:"downloadLocation"`
+	LicenseConcluded        string     `json:"licenseConcluded"`
+	LicenseDeclared         string     `json:"licenseDeclared"`
+	CommentsLicense         string     `json:"licenseComments"`
 	OtherLicense            []license.License
 	Copyright               string `json:"copyright"`
 	PackageComment          string `json:"comment"`
 	Root                    bool
+	Scope                   Scope `json:"scope,omitempty"`
 	Packages                map[string]*Package
+	// Files are the files of LocalPath, listed by AnalyzeFiles
+	Files []File `json:"files,omitempty"`
+	// VerificationCode is set by AnalyzeFiles
+	VerificationCode *VerificationCode `json:"verificationCode,omitempty"`
 }
 
 // TypeContact ...
@@ -79,45 +86,3 @@ func (s *Supplier) Get() string {
 
 	return pkgSupplier
 }
-
-type Checksum struct {
-	Algorithm HashAlgorithm
-	Content   []byte
-	Value     string
-}
-
-func (c *Checksum) String() string {
-	if c.Value == "" {
-		c.Value = c.Compute(c.Content)
-	}
-	return c.Value
-}
-
-func (c *Checksum) Compute(content []byte) string {
-	var h hash.Hash
-	switch c.Algorithm {
-	case HashAlgoSHA256:
-		h = sha256.New()
-	case HashAlgoSHA512:
-		h = sha512.New()
-	default:
-		h = sha1.New()
-	}
-	h.Write(content)
-	return hex.EncodeToString(h.Sum(nil))
-}
-
-// HashAlgorithm ...
-type HashAlgorithm string
-
-const (
-	HashAlgoSHA1   HashAlgorithm = "SHA1"
-	HashAlgoSHA224 HashAlgorithm = "SHA224"
-	HashAlgoSHA256 HashAlgorithm = "SHA256"
-	HashAlgoSHA384 HashAlgorithm = "SHA384"
-	HashAlgoSHA512 HashAlgorithm = "SHA512"
-	HashAlgoMD2    HashAlgorithm = "MD2"
-	HashAlgoMD4    HashAlgorithm = "MD4"
-	HashAlgoMD5    HashAlgorithm = "MD5"
-	HashAlgoMD6    HashAlgorithm = "MD6"
-)
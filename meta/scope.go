@@ -0,0 +1,146 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package meta
+
+// Scope tells how a package is used by the package depending on it. In a
+// Packages map it is the scope of that edge, on the packages returned by a
+// plugin it is the scope through which the package is reached from the root.
+type Scope string
+
+const (
+	ScopeRuntime     Scope = "runtime"
+	ScopeDevelopment Scope = "dev"
+	ScopeTest        Scope = "test"
+	ScopeBuild       Scope = "build"
+	ScopeOptional    Scope = "optional"
+	ScopePeer        Scope = "peer"
+)
+
+// IsRuntime reports whether the scope is needed at runtime. Packages without
+// a scope are considered runtime dependencies.
+func (s Scope) IsRuntime() bool {
+	return s == "" || s == ScopeRuntime
+}
+
+// ResolveScopes sets the scope of every top level package from the edges
+// that lead to it from the root packages. A package reachable through
+// runtime edges only is a runtime dependency, otherwise it gets the scope of
+// the first non runtime edge on its path, e.g. the dependencies of a
+// development dependency are development dependencies as well. Edges are
+// matched to top level packages by name and version, or by name alone when
+// only one version is listed. Packages that cannot be reached from a root
+// keep their scope.
+func ResolveScopes(pkgs []Package) {
+	byKey := map[string]int{}
+	byName := map[string]int{}
+	for i := range pkgs {
+		if _, ok := byKey[Key(&pkgs[i])]; !ok {
+			byKey[Key(&pkgs[i])] = i
+		}
+		if j, ok := byName[pkgs[i].Name]; ok && pkgs[j].Version != pkgs[i].Version {
+			byName[pkgs[i].Name] = -1
+		} else if !ok {
+			byName[pkgs[i].Name] = i
+		}
+	}
+
+	// lookup returns the top level package matching an edge
+	lookup := func(p *Package) (*Package, string) {
+		if i, ok := byKey[Key(p)]; ok {
+			return &pkgs[i], Key(&pkgs[i])
+		}
+		if i, ok := byName[p.Name]; ok && i >= 0 {
+			return &pkgs[i], Key(&pkgs[i])
+		}
+		return p, Key(p)
+	}
+
+	type step struct {
+		pkg   *Package
+		scope Scope
+	}
+	resolved := map[string]Scope{}
+	queue := []step{}
+	for i := range pkgs {
+		if pkgs[i].Root {
+			queue = append(queue, step{pkg: &pkgs[i], scope: ScopeRuntime})
+		}
+	}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, child := range current.pkg.Dependencies() {
+			scope := current.scope
+			if scope.IsRuntime() {
+				scope = child.Scope
+				if scope == "" {
+					scope = ScopeRuntime
+				}
+			}
+
+			// prefer the top level package, edges are often partial copies
+			next, k := lookup(child)
+			if next.Packages == nil {
+				next = child
+			}
+			if prev, ok := resolved[k]; ok && (prev.IsRuntime() || !scope.IsRuntime()) {
+				continue
+			}
+			resolved[k] = scope
+			queue = append(queue, step{pkg: next, scope: scope})
+		}
+	}
+
+	for i := range pkgs {
+		if pkgs[i].Root {
+			continue
+		}
+		if scope, ok := resolved[Key(&pkgs[i])]; ok {
+			pkgs[i].Scope = scope
+		}
+	}
+}
+
+// RuntimeOnly returns the packages needed at runtime. Top level packages
+// with a non runtime scope are dropped, as are non runtime edges. The input
+// is left untouched.
+func RuntimeOnly(pkgs []Package) []Package {
+	copies := map[*Package]*Package{}
+	result := make([]Package, 0, len(pkgs))
+	for i := range pkgs {
+		if !pkgs[i].Root && !pkgs[i].Scope.IsRuntime() {
+			continue
+		}
+		p := pkgs[i]
+		p.Packages = runtimeEdges(pkgs[i].Packages, copies)
+		result = append(result, p)
+	}
+
+	return result
+}
+
+func runtimeEdges(edges map[string]*Package, copies map[*Package]*Package) map[string]*Package {
+	if edges == nil {
+		return nil
+	}
+
+	result := make(map[string]*Package, len(edges))
+	for name, child := range edges {
+		if child == nil || !child.Scope.IsRuntime() {
+			continue
+		}
+		if c, ok := copies[child]; ok {
+			result[name] = c
+			continue
+		}
+
+		c := *child
+		copies[child] = &c
+		c.Packages = runtimeEdges(child.Packages, copies)
+		result[name] = &c
+	}
+
+	return result
+}
@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func scopedPackages() []Package {
+	shared := &Package{Name: "shared", Version: "1.0.0", Scope: ScopeRuntime}
+	lint := &Package{Name: "lint", Version: "2.0.0", Scope: ScopeDevelopment}
+	app := &Package{Name: "lib", Version: "1.0.0", Scope: ScopeRuntime}
+
+	return []Package{
+		{
+			Name:     "root",
+			Root:     true,
+			Packages: map[string]*Package{"lib": app, "lint": lint},
+		},
+		{
+			Name:     "lib",
+			Version:  "1.0.0",
+			Packages: map[string]*Package{"shared": shared},
+		},
+		{
+			Name:    "lint",
+			Version: "2.0.0",
+			Packages: map[string]*Package{
+				// range instead of resolved version, matched by name
+				"shared": {Name: "shared", Version: "^1.0.0"},
+				"parser": {Name: "parser", Version: "3.0.0"},
+			},
+		},
+		{Name: "shared", Version: "1.0.0"},
+		{Name: "parser", Version: "3.0.0"},
+		{Name: "orphan", Version: "1.0.0", Scope: ScopeTest},
+	}
+}
+
+func TestResolveScopes(t *testing.T) {
+	pkgs := scopedPackages()
+	ResolveScopes(pkgs)
+
+	scopes := map[string]Scope{}
+	for i := range pkgs {
+		scopes[pkgs[i].Name] = pkgs[i].Scope
+	}
+
+	assert.Equal(t, Scope(""), scopes["root"])
+	assert.Equal(t, ScopeRuntime, scopes["lib"])
+	assert.Equal(t, ScopeRuntime, scopes["shared"], "reachable through a runtime path")
+	assert.Equal(t, ScopeDevelopment, scopes["lint"])
+	assert.Equal(t, ScopeDevelopment, scopes["parser"], "only needed by a development dependency")
+	assert.Equal(t, ScopeTest, scopes["orphan"], "unreachable packages keep their scope")
+}
+
+func TestRuntimeOnly(t *testing.T) {
+	pkgs := scopedPackages()
+	ResolveScopes(pkgs)
+
+	runtime := RuntimeOnly(pkgs)
+	names := []string{}
+	for i := range runtime {
+		names = append(names, runtime[i].Name)
+	}
+
+	assert.Equal(t, []string{"root", "lib", "shared"}, names)
+	require.Len(t, runtime[0].Packages, 1)
+	assert.Contains(t, runtime[0].Packages, "lib")
+	assert.Len(t, pkgs[0].Packages, 2, "input must not be modified")
+}
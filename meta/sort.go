@@ -0,0 +1,41 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package meta
+
+import "sort"
+
+// Sort puts the packages in canonical order: root packages first, then by
+// name, version and package URL. Plugins sort what they return so that two
+// runs on the same project give the same result.
+func Sort(pkgs []Package) {
+	sort.SliceStable(pkgs, func(i, j int) bool {
+		return less(&pkgs[i], &pkgs[j])
+	})
+}
+
+// Dependencies returns the dependencies of the package ordered by their
+// key in Packages
+func (p *Package) Dependencies() []*Package {
+	deps := make([]*Package, 0, len(p.Packages))
+	for _, name := range sortedKeys(p.Packages) {
+		if p.Packages[name] != nil {
+			deps = append(deps, p.Packages[name])
+		}
+	}
+
+	return deps
+}
+
+func less(a, b *Package) bool {
+	if a.Root != b.Root {
+		return a.Root
+	}
+	if a.Name != b.Name {
+		return a.Name < b.Name
+	}
+	if a.Version != b.Version {
+		return a.Version < b.Version
+	}
+
+	return a.PackageURL < b.PackageURL
+}
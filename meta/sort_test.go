@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSort(t *testing.T) {
+	pkgs := []Package{
+		{Name: "b", Version: "1.0.0"},
+		{Name: "a", Version: "2.0.0"},
+		{Name: "z", Root: true},
+		{Name: "a", Version: "1.0.0", PackageURL: "pkg:npm/a@1.0.0"},
+		{Name: "a", Version: "1.0.0", PackageURL: "pkg:generic/a@1.0.0"},
+	}
+	Sort(pkgs)
+
+	names := []string{}
+	for i := range pkgs {
+		names = append(names, pkgs[i].PackageURL+" "+Key(&pkgs[i]))
+	}
+	assert.Equal(t, []string{
+		" z@",
+		"pkg:generic/a@1.0.0 a@1.0.0",
+		"pkg:npm/a@1.0.0 a@1.0.0",
+		" a@2.0.0",
+		" b@1.0.0",
+	}, names)
+}
+
+func TestDependencies(t *testing.T) {
+	a, b := &Package{Name: "a"}, &Package{Name: "b"}
+	p := Package{Packages: map[string]*Package{"b": b, "missing": nil, "a": a}}
+
+	assert.Equal(t, []*Package{a, b}, p.Dependencies())
+	assert.Empty(t, (&Package{}).Dependencies())
+}
+
+func TestResolveScopesIsDeterministic(t *testing.T) {
+	// shared is reached through a development and an optional edge, the
+	// edges are walked in the order of their keys
+	for i := 0; i < 20; i++ {
+		shared := &Package{Name: "shared", Version: "1.0.0"}
+		pkgs := []Package{
+			{Name: "root", Root: true, Packages: map[string]*Package{
+				"a": {Name: "a", Version: "1.0.0", Scope: ScopeDevelopment},
+				"b": {Name: "b", Version: "1.0.0", Scope: ScopeOptional},
+			}},
+			{Name: "a", Version: "1.0.0", Packages: map[string]*Package{"shared": shared}},
+			{Name: "b", Version: "1.0.0", Packages: map[string]*Package{"shared": shared}},
+			{Name: "shared", Version: "1.0.0"},
+		}
+		ResolveScopes(pkgs)
+		assert.Equal(t, ScopeDevelopment, pkgs[3].Scope)
+	}
+}
@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package meta
+
+// Key identifies a package across the dependency tree by its name and
+// version
+func Key(p *Package) string {
+	return p.Name + "@" + p.Version
+}
+
+// WalkDependencies visits the packages and their dependency trees depth
+// first, each key once, and calls fn with every package and its
+// dependencies. A package listed several times is visited as the first one
+// found under its key, the top level packages being the first ones: nested
+// dependencies are often only a name and version.
+func WalkDependencies(pkgs []Package, fn func(p *Package, deps []*Package)) {
+	first := map[string]*Package{}
+	for i := range pkgs {
+		if _, ok := first[Key(&pkgs[i])]; !ok {
+			first[Key(&pkgs[i])] = &pkgs[i]
+		}
+	}
+
+	visited := map[string]bool{}
+	var walk func(p *Package)
+	walk = func(p *Package) {
+		k := Key(p)
+		if visited[k] {
+			return
+		}
+		visited[k] = true
+
+		p = first[k]
+		deps := p.Dependencies()
+		for _, dep := range deps {
+			if _, ok := first[Key(dep)]; !ok {
+				first[Key(dep)] = dep
+			}
+		}
+		fn(p, deps)
+		for _, dep := range deps {
+			walk(dep)
+		}
+	}
+	for i := range pkgs {
+		walk(&pkgs[i])
+	}
+}
@@ -0,0 +1,35 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWalkDependencies(t *testing.T) {
+	// the nested copy of b is partial, the top level one is visited
+	partial := &Package{Name: "b", Version: "1.0.0"}
+	c := &Package{Name: "c", Version: "1.0.0"}
+	pkgs := []Package{
+		{Name: "app", Root: true, Packages: map[string]*Package{"b": partial, "c": c, "missing": nil}},
+		{Name: "b", Version: "1.0.0", Packages: map[string]*Package{"c": c}},
+		{Name: "app", PackageURL: "pkg:generic/app"},
+	}
+
+	visited := []string{}
+	edges := map[string][]string{}
+	WalkDependencies(pkgs, func(p *Package, deps []*Package) {
+		visited = append(visited, Key(p))
+		for _, dep := range deps {
+			edges[Key(p)] = append(edges[Key(p)], Key(dep))
+		}
+	})
+
+	assert.Equal(t, []string{"app@", "b@1.0.0", "c@1.0.0"}, visited)
+	assert.Equal(t, map[string][]string{
+		"app@":    {"b@1.0.0", "c@1.0.0"},
+		"b@1.0.0": {"c@1.0.0"},
+	}, edges)
+}
@@ -3,9 +3,8 @@
 package npm
 
 import (
-	"crypto/sha256"
+	"context"
 	"fmt"
-	"os/exec"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -13,11 +12,13 @@ import (
 	"github.com/opensbom-generator/parsers/internal/helper"
 	"github.com/opensbom-generator/parsers/meta"
 	"github.com/opensbom-generator/parsers/plugin"
+	"github.com/opensbom-generator/parsers/purl"
 	"github.com/opensbom-generator/parsers/reader"
 )
 
 type NPM struct {
 	metadata plugin.Metadata
+	options  plugin.Options
 }
 
 var (
@@ -27,6 +28,10 @@ var (
 	rg       = regexp.MustCompile(`^(((git|hg|svn|bzr)\+)?(http:\/\/www\.|https:\/\/www\.|http:\/\/|https:\/\/|ssh:\/\/|git:\/\/|svn:\/\/|sftp:\/\/|ftp:\/\/)?[a-z0-9]+([\-\.]{1}[a-z0-9]+){0,100}\.[a-z]{2,5}(:[0-9]{1,5})?(\/.*))|(git\+git@[a-zA-Z0-9\.]+:[a-zA-Z0-9/\\.@]+)|(bzr\+lp:[a-zA-Z0-9\.]+)$`)
 )
 
+func init() {
+	plugin.Register("npm", func() plugin.Plugin { return New() })
+}
+
 // New creates a new npm manager instance
 func New() *NPM {
 	return &NPM{
@@ -35,10 +40,22 @@ func New() *NPM {
 			Slug:       "npm",
 			Manifest:   []string{"package.json", lockFile},
 			ModulePath: []string{"node_modules"},
+			ReadOnly:   true,
+			Capabilities: plugin.Capabilities{
+				Executables:      []plugin.Executable{{Name: "npm", Optional: true}},
+				InstalledModules: true,
+				Lockfiles:        []string{lockFile, shrink},
+				TransitiveGraph:  true,
+			},
 		},
 	}
 }
 
+// SetOptions configures how modules are listed
+func (m *NPM) SetOptions(opts plugin.Options) {
+	m.options = opts
+}
+
 // GetMetadata returns metadata descriptions Name, Slug, Manifest, ModulePath
 func (m *NPM) GetMetadata() plugin.Metadata {
 	return m.metadata
@@ -57,6 +74,11 @@ func (m *NPM) IsValid(path string) bool {
 
 // HasModulesInstalled checks if modules of manifest file already installed
 func (m *NPM) HasModulesInstalled(path string) error {
+	return m.HasModulesInstalledContext(context.Background(), path)
+}
+
+// HasModulesInstalledContext checks if modules of manifest file already installed
+func (m *NPM) HasModulesInstalledContext(ctx context.Context, path string) error {
 	for _, p := range m.metadata.ModulePath {
 		if !helper.Exists(filepath.Join(path, p)) {
 			return errDependenciesNotFound
@@ -73,8 +95,13 @@ func (m *NPM) HasModulesInstalled(path string) error {
 
 // GetVersion returns npm version
 func (m *NPM) GetVersion() (string, error) {
-	cmd := exec.Command("npm", "--v")
-	output, err := cmd.Output()
+	return m.GetVersionContext(context.Background())
+}
+
+// GetVersionContext returns npm version
+func (m *NPM) GetVersionContext(ctx context.Context) (string, error) {
+	ctx = m.options.Context(ctx)
+	output, err := helper.Output(ctx, "", "npm", "--v")
 	if err != nil {
 		return "", err
 	}
@@ -88,17 +115,29 @@ func (m *NPM) GetVersion() (string, error) {
 
 // SetRootModule ...
 func (m *NPM) SetRootModule(path string) error {
+	return m.SetRootModuleContext(context.Background(), path)
+}
+
+// SetRootModuleContext ...
+func (m *NPM) SetRootModuleContext(ctx context.Context, path string) error {
+	ctx = m.options.Context(ctx)
 	return nil
 }
 
 // GetRootModule return root package information ex. Name, Version
 func (m *NPM) GetRootModule(path string) (*meta.Package, error) {
+	return m.GetRootModuleContext(context.Background(), path)
+}
+
+// GetRootModuleContext return root package information ex. Name, Version
+func (m *NPM) GetRootModuleContext(ctx context.Context, path string) (*meta.Package, error) {
+	ctx = m.options.Context(ctx)
 	r := reader.New(filepath.Join(path, m.metadata.Manifest[0]))
 	pkResult, err := r.ReadJSON()
 	if err != nil {
 		return nil, err
 	}
-	mod := &meta.Package{}
+	mod := &meta.Package{Root: true}
 
 	splitedPath := strings.Split(path, "/")
 	mod.Name = splitedPath[len(splitedPath)-1]
@@ -121,31 +160,29 @@ func (m *NPM) GetRootModule(path string) (*meta.Package, error) {
 		}
 	}
 	if pkResult["homepage"] != nil {
-		mod.PackageURL = helper.RemoveURLProtocol(pkResult["homepage"].(string))
+		mod.PackageHomePage = helper.RemoveURLProtocol(pkResult["homepage"].(string))
 	}
+	mod.PackageURL = purl.NPM(mod.Name, mod.Version)
 	if !rg.MatchString(mod.PackageDownloadLocation) {
 		mod.PackageDownloadLocation = "NONE"
 	}
 
 	mod.Packages = map[string]*meta.Package{}
 
-	mod.Copyright = getCopyright(path)
-	modLic, err := helper.GetLicenses(path)
-	if err != nil {
-		return mod, nil
-	}
-	mod.LicenseDeclared = helper.BuildLicenseDeclared(modLic.ID)
-	mod.LicenseConcluded = helper.BuildLicenseConcluded(modLic.ID)
-	mod.CommentsLicense = modLic.Comments
-	if !helper.LicenseSPDXExists(modLic.ID) {
-		mod.OtherLicense = append(mod.OtherLicense, *modLic)
-	}
+	mod.Copyright = helper.ScanCopyright(path)
+	helper.SetLicenses(ctx, mod, helper.PackageJSONLicense(pkResult), path)
 
 	return mod, nil
 }
 
 // ListUsedModules return brief info of installed modules, Name and Version
 func (m *NPM) ListUsedModules(path string) ([]meta.Package, error) {
+	return m.ListUsedModulesContext(context.Background(), path)
+}
+
+// ListUsedModulesContext return brief info of installed modules, Name and Version
+func (m *NPM) ListUsedModulesContext(ctx context.Context, path string) ([]meta.Package, error) {
+	ctx = m.options.Context(ctx)
 	r := reader.New(filepath.Join(path, m.metadata.Manifest[0]))
 	pkResult, err := r.ReadJSON()
 	if err != nil {
@@ -161,12 +198,19 @@ func (m *NPM) ListUsedModules(path string) ([]meta.Package, error) {
 		mod.Version = strings.TrimPrefix(v.(string), "^")
 		modules = append(modules, mod)
 	}
+	meta.Sort(modules)
 
 	return modules, nil
 }
 
 // ListModulesWithDeps return all info of installed modules
 func (m *NPM) ListModulesWithDeps(path string, globalSettingFile string) ([]meta.Package, error) {
+	return m.ListModulesWithDepsContext(context.Background(), path, globalSettingFile)
+}
+
+// ListModulesWithDepsContext return all info of installed modules
+func (m *NPM) ListModulesWithDepsContext(ctx context.Context, path string, globalSettingFile string) ([]meta.Package, error) {
+	ctx = m.options.Context(ctx)
 	pk := lockFile
 	if helper.Exists(filepath.Join(path, shrink)) {
 		pk = shrink
@@ -183,20 +227,22 @@ func (m *NPM) ListModulesWithDeps(path string, globalSettingFile string) ([]meta
 		deps = pkResults["dependencies"].(map[string]interface{})
 	}
 
-	return m.buildDependencies(path, deps)
+	modules, err := m.buildDependencies(ctx, path, deps)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.options.Filter(modules), nil
 }
 
-func (m *NPM) buildDependencies(path string, deps map[string]interface{}) ([]meta.Package, error) {
+func (m *NPM) buildDependencies(ctx context.Context, path string, deps map[string]interface{}) ([]meta.Package, error) {
 	modules := make([]meta.Package, 0)
-	de, err := m.GetRootModule(path)
+	de, err := m.GetRootModuleContext(ctx, path)
 	if err != nil {
 		return modules, err
 	}
-	h := fmt.Sprintf("%x", sha256.Sum256([]byte(fmt.Sprintf("%s-%s", de.Name, de.Version))))
-	de.Checksum = meta.Checksum{
-		Algorithm: "SHA256",
-		Value:     h,
-	}
+	// the project itself has no artifact to take a digest of
+	de.AddChecksum(meta.SyntheticChecksum(fmt.Sprintf("%s-%s", de.Name, de.Version)))
 	de.Supplier.Name = de.Name
 	if de.PackageDownloadLocation == "" {
 		de.PackageDownloadLocation = de.Name
@@ -216,6 +262,7 @@ func (m *NPM) buildDependencies(path string, deps map[string]interface{}) ([]met
 			mod.Version = strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(strings.TrimPrefix(strings.TrimPrefix(nkey, "^"), "~"), ">"), "="))
 			mod.Version = strings.Split(mod.Version, " ")[0]
 			mod.Name = depName
+			mod.Scope = lockScope(d)
 
 			r := ""
 			if d["resolved"] != nil {
@@ -228,14 +275,11 @@ func (m *NPM) buildDependencies(path string, deps map[string]interface{}) ([]met
 			}
 			mod.Supplier.Name = mod.Name
 
-			mod.PackageURL = getPackageHomepage(filepath.Join(path, m.metadata.ModulePath[0], key, m.metadata.Manifest[0]))
-			h := fmt.Sprintf("%x", sha256.Sum256([]byte(mod.Name)))
-			mod.Checksum = meta.Checksum{
-				Algorithm: "SHA256",
-				Value:     h,
-			}
+			mod.PackageURL = purl.NPM(packageName(key), mod.Version)
+			mod.PackageHomePage = getPackageHomepage(filepath.Join(path, m.metadata.ModulePath[0], key, m.metadata.Manifest[0]))
+			addChecksums(&mod, d, mod.Name)
 
-			mod.Copyright = getCopyright(filepath.Join(path, m.metadata.ModulePath[0], key))
+			mod.Copyright = helper.ScanCopyright(filepath.Join(path, m.metadata.ModulePath[0], key))
 			mod.Packages = map[string]*meta.Package{}
 			if dd["requires"] != nil {
 				modDeps := dd["requires"].(map[string]interface{})
@@ -253,17 +297,8 @@ func (m *NPM) buildDependencies(path string, deps map[string]interface{}) ([]met
 				}
 			}
 
-			modLic, err := helper.GetLicenses(filepath.Join(path, m.metadata.ModulePath[0], key))
-			if err != nil {
-				modules = append(modules, mod)
-				continue
-			}
-			mod.LicenseDeclared = helper.BuildLicenseDeclared(modLic.ID)
-			mod.LicenseConcluded = helper.BuildLicenseConcluded(modLic.ID)
-			mod.CommentsLicense = modLic.Comments
-			if !helper.LicenseSPDXExists(modLic.ID) {
-				mod.OtherLicense = append(mod.OtherLicense, *modLic)
-			}
+			modPath := filepath.Join(path, m.metadata.ModulePath[0], key)
+			helper.SetLicenses(ctx, &mod, getPackageLicense(filepath.Join(modPath, m.metadata.Manifest[0])), modPath)
 
 			modules = append(modules, mod)
 		}
@@ -272,62 +307,73 @@ func (m *NPM) buildDependencies(path string, deps map[string]interface{}) ([]met
 	return modules, nil
 }
 
-func getCopyright(path string) string {
-	licensePath := getLicenseFile(path)
-	if helper.Exists(licensePath) {
-		r := reader.New(licensePath)
-		s := r.StringFromFile()
-		return helper.GetCopyright(s)
-	}
-
-	return ""
-}
-
-func getLicenseFile(path string) string {
-	licensePath := filepath.Join(path, "LICENSE")
-	if helper.Exists(licensePath) {
-		return licensePath
-	}
-
-	licenseMDPath, err := filepath.Glob(filepath.Join(path, "LICENSE*"))
-	if err != nil {
-		return ""
-	}
-	if len(licenseMDPath) > 0 && helper.Exists(licenseMDPath[0]) {
-		return licenseMDPath[0]
-	}
-
-	licenseMDCaseSensitivePath, err := filepath.Glob(filepath.Join(path, "license*"))
-	if err != nil {
-		return ""
-	}
-	if len(licenseMDCaseSensitivePath) > 0 && helper.Exists(licenseMDCaseSensitivePath[0]) {
-		return licenseMDCaseSensitivePath[0]
-	}
-	return ""
-}
-
 func getPackageDependencies(modDeps map[string]interface{}, t string) map[string]*meta.Package {
 	m := make(map[string]*meta.Package)
 	for k, v := range modDeps {
 		name := strings.TrimPrefix(k, "@")
 		version := ""
+		scope := meta.ScopeRuntime
+		entry := map[string]interface{}{}
 		if t == "dependencies" {
-			version = strings.TrimPrefix(v.(map[string]interface{})["version"].(string), "^")
+			entry = v.(map[string]interface{})
+			version = strings.TrimPrefix(entry["version"].(string), "^")
+			scope = lockScope(entry)
 		}
 		if t == "requires" {
 			version = strings.TrimPrefix(v.(string), "^")
 		}
 		m[k] = &meta.Package{
-			Name:     name,
-			Version:  version,
-			Checksum: meta.Checksum{Content: []byte(fmt.Sprintf("%s-%s", name, version))},
+			Name:       name,
+			Version:    version,
+			Scope:      scope,
+			PackageURL: purl.NPM(packageName(k), version),
 		}
+		addChecksums(m[k], entry, fmt.Sprintf("%s-%s", name, version))
 	}
 
 	return m
 }
 
+// addChecksums adds the digests of the integrity field of a lock file
+// entry to pkg, or a synthetic checksum of fallback when it has none
+func addChecksums(pkg *meta.Package, entry map[string]interface{}, fallback string) {
+	if integrity, ok := entry["integrity"].(string); ok {
+		checksums, err := meta.ParseSRI(integrity)
+		if err == nil {
+			for _, c := range checksums {
+				pkg.AddChecksum(c)
+			}
+		}
+	}
+	if len(pkg.Checksums) == 0 {
+		pkg.AddChecksum(meta.SyntheticChecksum(fallback))
+	}
+}
+
+// lockScope returns the scope npm recorded for a lock file entry
+func lockScope(entry map[string]interface{}) meta.Scope {
+	switch {
+	case entry["dev"] == true, entry["devOptional"] == true:
+		return meta.ScopeDevelopment
+	case entry["peer"] == true:
+		return meta.ScopePeer
+	case entry["optional"] == true:
+		return meta.ScopeOptional
+	}
+
+	return meta.ScopeRuntime
+}
+
+// packageName returns the package name of a lock file key, which is either
+// the name itself or its path under node_modules
+func packageName(key string) string {
+	if i := strings.LastIndex(key, "node_modules/"); i >= 0 {
+		return key[i+len("node_modules/"):]
+	}
+
+	return key
+}
+
 func getPackageHomepage(path string) string {
 	r := reader.New(path)
 	pkResult, err := r.ReadJSON()
@@ -341,6 +387,16 @@ func getPackageHomepage(path string) string {
 	return ""
 }
 
+func getPackageLicense(path string) string {
+	r := reader.New(path)
+	pkResult, err := r.ReadJSON()
+	if err != nil {
+		return ""
+	}
+
+	return helper.PackageJSONLicense(pkResult)
+}
+
 func appendNestedDependencies(deps map[string]interface{}) map[string]map[string]interface{} {
 	allDeps := make(map[string]map[string]interface{})
 	for k, v := range deps {
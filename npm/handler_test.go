@@ -3,13 +3,13 @@
 package npm
 
 import (
-	"crypto/sha256"
 	"fmt"
 	"os/exec"
 	"strings"
 	"testing"
 
 	"github.com/opensbom-generator/parsers/meta"
+	"github.com/opensbom-generator/parsers/plugin"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -105,47 +105,40 @@ func TestListAllModules(t *testing.T) {
 	count := 0
 	for _, mod := range mods {
 		if mod.Name == "validator" {
-			h := fmt.Sprintf("%x", sha256.Sum256([]byte(mod.Name)))
-
 			assert.Equal(t, "10.11.0", mod.Version)
 			assert.Equal(t, "https://registry.npmjs.org/validator/-/validator-10.11.0.tgz", mod.PackageDownloadLocation)
-			assert.Equal(t, meta.HashAlgorithm("SHA256"), mod.Checksum.Algorithm)
-			assert.Equal(t, h, mod.Checksum.Value)
+			assert.Equal(t, meta.HashAlgoSHA512, mod.Checksum.Algorithm)
+			assert.False(t, mod.Checksum.Synthetic)
 			assert.Equal(t, "Copyright (c) 2018 Chris O'Hara <cohara87@gmail.com>", mod.Copyright)
 			assert.Equal(t, "MIT", mod.LicenseDeclared)
 			count++
 			continue
 		}
 		if mod.Name == "shortid" {
-			h := fmt.Sprintf("%x", sha256.Sum256([]byte(mod.Name)))
-
 			assert.Equal(t, "2.2.16", mod.Version)
 			assert.Equal(t, "https://registry.npmjs.org/shortid/-/shortid-2.2.16.tgz", mod.PackageDownloadLocation)
-			assert.Equal(t, meta.HashAlgorithm("SHA256"), mod.Checksum.Algorithm)
-			assert.Equal(t, h, mod.Checksum.Value)
+			assert.Equal(t, meta.HashAlgoSHA512, mod.Checksum.Algorithm)
+			assert.False(t, mod.Checksum.Synthetic)
 			assert.Equal(t, "Copyright (c) Dylan Greene", mod.Copyright)
 			assert.Equal(t, "MITNFA", mod.LicenseDeclared)
 			count++
 			continue
 		}
 		if mod.Name == "body-parser" {
-			h := fmt.Sprintf("%x", sha256.Sum256([]byte(mod.Name)))
-
 			assert.Equal(t, "1.20.2", mod.Version)
 			assert.Equal(t, "https://registry.npmjs.org/body-parser/-/body-parser-1.20.2.tgz", mod.PackageDownloadLocation)
-			assert.Equal(t, meta.HashAlgorithm("SHA256"), mod.Checksum.Algorithm)
-			assert.Equal(t, h, mod.Checksum.Value)
+			assert.Equal(t, meta.HashAlgoSHA512, mod.Checksum.Algorithm)
+			assert.False(t, mod.Checksum.Synthetic)
 			assert.Equal(t, "Copyright (c) 2014 Jonathan Ong <me@jongleberry.com>", mod.Copyright)
 			assert.Equal(t, "MIT", mod.LicenseDeclared)
 			count++
 			continue
 		}
 		if mod.Name == "bcryptjs" {
-			h := fmt.Sprintf("%x", sha256.Sum256([]byte(mod.Name)))
 			assert.Equal(t, "2.4.3", mod.Version)
 			assert.Equal(t, "https://registry.npmjs.org/bcryptjs/-/bcryptjs-2.4.3.tgz", mod.PackageDownloadLocation)
-			assert.Equal(t, meta.HashAlgorithm("SHA256"), mod.Checksum.Algorithm)
-			assert.Equal(t, h, mod.Checksum.Value)
+			assert.Equal(t, meta.HashAlgoSHA512, mod.Checksum.Algorithm)
+			assert.False(t, mod.Checksum.Synthetic)
 			assert.Equal(t, "Copyright (c) 2012 Nevins Bartolomeo <nevins.bartolomeo@gmail.com>", strings.TrimSpace(mod.Copyright))
 			assert.Equal(t, "MIT", mod.LicenseDeclared)
 			count++
@@ -166,3 +159,35 @@ func getPath() string {
 
 	return path
 }
+
+func TestLockScope(t *testing.T) {
+	assert.Equal(t, meta.ScopeRuntime, lockScope(map[string]interface{}{"version": "1.0.0"}))
+	assert.Equal(t, meta.ScopeDevelopment, lockScope(map[string]interface{}{"dev": true, "optional": true}))
+	assert.Equal(t, meta.ScopeDevelopment, lockScope(map[string]interface{}{"devOptional": true}))
+	assert.Equal(t, meta.ScopeOptional, lockScope(map[string]interface{}{"optional": true}))
+	assert.Equal(t, meta.ScopePeer, lockScope(map[string]interface{}{"peer": true}))
+}
+
+func TestAddChecksums(t *testing.T) {
+	pkg := meta.Package{}
+	addChecksums(&pkg, map[string]interface{}{
+		"integrity": "sha512-USbRo9X++6NaJ7ziWEFy71E/kshc0bdgFFse4nWQNGYo8S9j0bwxIef7+Po4FDzvkIvQI/DrgMWp5lZ5NL8Y7w==",
+	}, "axios")
+
+	assert.Equal(t, meta.HashAlgoSHA512, pkg.Checksum.Algorithm)
+	assert.False(t, pkg.Checksum.Synthetic)
+	assert.Equal(t, "5126d1a3d5fefba35a27bce2584172ef513f92c85cd1b760145b1ee27590346628f12f63d1bc3121e7fbf8fa38143cef908bd023f0eb80c5a9e6567934bf18ef", pkg.Checksum.Value)
+
+	pkg = meta.Package{}
+	addChecksums(&pkg, map[string]interface{}{"version": "1.0.0"}, "axios")
+	assert.True(t, pkg.Checksum.Synthetic)
+}
+
+func TestGetVersionReplay(t *testing.T) {
+	n := New()
+	n.SetOptions(plugin.Options{Runner: &plugin.Replayer{Dir: "testdata/fixtures"}})
+
+	version, err := n.GetVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, "9.8.1", version)
+}
@@ -3,16 +3,20 @@
 package nuget
 
 import (
+	"context"
 	"encoding/json"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/opensbom-generator/parsers/meta"
 	"github.com/opensbom-generator/parsers/plugin"
+	"github.com/opensbom-generator/parsers/purl"
 	log "github.com/sirupsen/logrus"
 
 	"github.com/opensbom-generator/parsers/internal/helper"
@@ -22,6 +26,7 @@ type Nuget struct {
 	metadata   plugin.Metadata
 	rootModule *meta.Package
 	command    *helper.Cmd
+	options    plugin.Options
 }
 
 var (
@@ -39,10 +44,16 @@ var (
 	assetType              = "type"
 	assetPackage           = "package"
 	assetDependencies      = "dependencies"
+	assetLibraries         = "libraries"
+	assetSHA512            = "sha512"
 	configModuleFile       = "packages.config"
 	nugetPackageSplit      = "global-packages:"
 )
 
+func init() {
+	plugin.Register("nuget", func() plugin.Plugin { return New() })
+}
+
 // New ...
 func New() *Nuget {
 	return &Nuget{
@@ -51,10 +62,24 @@ func New() *Nuget {
 			Slug:       "nuget",
 			Manifest:   manifestExtensions,
 			ModulePath: []string{},
+			ReadOnly:   true,
+			Capabilities: plugin.Capabilities{
+				Executables:      []plugin.Executable{{Name: "dotnet"}},
+				Network:          plugin.NetworkOptional,
+				MutatesWorkspace: true,
+				InstalledModules: true,
+				Lockfiles:        []string{assetModuleFile, configModuleFile},
+				TransitiveGraph:  true,
+			},
 		},
 	}
 }
 
+// SetOptions configures how modules are listed
+func (m *Nuget) SetOptions(opts plugin.Options) {
+	m.options = opts
+}
+
 // GetMetadata ...
 func (m *Nuget) GetMetadata() plugin.Metadata {
 	return m.metadata
@@ -62,7 +87,13 @@ func (m *Nuget) GetMetadata() plugin.Metadata {
 
 // SetRootModule ...
 func (m *Nuget) SetRootModule(path string) error {
-	module, err := m.GetRootModule(path)
+	return m.SetRootModuleContext(context.Background(), path)
+}
+
+// SetRootModuleContext ...
+func (m *Nuget) SetRootModuleContext(ctx context.Context, path string) error {
+	ctx = m.options.Context(ctx)
+	module, err := m.GetRootModuleContext(ctx, path)
 	if err != nil {
 		return err
 	}
@@ -79,8 +110,14 @@ func (m *Nuget) IsValid(path string) bool {
 
 // HasModulesInstalled ...
 func (m *Nuget) HasModulesInstalled(path string) error {
+	return m.HasModulesInstalledContext(context.Background(), path)
+}
+
+// HasModulesInstalledContext ...
+func (m *Nuget) HasModulesInstalledContext(ctx context.Context, path string) error {
+	ctx = m.options.Context(ctx)
 	// TODO: check nuGetFallBackFolderPath cache
-	if err := m.buildCmd(LocalPackageCacheCmd, "."); err != nil {
+	if err := m.buildCmd(ctx, LocalPackageCacheCmd, "."); err != nil {
 		return err
 	}
 	globalPackageCachePath, err := m.command.Output()
@@ -97,19 +134,23 @@ func (m *Nuget) HasModulesInstalled(path string) error {
 	}
 
 	projectPath := m.GetProjectManifestPath(path)
-	log.Infof("trying to restore the packages: %s", projectPath)
+	// restoring downloads the missing packages and writes the project assets,
+	// offline or read-only the cache and assets are used as they are
+	if !helper.IsOffline(ctx) && !helper.IsReadOnly(ctx) {
+		log.Debugf("trying to restore the packages: %s", projectPath)
 
-	restoreCommand := command(fmt.Sprintf("%s %s", RestorePackageCmd, projectPath))
-	if err := m.buildCmd(restoreCommand, "."); err != nil {
-		return err
-	}
+		restoreCommand := command(fmt.Sprintf("%s %s", RestorePackageCmd, projectPath))
+		if err := m.buildCmd(ctx, restoreCommand, "."); err != nil {
+			return err
+		}
 
-	_, err = m.command.Output()
-	if err != nil {
-		return err
+		_, err = m.command.Output()
+		if err != nil {
+			return err
+		}
 	}
 
-	log.Infof("looking for the project modules using location: %s", projectPath)
+	log.Debugf("looking for the project modules using location: %s", projectPath)
 
 	projectPaths, err := getProjectPaths(projectPath)
 	if err != nil {
@@ -136,13 +177,22 @@ func (m *Nuget) HasModulesInstalled(path string) error {
 	if len(projectArray) == 0 {
 		return nil
 	}
-	log.Infof("no modules found for project:%s", projectArray)
+	log.Debugf("no modules found for project:%s", projectArray)
+	if err := helper.CheckWritable(ctx, "the packages of "+strings.Join(projectArray, ", ")+" must be restored"); err != nil {
+		return err
+	}
 	return errDependenciesNotFound
 }
 
 // GetVersion...
 func (m *Nuget) GetVersion() (string, error) {
-	if err := m.buildCmd(VersionCmd, "."); err != nil {
+	return m.GetVersionContext(context.Background())
+}
+
+// GetVersionContext...
+func (m *Nuget) GetVersionContext(ctx context.Context) (string, error) {
+	ctx = m.options.Context(ctx)
+	if err := m.buildCmd(ctx, VersionCmd, "."); err != nil {
 		return "", err
 	}
 
@@ -151,6 +201,11 @@ func (m *Nuget) GetVersion() (string, error) {
 
 // GetRootModule...
 func (m *Nuget) GetRootModule(path string) (*meta.Package, error) {
+	return m.GetRootModuleContext(context.Background(), path)
+}
+
+// GetRootModuleContext...
+func (m *Nuget) GetRootModuleContext(ctx context.Context, path string) (*meta.Package, error) {
 	if m.rootModule == nil {
 		module := meta.Package{}
 		projectPath := m.GetProjectManifestPath(path)
@@ -160,11 +215,9 @@ func (m *Nuget) GetRootModule(path string) (*meta.Package, error) {
 			rootProjectName := fileName[0 : len(fileName)-len(pathExtension)]
 			module.Name = rootProjectName
 			module.Root = true
-			module.Checksum = meta.Checksum{
-				Algorithm: meta.HashAlgoSHA256,
-				Content:   []byte(fmt.Sprintf("%s%s", module.Name, module.Version)),
-			}
+			module.AddChecksum(meta.SyntheticChecksum(fmt.Sprintf("%s%s", module.Name, module.Version)))
 			module.Supplier.Name = rootProjectName
+			module.PackageURL = purl.NuGet(rootProjectName, module.Version)
 			module.PackageDownloadLocation = buildRootPackageURL(path)
 		}
 		m.rootModule = &module
@@ -174,6 +227,12 @@ func (m *Nuget) GetRootModule(path string) (*meta.Package, error) {
 
 // ListModulesWithDeps ...
 func (m *Nuget) ListModulesWithDeps(path string, globalSettingFile string) ([]meta.Package, error) {
+	return m.ListModulesWithDepsContext(context.Background(), path, globalSettingFile)
+}
+
+// ListModulesWithDepsContext ...
+func (m *Nuget) ListModulesWithDepsContext(ctx context.Context, path string, globalSettingFile string) ([]meta.Package, error) {
+	ctx = m.options.Context(ctx)
 	var modules []meta.Package
 	projectPath := m.GetProjectManifestPath(path)
 	projectPaths, err := getProjectPaths(projectPath)
@@ -188,18 +247,18 @@ func (m *Nuget) ListModulesWithDeps(path string, globalSettingFile string) ([]me
 	for _, project := range projectPaths {
 		projectDirectory := filepath.Dir(project)
 		if helper.Exists(filepath.Join(projectDirectory, modulePath)) {
-			packages, err := m.parseAssetModules(filepath.Join(projectDirectory, modulePath))
+			packages, err := m.parseAssetModules(ctx, filepath.Join(projectDirectory, modulePath))
 			if err != nil {
 				return modules, err
 			}
 			modules = append(modules, packages...)
-			log.Infof("dependency tree completed for project(a): %s", project)
+			log.Debugf("dependency tree completed for project(a): %s", project)
 		} else if helper.Exists(filepath.Join(projectDirectory, configModuleFile)) {
-			packages, err := m.parsePackagesConfigModules(filepath.Join(projectDirectory, configModuleFile))
+			packages, err := m.parsePackagesConfigModules(ctx, filepath.Join(projectDirectory, configModuleFile))
 			if err != nil {
 				return modules, err
 			}
-			log.Infof("dependency tree completed for project(c): %s", project)
+			log.Debugf("dependency tree completed for project(c): %s", project)
 			modules = append(modules, packages...)
 		}
 	}
@@ -210,16 +269,22 @@ func (m *Nuget) ListModulesWithDeps(path string, globalSettingFile string) ([]me
 	if m.rootModule != nil {
 		modules = append(modules, *m.rootModule)
 	}
-	return modules, nil
+	return m.options.Filter(modules), nil
 }
 
 // ListUsedModules ...
 func (m *Nuget) ListUsedModules(path string) ([]meta.Package, error) {
+	return m.ListUsedModulesContext(context.Background(), path)
+}
+
+// ListUsedModulesContext ...
+func (m *Nuget) ListUsedModulesContext(ctx context.Context, path string) ([]meta.Package, error) {
+	ctx = m.options.Context(ctx)
 	var globalSettingFile string
-	return m.ListModulesWithDeps(path, globalSettingFile)
+	return m.ListModulesWithDepsContext(ctx, path, globalSettingFile)
 }
 
-func (m *Nuget) buildCmd(cmd command, path string) error {
+func (m *Nuget) buildCmd(ctx context.Context, cmd command, path string) error {
 	cmdArgs := cmd.Parse()
 	if cmdArgs[0] != dotnetCmd {
 		return errNoDotnetCommand
@@ -229,6 +294,7 @@ func (m *Nuget) buildCmd(cmd command, path string) error {
 		Name:      cmdArgs[0],
 		Args:      cmdArgs[1:],
 		Directory: path,
+		Context:   ctx,
 	})
 
 	m.command = command
@@ -240,10 +306,7 @@ func (m *Nuget) buildCmd(cmd command, path string) error {
 func (m *Nuget) GetProjectManifestPath(path string) string {
 	for i := range m.metadata.Manifest {
 		pathPattern := filepath.Join(path, fmt.Sprintf("*%s", m.metadata.Manifest[i]))
-		projectPaths, err := filepath.Glob(pathPattern)
-		if err != nil {
-			log.Error(err)
-		}
+		projectPaths, _ := filepath.Glob(pathPattern)
 		if len(projectPaths) > 0 {
 			return projectPaths[0]
 		}
@@ -255,7 +318,7 @@ func (m *Nuget) GetProjectManifestPath(path string) string {
 }
 
 // parsePackagesConfigModules parses the output -- works for the packages.config
-func (m *Nuget) parsePackagesConfigModules(modulePath string) ([]meta.Package, error) {
+func (m *Nuget) parsePackagesConfigModules(ctx context.Context, modulePath string) ([]meta.Package, error) {
 	modules := make([]meta.Package, 0)
 	raw, err := os.ReadFile(modulePath)
 	if err != nil {
@@ -267,17 +330,20 @@ func (m *Nuget) parsePackagesConfigModules(modulePath string) ([]meta.Package, e
 		return modules, err
 	}
 	for _, modulePackage := range moduleData.Packages {
-		module, err := m.buildModule(modulePackage.ID, modulePackage.Version, nil)
+		module, err := m.buildModule(ctx, modulePackage.ID, modulePackage.Version, "", nil)
 		if err != nil {
 			return modules, err
 		}
+		if modulePackage.DevelopmentDependency {
+			module.Scope = meta.ScopeDevelopment
+		}
 		modules = append(modules, module)
 	}
 	return modules, nil
 }
 
 // parseAssetModules parses the output -- works for the project.assets.json
-func (m *Nuget) parseAssetModules(modulePath string) ([]meta.Package, error) {
+func (m *Nuget) parseAssetModules(ctx context.Context, modulePath string) ([]meta.Package, error) {
 	modules := make([]meta.Package, 0)
 	raw, err := os.ReadFile(modulePath)
 	if err != nil {
@@ -289,6 +355,8 @@ func (m *Nuget) parseAssetModules(modulePath string) ([]meta.Package, error) {
 	if err != nil {
 		return modules, err
 	}
+	// the libraries record the sha512 of the restored packages
+	libraries, _ := moduleData[assetLibraries].(map[string]interface{})
 	// parse targets from the asset json
 	targetsData := moduleData[assetTargets].(map[string]interface{})
 	packageNameMap := map[string]string{}
@@ -321,7 +389,11 @@ func (m *Nuget) parseAssetModules(modulePath string) ([]meta.Package, error) {
 				}
 				packageUniqueName := fmt.Sprintf("%s-%s", packageName, packageVersion)
 				if _, ok := packageNameMap[packageUniqueName]; !ok {
-					module, err := m.buildModule(packageName, packageVersion, dependencies)
+					var sha512 string
+					if library, ok := libraries[name].(map[string]interface{}); ok {
+						sha512, _ = library[assetSHA512].(string)
+					}
+					module, err := m.buildModule(ctx, packageName, packageVersion, sha512, dependencies)
 					if err != nil {
 						return modules, err
 					}
@@ -358,34 +430,40 @@ func getProjectPaths(path string) ([]string, error) {
 	return projectPath, nil
 }
 
-// buildModule .. set the properties
-func (m *Nuget) buildModule(name string, version string, dependencies map[string]string) (meta.Package, error) {
+// buildModule .. set the properties, sha512 is the base64 digest of the
+// package recorded in the project assets, if any
+func (m *Nuget) buildModule(ctx context.Context, name string, version string, sha512 string, dependencies map[string]string) (meta.Package, error) {
 	var module meta.Package
 	module.Name = name
 	module.Version = version
+	module.PackageURL = purl.NuGet(name, version)
+	module.Scope = meta.ScopeRuntime
 	// get the hash checksum
-	checkSum, err := getHashCheckSum(name, version)
+	checkSum, err := meta.NewChecksum(meta.HashAlgoSHA512, sha512)
 	if err != nil {
-		return module, err
+		if checkSum, err = getHashCheckSum(ctx, name, version); err != nil {
+			return module, err
+		}
 	}
-	module.Checksum = *checkSum
+	module.AddChecksum(checkSum)
 	// get nuget spec file details
-	nuSpecFile, err := getNugetSpec(name, version)
+	nuSpecFile, err := getNugetSpec(ctx, name, version)
 	if err != nil {
 		return module, err
 	}
+	declared := ""
 	if nuSpecFile != nil {
 		if nuSpecFile.Meta.ProjectURL != "" {
-			module.PackageURL = nuSpecFile.Meta.ProjectURL
+			module.PackageHomePage = nuSpecFile.Meta.ProjectURL
 		}
-		if helper.LicenseSPDXExists(nuSpecFile.Meta.License) {
-			module.LicenseDeclared = helper.BuildLicenseDeclared(nuSpecFile.Meta.License)
-			module.LicenseConcluded = helper.BuildLicenseConcluded(nuSpecFile.Meta.License)
-		} else if nuSpecFile.Meta.License != "" {
-			module.LicenseDeclared = extractLicence(nuSpecFile.Meta.License)
-			module.LicenseConcluded = extractLicence(nuSpecFile.Meta.License)
+		// a license of type file names the license file of the package
+		if nuSpecFile.Meta.License.Type != "file" {
+			declared = nuSpecFile.Meta.License.Value
 		}
 		module.Copyright = nuSpecFile.Meta.Copyright
+		if statements := helper.GetCopyright(nuSpecFile.Meta.Copyright); statements != "" {
+			module.Copyright = statements
+		}
 
 		switch {
 		case nuSpecFile.Meta.Authors != "":
@@ -401,18 +479,27 @@ func (m *Nuget) buildModule(name string, version string, dependencies map[string
 		} else {
 			module.PackageDownloadLocation = m.rootModule.PackageDownloadLocation
 		}
+	} else if helper.ClientFrom(ctx).Offline(ctx) {
+		module.PackageDownloadLocation = helper.NoAssertion
+		helper.Report(ctx, meta.SeverityInfo, meta.CodeMissingMetadata, name, "the nuspec of %s is not cached and cannot be fetched offline", name)
+	}
+	// the license files are only on disk when the package is cached
+	packageDir := ""
+	if specFileName := getCachedSpecFilename(name, version); specFileName != "" {
+		packageDir = filepath.Dir(specFileName)
+	}
+	helper.SetLicenses(ctx, &module, declared, packageDir)
+	if module.Copyright == "" && packageDir != "" {
+		module.Copyright = helper.ScanCopyright(packageDir)
 	}
 	// set dependencies
 	dependencyModules := map[string]*meta.Package{}
 	for dName, dVersion := range dependencies {
-		checkSum, err := getHashCheckSum(name, version)
-		if err != nil {
-			return module, err
-		}
 		dependencyModules[dName] = &meta.Package{
-			Name:     dName,
-			Version:  dVersion,
-			Checksum: *checkSum,
+			Name:       dName,
+			Version:    dVersion,
+			PackageURL: purl.NuGet(dName, dVersion),
+			Scope:      meta.ScopeRuntime,
 		}
 	}
 	module.Packages = dependencyModules
@@ -438,7 +525,7 @@ func getCachedSpecFilename(name string, version string) string {
 }
 
 // getNugetSpec ...
-func getNugetSpec(name string, version string) (*Spec, error) {
+func getNugetSpec(ctx context.Context, name string, version string) (*Spec, error) {
 	nuSpecFile := Spec{}
 	specFileName := getCachedSpecFilename(name, version)
 	if specFileName != "" {
@@ -454,17 +541,20 @@ func getNugetSpec(name string, version string) (*Spec, error) {
 	}
 	nugetURLPrefix := fmt.Sprintf("%s%s/%s/%s", nugetBaseURL, name, version, name)
 	nuspecURL := fmt.Sprintf("%s%s", nugetURLPrefix, specExt)
-	resp, err := getHTTPResponseWithHeaders(nuspecURL, map[string]string{"content-type": "application/xml"})
+	resp, err := getHTTPResponseWithHeaders(ctx, nuspecURL, map[string]string{"content-type": "application/xml"})
+	if errors.Is(err, helper.ErrOffline) {
+		return nil, nil
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	defer func() {
-		closeErr := resp.Body.Close()
-		if closeErr != nil {
-			log.Error(fmt.Sprintf("%#v", err))
-		}
-	}()
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		helper.Report(ctx, meta.SeverityWarning, meta.CodeMissingMetadata, name, "could not download %s: %s", nuspecURL, resp.Status)
+		return nil, nil
+	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
@@ -477,71 +567,42 @@ func getNugetSpec(name string, version string) (*Spec, error) {
 	return &nuSpecFile, nil
 }
 
-// getHashCheckSum ...
-func getHashCheckSum(name string, version string) (*meta.Checksum, error) {
-	var fileData []byte
+// getHashCheckSum returns the sha512 of the package from the .nupkg.sha512
+// file of the package cache, or computed from the cached or downloaded
+// package. Offline, packages missing from the cache have no checksum.
+func getHashCheckSum(ctx context.Context, name string, version string) (meta.Checksum, error) {
 	specFileName := getCachedSpecFilename(name, version)
 	if specFileName != "" {
-		extension := filepath.Ext(specFileName)
-		// extract the file name
-		fileName := specFileName[0 : len(specFileName)-len(extension)]
-		// change the extension - sha512Ext
-		shaName := fmt.Sprintf("%s.%s%s", fileName, version, sha512Ext)
-		// change the extension - pkgExt
-		pkgName := fileName + fmt.Sprintf("%s.%s%s", fileName, version, pkgExt)
-		if helper.Exists(shaName) {
-			shaFileData, err := os.ReadFile(shaName)
+		// the cache holds <name>.nuspec, <name>.<version>.nupkg and its sha512
+		prefix := fmt.Sprintf("%s.%s", strings.TrimSuffix(specFileName, specExt), version)
+		if helper.Exists(prefix + sha512Ext) {
+			digest, err := os.ReadFile(prefix + sha512Ext)
 			if err != nil {
-				return nil, err
+				return meta.Checksum{}, err
 			}
-			fileData = shaFileData
-		} else if helper.Exists(pkgName) {
-			shaFileData, err := os.ReadFile(pkgName)
-			if err != nil {
-				return nil, err
-			}
-			fileData = shaFileData
+			return meta.NewChecksum(meta.HashAlgoSHA512, string(digest))
+		}
+		if helper.Exists(prefix + pkgExt) {
+			return meta.ComputeFileChecksum(meta.HashAlgoSHA512, prefix+pkgExt)
 		}
 	}
-	if fileData != nil {
-		return &meta.Checksum{
-			Algorithm: meta.HashAlgoSHA256,
-			Content:   fileData,
-		}, nil
-	}
-	nugetURLPrefix := fmt.Sprintf("%s%s/%s/%s", nugetBaseURL, name, version, name)
+	nugetURLPrefix := fmt.Sprintf("%s%s/%s/%s", nugetBaseURL, strings.ToLower(name), version, strings.ToLower(name))
 	nuPkgURL := fmt.Sprintf("%s.%s%s", nugetURLPrefix, version, pkgExt)
-	resp, err := getHTTPResponseWithHeaders(nuPkgURL, map[string]string{"content-type": "application/xml"})
-	if err != nil {
-		return nil, err
+	resp, err := getHTTPResponseWithHeaders(ctx, nuPkgURL, map[string]string{"content-type": "application/octet-stream"})
+	if errors.Is(err, helper.ErrOffline) {
+		helper.Report(ctx, meta.SeverityWarning, meta.CodeChecksumUnavailable, name, "%s is not cached and cannot be downloaded offline", name)
+		return meta.Checksum{}, nil
 	}
-	defer func() {
-		closeErr := resp.Body.Close()
-		if closeErr != nil {
-			log.Error(fmt.Sprintf("%#v", err))
-		}
-	}()
-
-	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, err
+		return meta.Checksum{}, err
 	}
-	if body != nil {
-		return &meta.Checksum{
-			Algorithm: meta.HashAlgoSHA256,
-			Content:   fileData,
-		}, nil
+	defer resp.Body.Close()
+	// the client passes the error responses through, their body is no
+	// package
+	if resp.StatusCode != http.StatusOK {
+		helper.Report(ctx, meta.SeverityWarning, meta.CodeChecksumUnavailable, name, "could not download %s: %s", nuPkgURL, resp.Status)
+		return meta.Checksum{}, nil
 	}
-	return nil, nil
-}
 
-// extractLicence from the licenceMetaData
-func extractLicence(licenceMetaData string) string {
-	licenseArray := strings.Split(licenceMetaData, " ")
-	for _, license := range licenseArray {
-		if helper.LicenseSPDXExists(license) {
-			return license
-		}
-	}
-	return ""
+	return meta.ComputeChecksum(meta.HashAlgoSHA512, resp.Body)
 }
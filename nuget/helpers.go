@@ -3,19 +3,19 @@
 package nuget
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strings"
-	"time"
 
 	"github.com/go-git/go-git/v5"
+	"github.com/opensbom-generator/parsers/internal/helper"
+	"github.com/opensbom-generator/parsers/purl"
 )
 
-func getHTTPResponseWithHeaders(url string, headers map[string]string) (*http.Response, error) {
-	var netClient = &http.Client{
-		Timeout: time.Second * 30,
-	}
-	req, err := http.NewRequest("GET", url, nil)
+func getHTTPResponseWithHeaders(ctx context.Context, url string, headers map[string]string) (*http.Response, error) {
+	netClient := helper.ClientFrom(ctx)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, netClient.MirrorURL(purl.TypeNuGet, nugetBaseURL, url), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -24,13 +24,16 @@ type Spec struct {
 	Name  xml.Name `xml:"package"`
 	Xmlns string   `xml:"xmlns,attr,omitempty"`
 	Meta  struct {
-		ID               string `xml:"id"`
-		Version          string `xml:"version"`
-		Title            string `xml:"title,omitempty"`
-		Authors          string `xml:"authors"`
-		Owners           string `xml:"owners,omitempty"`
-		LicenseURL       string `xml:"licenseUrl,omitempty"`
-		License          string `xml:"license"`
+		ID         string `xml:"id"`
+		Version    string `xml:"version"`
+		Title      string `xml:"title,omitempty"`
+		Authors    string `xml:"authors"`
+		Owners     string `xml:"owners,omitempty"`
+		LicenseURL string `xml:"licenseUrl,omitempty"`
+		License    struct {
+			Type  string `xml:"type,attr"`
+			Value string `xml:",chardata"`
+		} `xml:"license"`
 		ProjectURL       string `xml:"projectUrl,omitempty"`
 		IconURL          string `xml:"iconUrl,omitempty"`
 		ReqLicenseAccept bool   `xml:"requireLicenseAcceptance"`
@@ -61,9 +64,10 @@ type PackageConfig struct {
 
 // PackageDetail ...
 type PackageDetail struct {
-	XMLName xml.Name `xml:"package"`
-	ID      string   `xml:"id,attr"`
-	Version string   `xml:"version,attr"`
+	XMLName               xml.Name `xml:"package"`
+	ID                    string   `xml:"id,attr"`
+	Version               string   `xml:"version,attr"`
+	DevelopmentDependency bool     `xml:"developmentDependency,attr"`
 }
 
 // ConvertedFromBytes ...
@@ -3,6 +3,8 @@
 package pip
 
 import (
+	"context"
+
 	"github.com/opensbom-generator/parsers/meta"
 	"github.com/opensbom-generator/parsers/pip/pipenv"
 	"github.com/opensbom-generator/parsers/pip/poetry"
@@ -12,7 +14,12 @@ import (
 )
 
 type PIP struct {
-	plugin plugin.Plugin
+	plugin  plugin.Plugin
+	options plugin.Options
+}
+
+func init() {
+	plugin.Register("pip", func() plugin.Plugin { return New() })
 }
 
 // New ...
@@ -22,9 +29,43 @@ func New() *PIP {
 	}
 }
 
-// Get Metadata ...
+// Set Options configures how modules are listed
+func (m *PIP) SetOptions(opts plugin.Options) {
+	m.options = opts
+}
+
+// Get Metadata returns the metadata of the plugin selected by IsValid, or
+// the metadata of pipenv, poetry and pyenv merged before
 func (m *PIP) GetMetadata() plugin.Metadata {
-	return m.plugin.GetMetadata()
+	if m.plugin != nil {
+		return m.plugin.GetMetadata()
+	}
+
+	merged := plugin.Metadata{
+		Name:     "The Python Package Index (PyPI)",
+		Slug:     "pip",
+		ReadOnly: true,
+		Capabilities: plugin.Capabilities{
+			TransitiveGraph: true,
+		},
+	}
+	for _, p := range []plugin.Plugin{pipenv.New(), poetry.New(), pyenv.New()} {
+		metadata := p.GetMetadata()
+		merged.Manifest = append(merged.Manifest, metadata.Manifest...)
+		merged.ReadOnly = merged.ReadOnly && metadata.ReadOnly
+
+		capabilities, c := &merged.Capabilities, metadata.Capabilities
+		capabilities.Executables = append(capabilities.Executables, c.Executables...)
+		if c.Network > capabilities.Network {
+			capabilities.Network = c.Network
+		}
+		capabilities.MutatesWorkspace = capabilities.MutatesWorkspace || c.MutatesWorkspace
+		capabilities.InstalledModules = capabilities.InstalledModules || c.InstalledModules
+		capabilities.Lockfiles = append(capabilities.Lockfiles, c.Lockfiles...)
+		capabilities.TransitiveGraph = capabilities.TransitiveGraph && c.TransitiveGraph
+	}
+
+	return merged
 }
 
 // Is Valid ...
@@ -49,30 +90,67 @@ func (m *PIP) IsValid(path string) bool {
 
 // Has Modules Installed ...
 func (m *PIP) HasModulesInstalled(path string) error {
-	return m.plugin.HasModulesInstalled(path)
+	return m.HasModulesInstalledContext(context.Background(), path)
+}
+
+// Has Modules Installed Context ...
+func (m *PIP) HasModulesInstalledContext(ctx context.Context, path string) error {
+	ctx = m.options.Context(ctx)
+	return plugin.WithContext(m.plugin).HasModulesInstalledContext(ctx, path)
 }
 
 // Get Version ...
 func (m *PIP) GetVersion() (string, error) {
-	return m.plugin.GetVersion()
+	return m.GetVersionContext(context.Background())
+}
+
+// Get Version Context ...
+func (m *PIP) GetVersionContext(ctx context.Context) (string, error) {
+	ctx = m.options.Context(ctx)
+	return plugin.WithContext(m.plugin).GetVersionContext(ctx)
 }
 
 // Set Root Module ...
 func (m *PIP) SetRootModule(path string) error {
-	return m.plugin.SetRootModule(path)
+	return m.SetRootModuleContext(context.Background(), path)
+}
+
+// Set Root Module Context ...
+func (m *PIP) SetRootModuleContext(ctx context.Context, path string) error {
+	ctx = m.options.Context(ctx)
+	return plugin.WithContext(m.plugin).SetRootModuleContext(ctx, path)
 }
 
 // Get Root Module ...
 func (m *PIP) GetRootModule(path string) (*meta.Package, error) {
-	return m.plugin.GetRootModule(path)
+	return m.GetRootModuleContext(context.Background(), path)
+}
+
+// Get Root Module Context ...
+func (m *PIP) GetRootModuleContext(ctx context.Context, path string) (*meta.Package, error) {
+	ctx = m.options.Context(ctx)
+	return plugin.WithContext(m.plugin).GetRootModuleContext(ctx, path)
 }
 
 // List Used Modules...
 func (m *PIP) ListUsedModules(path string) ([]meta.Package, error) {
-	return m.plugin.ListUsedModules(path)
+	return m.ListUsedModulesContext(context.Background(), path)
+}
+
+// List Used Modules Context ...
+func (m *PIP) ListUsedModulesContext(ctx context.Context, path string) ([]meta.Package, error) {
+	ctx = m.options.Context(ctx)
+	return plugin.WithContext(m.plugin).ListUsedModulesContext(ctx, path)
 }
 
 // List Modules With Deps ...
 func (m *PIP) ListModulesWithDeps(path string, globalSettingFile string) ([]meta.Package, error) {
-	return m.plugin.ListModulesWithDeps(path, globalSettingFile)
+	return m.ListModulesWithDepsContext(context.Background(), path, globalSettingFile)
+}
+
+// List Modules With Deps Context ...
+func (m *PIP) ListModulesWithDepsContext(ctx context.Context, path string, globalSettingFile string) ([]meta.Package, error) {
+	ctx = m.options.Context(ctx)
+	plugin.Configure(m.plugin, m.options)
+	return plugin.WithContext(m.plugin).ListModulesWithDepsContext(ctx, path, globalSettingFile)
 }
@@ -3,7 +3,10 @@
 package pipenv
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"os"
 	"path/filepath"
 	"strings"
 
@@ -38,6 +41,7 @@ type PipEnv struct {
 	pkgs       []worker.Packages
 	metainfo   map[string]worker.Metadata
 	allModules []meta.Package
+	options    plugin.Options
 }
 
 // New ...
@@ -48,10 +52,24 @@ func New() *PipEnv {
 			Slug:       "pipenv",
 			Manifest:   []string{manifestLockFile},
 			ModulePath: []string{},
+			ReadOnly:   true,
+			Capabilities: plugin.Capabilities{
+				Executables:      []plugin.Executable{{Name: "pipenv"}},
+				Network:          plugin.NetworkOptional,
+				MutatesWorkspace: true,
+				InstalledModules: true,
+				Lockfiles:        []string{manifestLockFile},
+				TransitiveGraph:  true,
+			},
 		},
 	}
 }
 
+// Set Options configures how modules are listed
+func (m *PipEnv) SetOptions(opts plugin.Options) {
+	m.options = opts
+}
+
 // Get Metadata ...
 func (m *PipEnv) GetMetadata() plugin.Metadata {
 	return m.metadata
@@ -69,7 +87,13 @@ func (m *PipEnv) IsValid(path string) bool {
 
 // Has Modules Installed ...
 func (m *PipEnv) HasModulesInstalled(path string) error {
-	if err := m.buildCmd(ModulesCmd, m.basepath); err != nil {
+	return m.HasModulesInstalledContext(context.Background(), path)
+}
+
+// HasModulesInstalledContext ...
+func (m *PipEnv) HasModulesInstalledContext(ctx context.Context, path string) error {
+	ctx = m.options.Context(ctx)
+	if err := m.buildCmd(ctx, ModulesCmd, m.basepath); err != nil {
 		return err
 	}
 	result, err := m.command.Output()
@@ -81,7 +105,13 @@ func (m *PipEnv) HasModulesInstalled(path string) error {
 
 // Get Version ...
 func (m *PipEnv) GetVersion() (string, error) {
-	if err := m.buildCmd(VersionCmd, m.basepath); err != nil {
+	return m.GetVersionContext(context.Background())
+}
+
+// GetVersionContext ...
+func (m *PipEnv) GetVersionContext(ctx context.Context) (string, error) {
+	ctx = m.options.Context(ctx)
+	if err := m.buildCmd(ctx, VersionCmd, m.basepath); err != nil {
 		return "", err
 	}
 	version, err := m.command.Output()
@@ -94,12 +124,24 @@ func (m *PipEnv) GetVersion() (string, error) {
 
 // Set Root Module ...
 func (m *PipEnv) SetRootModule(path string) error {
+	return m.SetRootModuleContext(context.Background(), path)
+}
+
+// SetRootModuleContext ...
+func (m *PipEnv) SetRootModuleContext(ctx context.Context, path string) error {
+	ctx = m.options.Context(ctx)
 	m.basepath = path
 	return nil
 }
 
 // Get Root Module ...
 func (m *PipEnv) GetRootModule(path string) (*meta.Package, error) {
+	return m.GetRootModuleContext(context.Background(), path)
+}
+
+// GetRootModuleContext ...
+func (m *PipEnv) GetRootModuleContext(ctx context.Context, path string) (*meta.Package, error) {
+	ctx = m.options.Context(ctx)
 	if m.rootModule == nil {
 		module := m.fetchRootModule()
 		m.rootModule = &module
@@ -109,34 +151,72 @@ func (m *PipEnv) GetRootModule(path string) (*meta.Package, error) {
 
 // List Used Modules...
 func (m *PipEnv) ListUsedModules(path string) ([]meta.Package, error) {
-	if err := m.LoadModuleList(path); err != nil {
+	return m.ListUsedModulesContext(context.Background(), path)
+}
+
+// ListUsedModulesContext ...
+func (m *PipEnv) ListUsedModulesContext(ctx context.Context, path string) ([]meta.Package, error) {
+	ctx = m.options.Context(ctx)
+	if err := m.LoadModuleList(ctx, path); err != nil {
 		return m.allModules, errFailedToConvertModules
 	}
 
-	decoder := worker.NewMetadataDecoder(m.GetPackageDetails)
-	metainfo, err := decoder.ConvertMetadataToModules(m.pkgs, &m.allModules)
+	decoder := worker.NewMetadataDecoder(m.GetPackageDetailsContext)
+	metainfo, err := decoder.ConvertMetadataToModules(ctx, m.pkgs, &m.allModules)
 	if err != nil {
 		return m.allModules, err
 	}
 
 	m.metainfo = metainfo
+	meta.Sort(m.allModules)
+
 	return m.allModules, nil
 }
 
 // List Modules With Deps ...
 func (m *PipEnv) ListModulesWithDeps(path string, globalSettingFile string) ([]meta.Package, error) {
-	modules, err := m.ListUsedModules(path)
-	if err != nil {
+	return m.ListModulesWithDepsContext(context.Background(), path, globalSettingFile)
+}
+
+// ListModulesWithDepsContext ...
+func (m *PipEnv) ListModulesWithDepsContext(ctx context.Context, path string, globalSettingFile string) ([]meta.Package, error) {
+	ctx = m.options.Context(ctx)
+	if _, err := m.ListUsedModulesContext(ctx, path); err != nil {
 		return nil, err
 	}
-	_, _ = m.GetRootModule(path)
-	if err := worker.BuildDependencyGraph(&m.allModules, &m.metainfo); err != nil {
+	_, _ = m.GetRootModuleContext(ctx, path)
+	if err := worker.BuildDependencyGraph(ctx, &m.allModules, &m.metainfo); err != nil {
 		return nil, err
 	}
-	return modules, err
+	worker.ResolveScopes(m.allModules, developPackages(path))
+	return m.options.Filter(m.allModules), nil
 }
 
-func (m *PipEnv) buildCmd(cmd command, path string) error {
+// developPackages returns the names listed in the develop section of Pipfile.lock
+func developPackages(path string) []string {
+	data, err := os.ReadFile(filepath.Join(path, manifestLockFile))
+	if err != nil {
+		return nil
+	}
+
+	lock := struct {
+		Default map[string]json.RawMessage `json:"default"`
+		Develop map[string]json.RawMessage `json:"develop"`
+	}{}
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil
+	}
+
+	names := []string{}
+	for name := range lock.Develop {
+		if _, ok := lock.Default[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func (m *PipEnv) buildCmd(ctx context.Context, cmd command, path string) error {
 	cmdArgs := cmd.Parse()
 	if cmdArgs[0] != cmdName {
 		return errNoPipCommand
@@ -146,6 +226,7 @@ func (m *PipEnv) buildCmd(cmd command, path string) error {
 		Name:      cmdArgs[0],
 		Args:      cmdArgs[1:],
 		Directory: path,
+		Context:   ctx,
 	})
 
 	m.command = command
@@ -154,9 +235,14 @@ func (m *PipEnv) buildCmd(cmd command, path string) error {
 }
 
 func (m *PipEnv) GetPackageDetails(packageNameList string) (string, error) {
+	return m.GetPackageDetailsContext(context.Background(), packageNameList)
+}
+
+func (m *PipEnv) GetPackageDetailsContext(ctx context.Context, packageNameList string) (string, error) {
+	ctx = m.options.Context(ctx)
 	metatdataCmd := command(strings.ReplaceAll(string(MetadataCmd), placeholderPkgName, packageNameList))
 
-	_ = m.buildCmd(metatdataCmd, m.basepath)
+	_ = m.buildCmd(ctx, metatdataCmd, m.basepath)
 	result, err := m.command.Output()
 	if err != nil {
 		return "", err
@@ -165,8 +251,15 @@ func (m *PipEnv) GetPackageDetails(packageNameList string) (string, error) {
 	return result, nil
 }
 
-func (m *PipEnv) PushRootModuleToVenv() (bool, error) {
-	if err := m.buildCmd(InstallRootModuleCmd, m.basepath); err != nil {
+func (m *PipEnv) PushRootModuleToVenv(ctx context.Context) (bool, error) {
+	// installing the root module may download its dependencies and changes
+	// the virtual environment, the root module is then only found when it is
+	// already installed
+	if helper.IsOffline(ctx) || helper.IsReadOnly(ctx) {
+		return false, nil
+	}
+
+	if err := m.buildCmd(ctx, InstallRootModuleCmd, m.basepath); err != nil {
 		return false, err
 	}
 	result, err := m.command.Output()
@@ -185,16 +278,16 @@ func (m *PipEnv) markRootModue() {
 	}
 }
 
-func (m *PipEnv) LoadModuleList(path string) error {
+func (m *PipEnv) LoadModuleList(ctx context.Context, path string) error {
 	var state bool
 	var err error
 
 	if worker.IsValidRootModule(path) {
-		state, err = m.PushRootModuleToVenv()
+		state, err = m.PushRootModuleToVenv(ctx)
 		if err != nil && !state {
 			return err
 		}
-		_ = m.buildCmd(ModulesCmd, m.basepath)
+		_ = m.buildCmd(ctx, ModulesCmd, m.basepath)
 		result, err := m.command.Output()
 		if err == nil && len(result) > 0 && worker.IsRequirementMeet(result) {
 			m.pkgs = worker.LoadModules(result, m.version)
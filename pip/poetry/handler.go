@@ -3,7 +3,9 @@
 package poetry
 
 import (
+	"context"
 	"errors"
+	"os"
 	"path/filepath"
 	"strings"
 
@@ -11,6 +13,7 @@ import (
 	"github.com/opensbom-generator/parsers/meta"
 	"github.com/opensbom-generator/parsers/pip/worker"
 	"github.com/opensbom-generator/parsers/plugin"
+	toml "github.com/pelletier/go-toml/v2"
 )
 
 const (
@@ -36,6 +39,7 @@ type Poetry struct {
 	pkgs       []worker.Packages
 	metainfo   map[string]worker.Metadata
 	allModules []meta.Package
+	options    plugin.Options
 }
 
 // New ...
@@ -46,10 +50,24 @@ func New() *Poetry {
 			Slug:       "poetry",
 			Manifest:   []string{manifestLockFile},
 			ModulePath: []string{},
+			ReadOnly:   true,
+			Capabilities: plugin.Capabilities{
+				Executables:      []plugin.Executable{{Name: "poetry"}},
+				Network:          plugin.NetworkOptional,
+				MutatesWorkspace: true,
+				InstalledModules: true,
+				Lockfiles:        []string{manifestLockFile},
+				TransitiveGraph:  true,
+			},
 		},
 	}
 }
 
+// Set Options configures how modules are listed
+func (m *Poetry) SetOptions(opts plugin.Options) {
+	m.options = opts
+}
+
 // Get Metadata ...
 func (m *Poetry) GetMetadata() plugin.Metadata {
 	return m.metadata
@@ -67,7 +85,13 @@ func (m *Poetry) IsValid(path string) bool {
 
 // Has Modules Installed ...
 func (m *Poetry) HasModulesInstalled(path string) error {
-	if err := m.buildCmd(ModulesCmd, m.basepath); err != nil {
+	return m.HasModulesInstalledContext(context.Background(), path)
+}
+
+// HasModulesInstalledContext ...
+func (m *Poetry) HasModulesInstalledContext(ctx context.Context, path string) error {
+	ctx = m.options.Context(ctx)
+	if err := m.buildCmd(ctx, ModulesCmd, m.basepath); err != nil {
 		return err
 	}
 	result, err := m.command.Output()
@@ -79,7 +103,13 @@ func (m *Poetry) HasModulesInstalled(path string) error {
 
 // Get Version ...
 func (m *Poetry) GetVersion() (string, error) {
-	if err := m.buildCmd(VersionCmd, m.basepath); err != nil {
+	return m.GetVersionContext(context.Background())
+}
+
+// GetVersionContext ...
+func (m *Poetry) GetVersionContext(ctx context.Context) (string, error) {
+	ctx = m.options.Context(ctx)
+	if err := m.buildCmd(ctx, VersionCmd, m.basepath); err != nil {
 		return "", err
 	}
 	version, err := m.command.Output()
@@ -92,12 +122,24 @@ func (m *Poetry) GetVersion() (string, error) {
 
 // Set Root Module ...
 func (m *Poetry) SetRootModule(path string) error {
+	return m.SetRootModuleContext(context.Background(), path)
+}
+
+// SetRootModuleContext ...
+func (m *Poetry) SetRootModuleContext(ctx context.Context, path string) error {
+	ctx = m.options.Context(ctx)
 	m.basepath = path
 	return nil
 }
 
 // Get Root Module ...
 func (m *Poetry) GetRootModule(path string) (*meta.Package, error) {
+	return m.GetRootModuleContext(context.Background(), path)
+}
+
+// GetRootModuleContext ...
+func (m *Poetry) GetRootModuleContext(ctx context.Context, path string) (*meta.Package, error) {
+	ctx = m.options.Context(ctx)
 	if m.rootModule == nil {
 		module := m.fetchRootModule()
 		m.rootModule = &module
@@ -107,34 +149,73 @@ func (m *Poetry) GetRootModule(path string) (*meta.Package, error) {
 
 // List Used Modules...
 func (m *Poetry) ListUsedModules(path string) ([]meta.Package, error) {
-	if err := m.LoadModuleList(path); err != nil {
+	return m.ListUsedModulesContext(context.Background(), path)
+}
+
+// ListUsedModulesContext ...
+func (m *Poetry) ListUsedModulesContext(ctx context.Context, path string) ([]meta.Package, error) {
+	ctx = m.options.Context(ctx)
+	if err := m.LoadModuleList(ctx, path); err != nil {
 		return m.allModules, errFailedToConvertModules
 	}
 
-	decoder := worker.NewMetadataDecoder(m.GetPackageDetails)
-	metainfo, err := decoder.ConvertMetadataToModules(m.pkgs, &m.allModules)
+	decoder := worker.NewMetadataDecoder(m.GetPackageDetailsContext)
+	metainfo, err := decoder.ConvertMetadataToModules(ctx, m.pkgs, &m.allModules)
 	if err != nil {
 		return m.allModules, err
 	}
 	m.metainfo = metainfo
+	meta.Sort(m.allModules)
 
 	return m.allModules, nil
 }
 
 // List Modules With Deps ...
 func (m *Poetry) ListModulesWithDeps(path string, globalSettingFile string) ([]meta.Package, error) {
-	modules, err := m.ListUsedModules(path)
-	if err != nil {
+	return m.ListModulesWithDepsContext(context.Background(), path, globalSettingFile)
+}
+
+// ListModulesWithDepsContext ...
+func (m *Poetry) ListModulesWithDepsContext(ctx context.Context, path string, globalSettingFile string) ([]meta.Package, error) {
+	ctx = m.options.Context(ctx)
+	if _, err := m.ListUsedModulesContext(ctx, path); err != nil {
 		return nil, err
 	}
-	_, _ = m.GetRootModule(path) // TODO: not getting the return need to check the entire function
-	if err := worker.BuildDependencyGraph(&m.allModules, &m.metainfo); err != nil {
+	_, _ = m.GetRootModuleContext(ctx, path) // TODO: not getting the return need to check the entire function
+	if err := worker.BuildDependencyGraph(ctx, &m.allModules, &m.metainfo); err != nil {
 		return nil, err
 	}
-	return modules, err
+	worker.ResolveScopes(m.allModules, devPackages(path))
+	return m.options.Filter(m.allModules), nil
 }
 
-func (m *Poetry) buildCmd(cmd command, path string) error {
+// devPackages returns the names of the packages poetry.lock lists in the dev category
+func devPackages(path string) []string {
+	data, err := os.ReadFile(filepath.Join(path, manifestLockFile))
+	if err != nil {
+		return nil
+	}
+
+	lock := struct {
+		Package []struct {
+			Name     string `toml:"name"`
+			Category string `toml:"category"`
+		} `toml:"package"`
+	}{}
+	if err := toml.Unmarshal(data, &lock); err != nil {
+		return nil
+	}
+
+	names := []string{}
+	for _, pkg := range lock.Package {
+		if pkg.Category == "dev" {
+			names = append(names, pkg.Name)
+		}
+	}
+	return names
+}
+
+func (m *Poetry) buildCmd(ctx context.Context, cmd command, path string) error {
 	cmdArgs := cmd.Parse()
 	if cmdArgs[0] != cmdName {
 		return errNoPipCommand
@@ -144,6 +225,7 @@ func (m *Poetry) buildCmd(cmd command, path string) error {
 		Name:      cmdArgs[0],
 		Args:      cmdArgs[1:],
 		Directory: path,
+		Context:   ctx,
 	})
 
 	m.command = command
@@ -152,9 +234,14 @@ func (m *Poetry) buildCmd(cmd command, path string) error {
 }
 
 func (m *Poetry) GetPackageDetails(packageName string) (string, error) {
+	return m.GetPackageDetailsContext(context.Background(), packageName)
+}
+
+func (m *Poetry) GetPackageDetailsContext(ctx context.Context, packageName string) (string, error) {
+	ctx = m.options.Context(ctx)
 	metatdataCmd := command(strings.ReplaceAll(string(MetadataCmd), placeholderPkgName, packageName))
 
-	_ = m.buildCmd(metatdataCmd, m.basepath)
+	_ = m.buildCmd(ctx, metatdataCmd, m.basepath)
 	result, err := m.command.Output()
 	if err != nil {
 		return "", err
@@ -163,8 +250,15 @@ func (m *Poetry) GetPackageDetails(packageName string) (string, error) {
 	return result, nil
 }
 
-func (m *Poetry) PushRootModuleToVenv() (bool, error) {
-	if err := m.buildCmd(InstallRootModuleCmd, m.basepath); err != nil {
+func (m *Poetry) PushRootModuleToVenv(ctx context.Context) (bool, error) {
+	// installing the root module may download its dependencies and changes
+	// the virtual environment, the root module is then only found when it is
+	// already installed
+	if helper.IsOffline(ctx) || helper.IsReadOnly(ctx) {
+		return false, nil
+	}
+
+	if err := m.buildCmd(ctx, InstallRootModuleCmd, m.basepath); err != nil {
 		return false, err
 	}
 	result, err := m.command.Output()
@@ -183,13 +277,13 @@ func (m *Poetry) markRootModue() {
 	}
 }
 
-func (m *Poetry) LoadModuleList(path string) error {
-	state, err := m.PushRootModuleToVenv()
+func (m *Poetry) LoadModuleList(ctx context.Context, path string) error {
+	state, err := m.PushRootModuleToVenv(ctx)
 	if err != nil && !state {
 		return err
 	}
 
-	err = m.buildCmd(ModulesCmd, m.basepath)
+	err = m.buildCmd(ctx, ModulesCmd, m.basepath)
 	if err != nil {
 		return err
 	}
@@ -3,6 +3,7 @@
 package pyenv
 
 import (
+	"context"
 	"errors"
 	"path/filepath"
 	"runtime"
@@ -43,6 +44,7 @@ type PyEnv struct {
 	metainfo   map[string]worker.Metadata
 	allModules []meta.Package
 	venv       string
+	options    plugin.Options
 }
 
 // New ...
@@ -53,10 +55,24 @@ func New() *PyEnv {
 			Slug:       "pyenv",
 			Manifest:   []string{manifestFile},
 			ModulePath: []string{},
+			ReadOnly:   true,
+			Capabilities: plugin.Capabilities{
+				Executables:      []plugin.Executable{{Name: "python", Constraint: ">=3"}},
+				Network:          plugin.NetworkOptional,
+				MutatesWorkspace: true,
+				InstalledModules: true,
+				Lockfiles:        []string{manifestFile},
+				TransitiveGraph:  true,
+			},
 		},
 	}
 }
 
+// Set Options configures how modules are listed
+func (m *PyEnv) SetOptions(opts plugin.Options) {
+	m.options = opts
+}
+
 // Get Metadata ...
 func (m *PyEnv) GetMetadata() plugin.Metadata {
 	return m.metadata
@@ -74,9 +90,15 @@ func (m *PyEnv) IsValid(path string) bool {
 
 // HasModulesInstalled
 func (m *PyEnv) HasModulesInstalled(path string) error {
+	return m.HasModulesInstalledContext(context.Background(), path)
+}
+
+// HasModulesInstalledContext
+func (m *PyEnv) HasModulesInstalledContext(ctx context.Context, path string) error {
+	ctx = m.options.Context(ctx)
 	dir := m.GetExecutableDir()
 	ModulesCmd := GetExecutableCommand(ModulesCmd)
-	if err := m.buildCmd(ModulesCmd, dir); err != nil {
+	if err := m.buildCmd(ctx, ModulesCmd, dir); err != nil {
 		return err
 	}
 	result, err := m.command.Output()
@@ -88,6 +110,12 @@ func (m *PyEnv) HasModulesInstalled(path string) error {
 
 // Get Version ...
 func (m *PyEnv) GetVersion() (string, error) {
+	return m.GetVersionContext(context.Background())
+}
+
+// GetVersionContext ...
+func (m *PyEnv) GetVersionContext(ctx context.Context) (string, error) {
+	ctx = m.options.Context(ctx)
 	version := "Python"
 	err := errVersionNotFound
 
@@ -95,7 +123,7 @@ func (m *PyEnv) GetVersion() (string, error) {
 	if runme {
 		dir := m.GetExecutableDir()
 		VersionCmd := GetExecutableCommand(VersionCmd)
-		if err = m.buildCmd(VersionCmd, dir); err != nil {
+		if err = m.buildCmd(ctx, VersionCmd, dir); err != nil {
 			return "", err
 		}
 		version, err = m.command.Output()
@@ -110,12 +138,24 @@ func (m *PyEnv) GetVersion() (string, error) {
 
 // Set Root Module ...
 func (m *PyEnv) SetRootModule(path string) error {
+	return m.SetRootModuleContext(context.Background(), path)
+}
+
+// SetRootModuleContext ...
+func (m *PyEnv) SetRootModuleContext(ctx context.Context, path string) error {
+	ctx = m.options.Context(ctx)
 	m.basepath = path
 	return nil
 }
 
 // Get Root Module ...
 func (m *PyEnv) GetRootModule(path string) (*meta.Package, error) {
+	return m.GetRootModuleContext(context.Background(), path)
+}
+
+// GetRootModuleContext ...
+func (m *PyEnv) GetRootModuleContext(ctx context.Context, path string) (*meta.Package, error) {
+	ctx = m.options.Context(ctx)
 	if m.rootModule == nil {
 		module := m.fetchRootModule()
 		m.rootModule = &module
@@ -125,34 +165,47 @@ func (m *PyEnv) GetRootModule(path string) (*meta.Package, error) {
 
 // List Used Modules...
 func (m *PyEnv) ListUsedModules(path string) ([]meta.Package, error) {
-	if err := m.LoadModuleList(path); err != nil {
+	return m.ListUsedModulesContext(context.Background(), path)
+}
+
+// ListUsedModulesContext ...
+func (m *PyEnv) ListUsedModulesContext(ctx context.Context, path string) ([]meta.Package, error) {
+	ctx = m.options.Context(ctx)
+	if err := m.LoadModuleList(ctx, path); err != nil {
 		return m.allModules, errFailedToConvertModules
 	}
 
-	decoder := worker.NewMetadataDecoder(m.GetPackageDetails)
-	metainfo, err := decoder.ConvertMetadataToModules(m.pkgs, &m.allModules)
+	decoder := worker.NewMetadataDecoder(m.GetPackageDetailsContext)
+	metainfo, err := decoder.ConvertMetadataToModules(ctx, m.pkgs, &m.allModules)
 	if err != nil {
 		return m.allModules, err
 	}
 	m.metainfo = metainfo
+	meta.Sort(m.allModules)
 
 	return m.allModules, nil
 }
 
 // List Modules With Deps ...
 func (m *PyEnv) ListModulesWithDeps(path string, globalSettingFile string) ([]meta.Package, error) {
-	modules, err := m.ListUsedModules(path)
-	if err != nil {
+	return m.ListModulesWithDepsContext(context.Background(), path, globalSettingFile)
+}
+
+// ListModulesWithDepsContext ...
+func (m *PyEnv) ListModulesWithDepsContext(ctx context.Context, path string, globalSettingFile string) ([]meta.Package, error) {
+	ctx = m.options.Context(ctx)
+	if _, err := m.ListUsedModulesContext(ctx, path); err != nil {
 		return nil, err
 	}
-	_, _ = m.GetRootModule(path)
-	if err := worker.BuildDependencyGraph(&m.allModules, &m.metainfo); err != nil {
+	_, _ = m.GetRootModuleContext(ctx, path)
+	if err := worker.BuildDependencyGraph(ctx, &m.allModules, &m.metainfo); err != nil {
 		return nil, err
 	}
-	return modules, err
+	worker.ResolveScopes(m.allModules, nil)
+	return m.options.Filter(m.allModules), nil
 }
 
-func (m *PyEnv) buildCmd(cmd Command, path string) error {
+func (m *PyEnv) buildCmd(ctx context.Context, cmd Command, path string) error {
 	cmdArgs := cmd.Parse()
 	if !strings.Contains(cmdArgs[0], cmdName) {
 		return errNoPipCommand
@@ -162,6 +215,7 @@ func (m *PyEnv) buildCmd(cmd Command, path string) error {
 		Name:      cmdArgs[0],
 		Args:      cmdArgs[1:],
 		Directory: path,
+		Context:   ctx,
 	})
 
 	m.command = command
@@ -177,11 +231,16 @@ func (m *PyEnv) GetExecutableDir() string {
 }
 
 func (m *PyEnv) GetPackageDetails(packageName string) (string, error) {
+	return m.GetPackageDetailsContext(context.Background(), packageName)
+}
+
+func (m *PyEnv) GetPackageDetailsContext(ctx context.Context, packageName string) (string, error) {
+	ctx = m.options.Context(ctx)
 	MetadataCmd := GetExecutableCommand(MetadataCmd)
 	MetadataCmd = Command(strings.ReplaceAll(string(MetadataCmd), placeholderPkgName, packageName))
 	dir := m.GetExecutableDir()
 
-	_ = m.buildCmd(MetadataCmd, dir)
+	_ = m.buildCmd(ctx, MetadataCmd, dir)
 	result, err := m.command.Output()
 	if err != nil {
 		return "", err
@@ -190,10 +249,17 @@ func (m *PyEnv) GetPackageDetails(packageName string) (string, error) {
 	return result, nil
 }
 
-func (m *PyEnv) PushRootModuleToVenv() (bool, error) {
+func (m *PyEnv) PushRootModuleToVenv(ctx context.Context) (bool, error) {
+	// installing the root module may download its dependencies and changes
+	// the virtual environment, the root module is then only found when it is
+	// already installed
+	if helper.IsOffline(ctx) || helper.IsReadOnly(ctx) {
+		return false, nil
+	}
+
 	dir := m.GetExecutableDir()
 	InstallRootModuleCmd := GetExecutableCommand(InstallRootModuleCmd)
-	if err := m.buildCmd(InstallRootModuleCmd, dir); err != nil {
+	if err := m.buildCmd(ctx, InstallRootModuleCmd, dir); err != nil {
 		return false, err
 	}
 	result, err := m.command.Output()
@@ -212,18 +278,18 @@ func (m *PyEnv) markRootModue() {
 	}
 }
 
-func (m *PyEnv) LoadModuleList(path string) error {
+func (m *PyEnv) LoadModuleList(ctx context.Context, path string) error {
 	var state bool
 	var err error
 
 	if worker.IsValidRootModule(path) {
-		state, err = m.PushRootModuleToVenv()
+		state, err = m.PushRootModuleToVenv(ctx)
 		if err != nil && !state {
 			return err
 		}
 		dir := m.GetExecutableDir()
 		ModulesCmd := GetExecutableCommand(ModulesCmd)
-		_ = m.buildCmd(ModulesCmd, dir)
+		_ = m.buildCmd(ctx, ModulesCmd, dir)
 		result, err := m.command.Output()
 		if err == nil && len(result) > 0 && worker.IsRequirementMeet(result) {
 			m.pkgs = worker.LoadModules(result, m.version)
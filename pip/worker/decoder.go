@@ -3,19 +3,20 @@
 package worker
 
 import (
-	"fmt"
+	"context"
+	"errors"
 	"regexp"
 	"strings"
 
 	"github.com/opensbom-generator/parsers/meta"
-	log "github.com/sirupsen/logrus"
+	"github.com/opensbom-generator/parsers/purl"
 
 	"github.com/opensbom-generator/parsers/internal/helper"
 )
 
 const pkgMetedataSeparator string = "---"
 
-type GetPackageDetailsFunc = func(PackageName string) (string, error)
+type GetPackageDetailsFunc = func(ctx context.Context, PackageName string) (string, error)
 
 type MetadataDecoder struct {
 	getPkgDetailsFunc GetPackageDetailsFunc
@@ -82,7 +83,7 @@ func getAddionalMataDataInfo(metadata *Metadata) {
 	metadata.WheelPath = BuildWheelPath(metadata.DistInfoPath)
 }
 
-func (d *MetadataDecoder) BuildMetadata(pkgs []Packages) (map[string]Metadata, []Metadata, error) {
+func (d *MetadataDecoder) BuildMetadata(ctx context.Context, pkgs []Packages) (map[string]Metadata, []Metadata, error) {
 	metainfo := map[string]Metadata{}
 	metaList := []Metadata{}
 	pkgIndex := map[string]int{}
@@ -95,7 +96,7 @@ func (d *MetadataDecoder) BuildMetadata(pkgs []Packages) (map[string]Metadata, [
 		pkgIndex[strings.ToLower(pkg.Name)] = i
 	}
 
-	allpkgsmetadatastr, err := d.getPkgDetailsFunc(pkgNameList)
+	allpkgsmetadatastr, err := d.getPkgDetailsFunc(ctx, pkgNameList)
 	if err != nil {
 		return nil, nil, errorUnableToFetchPackageMetadata
 	}
@@ -116,7 +117,7 @@ func (d *MetadataDecoder) BuildMetadata(pkgs []Packages) (map[string]Metadata, [
 		metadata.CPVersion = pkgs[pkgIndex[strings.ToLower(metadata.Name)]].CPVersion
 		generator, tag, err := GetWheelDistributionInfo(metadata)
 		if err != nil {
-			log.Warnf("Wheel distribution info not found for `%s` package.", metadata.Name)
+			helper.Report(ctx, meta.SeverityWarning, meta.CodeMissingDistInfo, metadata.Name, "Wheel distribution info not found for `%s` package.", metadata.Name)
 		}
 		metadata.Generator = generator
 		metadata.Tag = tag
@@ -127,7 +128,7 @@ func (d *MetadataDecoder) BuildMetadata(pkgs []Packages) (map[string]Metadata, [
 	return metainfo, metaList, nil
 }
 
-func (d *MetadataDecoder) BuildModule(metadata Metadata) meta.Package {
+func (d *MetadataDecoder) BuildModule(ctx context.Context, metadata Metadata) meta.Package {
 	var module meta.Package
 
 	// Prepare basic module info
@@ -136,20 +137,15 @@ func (d *MetadataDecoder) BuildModule(metadata Metadata) meta.Package {
 	module.Name = metadata.Name
 	module.Path = metadata.ProjectURL
 	module.LocalPath = metadata.LocalPath
-	module.PackageURL = metadata.PackageReleaseURL
+	module.PackageURL = purl.PyPI(metadata.Name, metadata.Version)
 	module.PackageHomePage = metadata.HomePage
+	module.Scope = meta.ScopeRuntime
 	module.PackageComment = metadata.Description
 
-	if (metadata.Root) && (len(metadata.HomePage) > 0) && metadata.HomePage != "None" {
-		module.PackageURL = metadata.HomePage
-	}
-
-	pypiData, err := GetPackageDataFromPyPi(metadata.PackageJSONURL)
-	if err != nil {
-		log.Warnf("Unable to get `%s` package details from pypi.org", metadata.Name)
-		if (len(metadata.HomePage) > 0) && (metadata.HomePage != "None") {
-			module.PackageURL = metadata.HomePage
-		}
+	pypiData, err := GetPackageDataFromPyPi(ctx, metadata.PackageJSONURL)
+	offline := errors.Is(err, helper.ErrOffline)
+	if err != nil && !offline {
+		helper.Report(ctx, meta.SeverityWarning, meta.CodeRegistryUnavailable, metadata.Name, "Unable to get `%s` package details from pypi.org: %v", metadata.Name, err)
 	}
 
 	// Prepare supplier contact
@@ -170,7 +166,7 @@ func (d *MetadataDecoder) BuildModule(metadata Metadata) meta.Package {
 
 	// Prepare checksum
 	checksum := GetChecksumeFromPyPiPackageData(pypiData, metadata)
-	module.Checksum = *checksum
+	module.AddChecksum(*checksum)
 
 	// Prepare download location
 	downloadURL := GetDownloadLocationFromPyPiPackageData(pypiData, metadata)
@@ -180,20 +176,13 @@ func (d *MetadataDecoder) BuildModule(metadata Metadata) meta.Package {
 			module.PackageDownloadLocation = metadata.HomePage
 		}
 	}
+	if len(module.PackageDownloadLocation) == 0 && offline {
+		module.PackageDownloadLocation = NoAssertion
+	}
 
 	// Prepare licenses
-	licensePkg, err := helper.GetLicenses(metadata.DistInfoPath)
-	if err == nil {
-		module.LicenseDeclared = helper.BuildLicenseDeclared(licensePkg.ID)
-		module.LicenseConcluded = helper.BuildLicenseConcluded(licensePkg.ID)
-		module.Copyright = helper.GetCopyright(licensePkg.ExtractedText)
-		module.CommentsLicense = licensePkg.Comments
-		if !helper.LicenseSPDXExists(licensePkg.ID) {
-			licensePkg.ID = fmt.Sprintf("LicenseRef-%s", licensePkg.ID)
-			licensePkg.ExtractedText = fmt.Sprintf("<text>%s</text>", licensePkg.ExtractedText)
-			module.OtherLicense = append(module.OtherLicense, *licensePkg)
-		}
-	}
+	helper.SetLicenses(ctx, &module, GetLicenseFromPyPiPackageData(pypiData, metadata), metadata.DistInfoPath)
+	module.Copyright = helper.ScanCopyright(metadata.DistInfoPath)
 
 	// Prepare dependency module
 	module.Packages = map[string]*meta.Package{}
@@ -201,8 +190,8 @@ func (d *MetadataDecoder) BuildModule(metadata Metadata) meta.Package {
 	return module
 }
 
-func (d *MetadataDecoder) GetMetadataList(pkgs []Packages) (map[string]Metadata, []Metadata, error) {
-	metainfo, metaList, err := d.BuildMetadata(pkgs)
+func (d *MetadataDecoder) GetMetadataList(ctx context.Context, pkgs []Packages) (map[string]Metadata, []Metadata, error) {
+	metainfo, metaList, err := d.BuildMetadata(ctx, pkgs)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -210,52 +199,66 @@ func (d *MetadataDecoder) GetMetadataList(pkgs []Packages) (map[string]Metadata,
 	return metainfo, metaList, nil
 }
 
-func (d *MetadataDecoder) ConvertMetadataToModules(pkgs []Packages, modules *[]meta.Package) (map[string]Metadata, error) {
-	metainfo, metaList, err := d.GetMetadataList(pkgs)
+func (d *MetadataDecoder) ConvertMetadataToModules(ctx context.Context, pkgs []Packages, modules *[]meta.Package) (map[string]Metadata, error) {
+	metainfo, metaList, err := d.GetMetadataList(ctx, pkgs)
 	if err != nil {
 		return nil, err
 	}
 
 	for _, metadata := range metaList {
-		mod := d.BuildModule(metadata)
+		mod := d.BuildModule(ctx, metadata)
 		*modules = append(*modules, mod)
 	}
 	return metainfo, nil
 }
 
-func BuildDependencyGraph(modules *[]meta.Package, pkgsMetadata *map[string]Metadata) error {
-	moduleMap := map[string]meta.Package{}
-
-	for _, module := range *modules {
-		moduleMap[strings.ToLower(module.Name)] = module
+// BuildDependencyGraph links the modules using the requirements listed in
+// their metadata, each module is listed once in the result
+func BuildDependencyGraph(ctx context.Context, modules *[]meta.Package, pkgsMetadata *map[string]Metadata) error {
+	graph := meta.NewGraph(purl.TypePyPI)
+	ids := map[string]meta.NodeID{}
+	for i := range *modules {
+		ids[strings.ToLower((*modules)[i].Name)] = graph.AddNode((*modules)[i])
 	}
 
 	for _, pkgmeta := range *pkgsMetadata {
-		mod := moduleMap[strings.ToLower(pkgmeta.Name)]
+		from, ok := ids[strings.ToLower(pkgmeta.Name)]
+		if !ok {
+			continue
+		}
 		for _, modname := range pkgmeta.Modules {
-			if depModule, ok := moduleMap[strings.ToLower(modname)]; ok {
-				mod.Packages[depModule.Name] = &meta.Package{
-					Version:          depModule.Version,
-					Name:             depModule.Name,
-					Path:             depModule.Path,
-					LocalPath:        depModule.LocalPath,
-					Supplier:         depModule.Supplier,
-					PackageURL:       depModule.PackageURL,
-					Checksum:         depModule.Checksum,
-					PackageHomePage:  depModule.PackageHomePage,
-					LicenseConcluded: depModule.LicenseConcluded,
-					LicenseDeclared:  depModule.LicenseDeclared,
-					CommentsLicense:  depModule.CommentsLicense,
-					OtherLicense:     depModule.OtherLicense,
-					Copyright:        depModule.Copyright,
-					PackageComment:   depModule.PackageComment,
-					Root:             depModule.Root,
-				}
-			} else {
-				log.Warnf("Unable to find `%s` required by `%s`", modname, pkgmeta.Name)
+			to, ok := ids[strings.ToLower(modname)]
+			if !ok {
+				helper.Report(ctx, meta.SeverityError, meta.CodeMissingDependency, pkgmeta.Name, "Unable to find `%s` required by `%s`", modname, pkgmeta.Name)
+				continue
+			}
+			if err := graph.AddEdge(from, to, meta.ScopeRuntime); err != nil {
+				return err
 			}
 		}
 	}
 
+	*modules = graph.Packages()
 	return nil
 }
+
+// ResolveScopes marks the modules named in development as development
+// dependencies, unless they are reachable from the root module at runtime.
+func ResolveScopes(modules []meta.Package, development []string) {
+	dev := map[string]bool{}
+	for _, name := range development {
+		dev[normalizeName(name)] = true
+	}
+
+	for i := range modules {
+		if !modules[i].Root && dev[normalizeName(modules[i].Name)] {
+			modules[i].Scope = meta.ScopeDevelopment
+		}
+	}
+	meta.ResolveScopes(modules)
+}
+
+// normalizeName returns the normalized form of a python package name
+func normalizeName(name string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(strings.ToLower(name), "_", "-"), ".", "-")
+}
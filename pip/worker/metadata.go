@@ -196,7 +196,8 @@ func SetMetadataToNoAssertion(metadata *Metadata, packagename string) {
 	metadata.Author = NoAssertion
 	metadata.AuthorEmail = NoAssertion
 	metadata.License = NoAssertion
-	metadata.LocalPath = NoAssertion
+	// the directory is unknown, meta leaves packages without one as they are
+	metadata.LocalPath = ""
 	metadata.Modules = []string{}
 }
 
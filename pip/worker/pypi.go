@@ -3,6 +3,7 @@
 package worker
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"io"
@@ -10,7 +11,9 @@ import (
 	"reflect"
 	"strings"
 
+	"github.com/opensbom-generator/parsers/internal/helper"
 	"github.com/opensbom-generator/parsers/meta"
+	"github.com/opensbom-generator/parsers/purl"
 )
 
 var (
@@ -82,29 +85,34 @@ var HashAlgoPickOrder []meta.HashAlgorithm = []meta.HashAlgorithm{
 	meta.HashAlgoMD2,
 }
 
-func makeGetRequest(packageJSONURL string) (*http.Response, error) {
-	url := "https://" + packageJSONURL
+func makeGetRequest(ctx context.Context, packageJSONURL string) (*http.Response, error) {
+	// offline, the client answers from its cache or fails with ErrOffline
+	client := helper.ClientFrom(ctx)
+	url := client.MirrorURL(purl.TypePyPI, "https://"+PackageURL, "https://"+packageJSONURL)
 
-	request, _ := http.NewRequest("GET", url, nil)
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
 	request.Header.Set("Accept", "application/json")
 
-	client := &http.Client{}
 	response, err := client.Do(request)
 	if err != nil {
 		return nil, err
 	}
 
 	if response.StatusCode != http.StatusOK {
+		response.Body.Close()
 		return nil, errorPypiCouldNotFetchPkgData
 	}
 
-	return response, err
+	return response, nil
 }
 
-func GetPackageDataFromPyPi(packageJSONURL string) (PypiPackageData, error) {
+func GetPackageDataFromPyPi(ctx context.Context, packageJSONURL string) (PypiPackageData, error) {
 	packageInfo := PypiPackageData{}
 
-	response, err := makeGetRequest(packageJSONURL)
+	response, err := makeGetRequest(ctx, packageJSONURL)
 	if err != nil {
 		return packageInfo, err
 	}
@@ -177,26 +185,24 @@ func GetPackageSDistInfo(distInfo PypiPackageDistInfo, generator string) (PypiPa
 }
 
 func GetChecksumeFromPyPiPackageData(pkgData PypiPackageData, metadata Metadata) *meta.Checksum {
+	// without a distribution digest the checksum is one of the name
 	checksum := meta.Checksum{
 		Algorithm: meta.HashAlgoSHA1,
 		Content:   []byte(pkgData.Info.Name),
+		Synthetic: true,
 	}
 
 	for _, packageDistInfo := range pkgData.Urls {
 		distInfo, status := GetPackageBDistWheelInfo(packageDistInfo, metadata.Generator, metadata.Tag, metadata.CPVersion)
 		if status {
 			algo, value := GetHighestOrderHashData(distInfo.Digests)
-			checksum.Algorithm = algo
-			checksum.Value = value
-			return &checksum
+			return &meta.Checksum{Algorithm: algo, Value: value}
 		}
 
 		distInfo, status = GetPackageSDistInfo(packageDistInfo, "sdist")
 		if status {
 			algo, value := GetHighestOrderHashData(distInfo.Digests)
-			checksum.Algorithm = algo
-			checksum.Value = value
-			return &checksum
+			return &meta.Checksum{Algorithm: algo, Value: value}
 		}
 	}
 
@@ -218,3 +224,22 @@ func GetDownloadLocationFromPyPiPackageData(pkgData PypiPackageData, metadata Me
 
 	return ""
 }
+
+// GetLicenseFromPyPiPackageData returns the license declared in the package
+// metadata, or else on PyPI. Setuptools writes UNKNOWN when no license is
+// declared and some packages put the whole license text in the field, which
+// does not declare an expression either.
+func GetLicenseFromPyPiPackageData(pkgData PypiPackageData, metadata Metadata) string {
+	for _, value := range []string{metadata.License, pkgData.Info.License} {
+		value = strings.TrimSpace(value)
+		switch {
+		case value == "", value == NoAssertion, strings.EqualFold(value, "UNKNOWN"), strings.EqualFold(value, "None"):
+			continue
+		case strings.Contains(value, "\n"):
+			continue
+		}
+		return value
+	}
+
+	return ""
+}
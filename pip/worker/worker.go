@@ -6,7 +6,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"log"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -64,7 +63,11 @@ func HasPyvenvCfg(path string) bool {
 func ScanPyvenvCfg(files *string, folderpath *string) filepath.WalkFunc {
 	return func(path string, info os.FileInfo, err error) error {
 		if err != nil {
-			log.Fatal(err)
+			// a missing root stops the walk, unreadable directories are skipped
+			if info == nil {
+				return err
+			}
+			return nil
 		}
 		if info.IsDir() {
 			if HasPyvenvCfg(path) {
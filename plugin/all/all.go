@@ -0,0 +1,22 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package all registers every built-in parser in plugin.DefaultRegistry.
+// Import it for its side effects:
+//
+//	import _ "github.com/opensbom-generator/parsers/plugin/all"
+package all
+
+import (
+	// built-in plugins register themselves on init
+	_ "github.com/opensbom-generator/parsers/cargo"
+	_ "github.com/opensbom-generator/parsers/composer"
+	_ "github.com/opensbom-generator/parsers/gem"
+	_ "github.com/opensbom-generator/parsers/go"
+	_ "github.com/opensbom-generator/parsers/gradle"
+	_ "github.com/opensbom-generator/parsers/maven"
+	_ "github.com/opensbom-generator/parsers/npm"
+	_ "github.com/opensbom-generator/parsers/nuget"
+	_ "github.com/opensbom-generator/parsers/pip"
+	_ "github.com/opensbom-generator/parsers/swift"
+	_ "github.com/opensbom-generator/parsers/yarn"
+)
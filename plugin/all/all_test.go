@@ -0,0 +1,32 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package all
+
+import (
+	"testing"
+
+	"github.com/opensbom-generator/parsers/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPluginsAreContextAware(t *testing.T) {
+	for _, slug := range plugin.DefaultRegistry.Slugs() {
+		p, err := plugin.DefaultRegistry.Get(slug)
+		require.NoError(t, err)
+		_, ok := p.(plugin.PluginV2)
+		assert.True(t, ok, slug)
+	}
+}
+
+func TestPluginsDeclareCapabilities(t *testing.T) {
+	for _, slug := range plugin.DefaultRegistry.Slugs() {
+		p, err := plugin.DefaultRegistry.Get(slug)
+		require.NoError(t, err)
+		capabilities := p.GetMetadata().Capabilities
+		assert.NotEmpty(t, capabilities.Executables, slug)
+		for _, e := range capabilities.Executables {
+			assert.NotEmpty(t, e.Name, slug)
+		}
+	}
+}
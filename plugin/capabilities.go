@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"context"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"golang.org/x/mod/semver"
+
+	"github.com/opensbom-generator/parsers/internal/helper"
+)
+
+// NetworkUse tells how a plugin depends on the network
+type NetworkUse int
+
+const (
+	// NetworkNone plugins never reach the network
+	NetworkNone NetworkUse = iota
+	// NetworkOptional plugins download metadata, checksums or missing
+	// packages when online and report what they cannot resolve as
+	// NOASSERTION when offline
+	NetworkOptional
+	// NetworkRequired plugins fail when offline
+	NetworkRequired
+)
+
+func (n NetworkUse) String() string {
+	switch n {
+	case NetworkNone:
+		return "none"
+	case NetworkOptional:
+		return "optional"
+	case NetworkRequired:
+		return "required"
+	}
+
+	return "unknown"
+}
+
+// Capabilities describes what a plugin needs to run and what it produces,
+// so callers can pick the cheapest plugin able to scan a directory
+type Capabilities struct {
+	// Executables lists the tools the plugin runs
+	Executables []Executable
+	Network     NetworkUse
+	// MutatesWorkspace is true when the plugin writes to the project or
+	// installs packages outside read-only mode
+	MutatesWorkspace bool
+	// InstalledModules is true when the dependencies must be installed in
+	// the project, see HasModulesInstalled
+	InstalledModules bool
+	// Lockfiles lists the lock files the dependency versions are read from
+	Lockfiles []string
+	// TransitiveGraph is true when ListModulesWithDeps reports every
+	// dependency with its own dependencies, false when it stops at the
+	// direct dependencies of the root module
+	TransitiveGraph bool
+}
+
+// Executable is a tool run by a plugin
+type Executable struct {
+	Name string
+	// Constraint is the space separated list of versions the tool must
+	// satisfy, e.g. ">=1.41 <2", empty when any version works
+	Constraint string
+	// Wrapper is a script of the project used instead of Name when it
+	// exists, e.g. gradlew
+	Wrapper string
+	// Optional tools are only used by GetVersion or to complete the
+	// results, the modules are listed without them
+	Optional bool
+	// VersionPattern finds the version of the tool in the output of
+	// GetVersion, the first submatch being the version when there is one.
+	// nil takes the first number of the output.
+	VersionPattern *regexp.Regexp
+}
+
+// Available reports whether the tool, or its wrapper in dir, can be run.
+// ctx is usually built by Options.Context so the lookup goes through the
+// runner of the options.
+func (e Executable) Available(ctx context.Context, dir string) bool {
+	if e.Wrapper != "" && helper.Exists(filepath.Join(dir, e.Wrapper)) {
+		return true
+	}
+
+	_, err := helper.LookPath(ctx, e.Name)
+	return err == nil
+}
+
+var versionPattern = regexp.MustCompile(`\d+(\.\d+){0,2}`)
+
+// Satisfies reports whether the version found in version, e.g. the output
+// of GetVersion, meets the constraint
+func (e Executable) Satisfies(version string) bool {
+	if e.Constraint == "" {
+		return true
+	}
+
+	v := canonical(e.findVersion(version))
+	if v == "" {
+		return false
+	}
+	for _, c := range strings.Fields(e.Constraint) {
+		op := c[:len(c)-len(strings.TrimLeft(c, "<>=!"))]
+		bound := canonical(strings.TrimPrefix(c, op))
+		if bound == "" {
+			return false
+		}
+		cmp := semver.Compare(v, bound)
+		ok := false
+		switch op {
+		case ">=":
+			ok = cmp >= 0
+		case ">":
+			ok = cmp > 0
+		case "<=":
+			ok = cmp <= 0
+		case "<":
+			ok = cmp < 0
+		case "=", "==", "":
+			ok = cmp == 0
+		case "!=":
+			ok = cmp != 0
+		}
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// findVersion returns the version of the tool found in output
+func (e Executable) findVersion(output string) string {
+	if e.VersionPattern == nil {
+		return versionPattern.FindString(output)
+	}
+
+	m := e.VersionPattern.FindStringSubmatch(output)
+	switch {
+	case m == nil:
+		return ""
+	case len(m) > 1:
+		return m[1]
+	}
+
+	return m[0]
+}
+
+func canonical(version string) string {
+	if version == "" {
+		return ""
+	}
+
+	return semver.Canonical("v" + version)
+}
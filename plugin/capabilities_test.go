@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecutableSatisfies(t *testing.T) {
+	for _, tc := range []struct {
+		constraint string
+		version    string
+		want       bool
+	}{
+		{"", "anything", true},
+		{">=1.16", "go version go1.21.0 linux/amd64", true},
+		{">=1.16", "go version go1.15.2 linux/amd64", false},
+		{">=1.41", "cargo 1.41.0 (626f0f40e 2019-12-03)", true},
+		{"<2", "1.22.19", true},
+		{"<2", "3.6.1", false},
+		{">=3", "Python 3.11.2", true},
+		{">=3 <3.12", "Python 3.12.0", false},
+		{"=5.9", "5.9", true},
+		{"!=5.9", "5.9.0", false},
+		{">=1", "no version", false},
+	} {
+		e := Executable{Name: "tool", Constraint: tc.constraint}
+		assert.Equal(t, tc.want, e.Satisfies(tc.version), "%q %q", tc.constraint, tc.version)
+	}
+}
+
+func TestExecutableVersionPattern(t *testing.T) {
+	e := Executable{Name: "swift", Constraint: ">=5.5", VersionPattern: regexp.MustCompile(`Swift version (\d+(?:\.\d+){0,2})`)}
+
+	assert.True(t, e.Satisfies("swift-driver version: 1.87.1 Apple Swift version 5.9 (swiftlang-5.9.0.128.108 clang-1500.0.40.1)"))
+	assert.True(t, e.Satisfies("Swift version 5.8.1 (swift-5.8.1-RELEASE)"))
+	assert.False(t, e.Satisfies("Swift version 5.4 (swift-5.4-RELEASE)"))
+	assert.False(t, e.Satisfies("swift-driver version: 1.87.1"))
+
+	// without submatch the whole match is the version
+	e = Executable{Name: "tool", Constraint: ">=2", VersionPattern: regexp.MustCompile(`\b2\.\d+`)}
+	assert.True(t, e.Satisfies("tool 1.0 using 2.5"))
+}
+
+func TestExecutableAvailable(t *testing.T) {
+	dir := t.TempDir()
+	ctx := Options{Runner: &Replayer{Dir: dir}}.Context(context.Background())
+
+	assert.False(t, Executable{Name: "missing-tool"}.Available(ctx, dir))
+	assert.True(t, Executable{Name: "missing-tool", Wrapper: "capabilities_test.go"}.Available(ctx, "."))
+}
@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"context"
+
+	"github.com/opensbom-generator/parsers/meta"
+)
+
+// PluginV2 extends Plugin with methods taking a context. Cancelling the
+// context or reaching its deadline stops the package manager commands and
+// HTTP requests started by the call. The Plugin methods behave like their
+// context aware counterparts called with context.Background().
+type PluginV2 interface {
+	Plugin
+	SetRootModuleContext(ctx context.Context, path string) error
+	GetVersionContext(ctx context.Context) (string, error)
+	GetRootModuleContext(ctx context.Context, path string) (*meta.Package, error)
+	ListUsedModulesContext(ctx context.Context, path string) ([]meta.Package, error)
+	ListModulesWithDepsContext(ctx context.Context, path string, globalSettingFile string) ([]meta.Package, error)
+	HasModulesInstalledContext(ctx context.Context, path string) error
+}
+
+// WithContext returns p as a PluginV2. Plugins only implementing Plugin are
+// wrapped: the context is checked before each call, but a call that already
+// started runs to completion.
+func WithContext(p Plugin) PluginV2 {
+	if v2, ok := p.(PluginV2); ok {
+		return v2
+	}
+
+	return &contextAdapter{Plugin: p}
+}
+
+// contextAdapter implements PluginV2 on top of a Plugin
+type contextAdapter struct {
+	Plugin
+}
+
+// SetOptions forwards the options to the wrapped plugin
+func (a *contextAdapter) SetOptions(opts Options) {
+	Configure(a.Plugin, opts)
+}
+
+func (a *contextAdapter) SetRootModuleContext(ctx context.Context, path string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return a.SetRootModule(path)
+}
+
+func (a *contextAdapter) GetVersionContext(ctx context.Context) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	return a.GetVersion()
+}
+
+func (a *contextAdapter) GetRootModuleContext(ctx context.Context, path string) (*meta.Package, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return a.GetRootModule(path)
+}
+
+func (a *contextAdapter) ListUsedModulesContext(ctx context.Context, path string) ([]meta.Package, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return a.ListUsedModules(path)
+}
+
+func (a *contextAdapter) ListModulesWithDepsContext(ctx context.Context, path string, globalSettingFile string) ([]meta.Package, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return a.ListModulesWithDeps(path, globalSettingFile)
+}
+
+func (a *contextAdapter) HasModulesInstalledContext(ctx context.Context, path string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return a.HasModulesInstalled(path)
+}
@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type configurablePlugin struct {
+	fakePlugin
+	options Options
+}
+
+func (c *configurablePlugin) SetOptions(opts Options) { c.options = opts }
+
+func TestWithContext(t *testing.T) {
+	p := &configurablePlugin{}
+	v2 := WithContext(p)
+	assert.Same(t, v2, WithContext(v2))
+
+	_, err := v2.ListModulesWithDepsContext(context.Background(), ".", "")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, v2.SetRootModuleContext(ctx, "."), context.Canceled)
+	_, err = v2.GetVersionContext(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = v2.ListUsedModulesContext(ctx, ".")
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// options reach the wrapped plugin
+	assert.True(t, Configure(v2, Options{RuntimeOnly: true}))
+	assert.True(t, p.options.RuntimeOnly)
+}
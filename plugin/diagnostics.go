@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"context"
+
+	"github.com/opensbom-generator/parsers/internal/helper"
+	"github.com/opensbom-generator/parsers/meta"
+)
+
+// Diagnostics collects the problems reported by the plugins, such as a
+// dependency that cannot be found or a license that cannot be detected
+type Diagnostics = helper.Diagnostics
+
+// WithDiagnostics returns a copy of ctx collecting the problems reported by
+// the plugin calls made with it. Problems reported without a collector are
+// logged.
+func WithDiagnostics(ctx context.Context) (context.Context, *Diagnostics) {
+	return helper.WithDiagnostics(ctx)
+}
+
+// GetRootModule returns the root module of p with the problems reported
+// while reading it
+func GetRootModule(ctx context.Context, p Plugin, path string) (*meta.Package, meta.Diagnostics, error) {
+	ctx, d := WithDiagnostics(ctx)
+	pkg, err := WithContext(p).GetRootModuleContext(ctx, path)
+	return pkg, d.List(), err
+}
+
+// ListUsedModules returns the modules used by the project with the problems
+// reported while listing them
+func ListUsedModules(ctx context.Context, p Plugin, path string) ([]meta.Package, meta.Diagnostics, error) {
+	ctx, d := WithDiagnostics(ctx)
+	pkgs, err := WithContext(p).ListUsedModulesContext(ctx, path)
+	return pkgs, d.List(), err
+}
+
+// ListModulesWithDeps returns the modules of the project and their
+// dependencies with the problems reported while listing them
+func ListModulesWithDeps(ctx context.Context, p Plugin, path string, globalSettingFile string) ([]meta.Package, meta.Diagnostics, error) {
+	ctx, d := WithDiagnostics(ctx)
+	pkgs, err := WithContext(p).ListModulesWithDepsContext(ctx, path, globalSettingFile)
+	return pkgs, d.List(), err
+}
@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opensbom-generator/parsers/internal/helper"
+	"github.com/opensbom-generator/parsers/meta"
+)
+
+// reportingPlugin reports a missing dependency while listing its modules
+type reportingPlugin struct {
+	contextAdapter
+}
+
+func (r *reportingPlugin) ListModulesWithDepsContext(ctx context.Context, path string, globalSettingFile string) ([]meta.Package, error) {
+	helper.Report(ctx, meta.SeverityError, meta.CodeMissingDependency, "app", "Unable to find `%s` required by `%s`", "lib", "app")
+	return []meta.Package{{Name: "app"}}, nil
+}
+
+func TestListModulesWithDepsDiagnostics(t *testing.T) {
+	p := &reportingPlugin{contextAdapter{Plugin: &fakePlugin{}}}
+
+	pkgs, diagnostics, err := ListModulesWithDeps(context.Background(), p, ".", "")
+	require.NoError(t, err)
+	assert.Len(t, pkgs, 1)
+	assert.Equal(t, meta.Diagnostics{{
+		Severity: meta.SeverityError,
+		Code:     meta.CodeMissingDependency,
+		Package:  "app",
+		Message:  "Unable to find `lib` required by `app`",
+	}}, diagnostics)
+
+	// each call collects its own diagnostics
+	_, diagnostics, err = ListUsedModules(context.Background(), p, ".")
+	require.NoError(t, err)
+	assert.Empty(t, diagnostics)
+}
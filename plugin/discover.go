@@ -0,0 +1,252 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/opensbom-generator/parsers/meta"
+)
+
+// DefaultIgnore lists the directories skipped by Discover when no ignore
+// patterns are given: version control data, vendored and installed
+// dependencies and build outputs
+var DefaultIgnore = []string{".git", ".build", ".venv", "node_modules", "target", "vendor"}
+
+// DiscoverOptions tunes how projects are searched under a root directory
+type DiscoverOptions struct {
+	// Ignore lists the directories not searched, in filepath.Match syntax.
+	// Patterns without a slash are matched against the directory name, the
+	// others against its slash separated path relative to the root. nil
+	// uses DefaultIgnore, an empty slice searches every directory.
+	Ignore []string
+	// MaxDepth is how many levels below the root are searched, 0 means no
+	// limit
+	MaxDepth int
+}
+
+// Project is a directory holding the manifest of at least one plugin
+type Project struct {
+	// Path is the slash separated path of the project relative to the root,
+	// "." for the root itself
+	Path    string
+	Matches []Match
+}
+
+// Discover walks root and returns every directory matched by a registered
+// plugin, sorted by path. Symbolic links are not followed.
+func (r *Registry) Discover(root string, opts DiscoverOptions) ([]Project, error) {
+	ignore := opts.Ignore
+	if ignore == nil {
+		ignore = DefaultIgnore
+	}
+	for _, pattern := range ignore {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid ignore pattern %q: %w", pattern, err)
+		}
+	}
+
+	projects := []Project{}
+	err := filepath.WalkDir(root, func(dir string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, dir)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel != "." {
+			if ignored(ignore, rel) {
+				return filepath.SkipDir
+			}
+			if opts.MaxDepth > 0 && strings.Count(rel, "/") >= opts.MaxDepth {
+				return filepath.SkipDir
+			}
+		}
+
+		if matches := r.Matches(dir); len(matches) > 0 {
+			projects = append(projects, Project{Path: rel, Matches: matches})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("discovering projects in %s: %w", root, err)
+	}
+
+	return projects, nil
+}
+
+func ignored(patterns []string, rel string) bool {
+	name := path.Base(rel)
+	for _, pattern := range patterns {
+		target := name
+		if strings.Contains(pattern, "/") {
+			target = rel
+		}
+		if ok, _ := path.Match(pattern, target); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ScanOptions tunes Scan
+type ScanOptions struct {
+	DiscoverOptions
+	// Options are passed to every plugin run
+	Options Options
+	// GlobalSettingFile is passed to ListModulesWithDeps
+	GlobalSettingFile string
+}
+
+// ProjectResult is what a plugin found in a project
+type ProjectResult struct {
+	// Path is the path of the project relative to the scan root
+	Path string
+	// Slug is the slug of the plugin run
+	Slug     string
+	Packages []meta.Package
+	// Diagnostics are the problems reported by the plugin
+	Diagnostics meta.Diagnostics
+	// Err is set when the plugin failed, Packages is then empty
+	Err error
+}
+
+// ProjectRef identifies a plugin run on a project
+type ProjectRef struct {
+	Path string
+	Slug string
+}
+
+// ScannedPackage is a package of a scan with the projects it was found in
+type ScannedPackage struct {
+	meta.Package
+	Projects []ProjectRef
+}
+
+// ScanResult merges the packages of every project found under a root
+type ScanResult struct {
+	Projects []ProjectResult
+	// Packages lists every package once, in the order they were first
+	// found. Packages are the same when their package URLs are, or when
+	// they have none, when the plugin, name and version are.
+	Packages []ScannedPackage
+}
+
+// Failed returns the projects a plugin failed on
+func (s *ScanResult) Failed() []ProjectResult {
+	failed := []ProjectResult{}
+	for i := range s.Projects {
+		if s.Projects[i].Err != nil {
+			failed = append(failed, s.Projects[i])
+		}
+	}
+
+	return failed
+}
+
+// Scan discovers the projects under root and runs every plugin matching
+// each of them. A plugin failing on a project is recorded in its
+// ProjectResult and does not stop the scan.
+func (r *Registry) Scan(ctx context.Context, root string, opts ScanOptions) (*ScanResult, error) {
+	projects, err := r.Discover(root, opts.DiscoverOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ScanResult{}
+	index := map[string]int{}
+	for _, project := range projects {
+		for _, match := range project.Matches {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			dir := filepath.Join(root, filepath.FromSlash(project.Path))
+			pkgs, diagnostics, err := scanProject(ctx, match.Plugin, dir, opts)
+			result.Projects = append(result.Projects, ProjectResult{
+				Path:        project.Path,
+				Slug:        match.Slug,
+				Packages:    pkgs,
+				Diagnostics: diagnostics,
+				Err:         err,
+			})
+
+			ref := ProjectRef{Path: project.Path, Slug: match.Slug}
+			for i := range pkgs {
+				key := pkgs[i].PackageURL
+				if key == "" {
+					key = match.Slug + ":" + pkgs[i].Name + "@" + pkgs[i].Version
+				}
+				if at, ok := index[key]; ok {
+					result.Packages[at].merge(pkgs[i], ref)
+					continue
+				}
+				index[key] = len(result.Packages)
+				scanned := ScannedPackage{Package: pkgs[i]}
+				// merge into a copy of the dependencies to leave the
+				// project result untouched
+				scanned.Packages = nil
+				scanned.merge(pkgs[i], ref)
+				result.Packages = append(result.Packages, scanned)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func scanProject(ctx context.Context, p Plugin, dir string, opts ScanOptions) ([]meta.Package, meta.Diagnostics, error) {
+	Configure(p, opts.Options)
+	v2 := WithContext(p)
+	if err := v2.SetRootModuleContext(ctx, dir); err != nil {
+		return nil, nil, err
+	}
+	if err := v2.HasModulesInstalledContext(ctx, dir); err != nil {
+		return nil, nil, err
+	}
+
+	return ListModulesWithDeps(ctx, v2, dir, opts.GlobalSettingFile)
+}
+
+// merge records that pkg was also found by ref, keeping the dependencies
+// found by every project
+func (s *ScannedPackage) merge(pkg meta.Package, ref ProjectRef) {
+	s.Root = s.Root || pkg.Root
+	for name, dep := range pkg.Packages {
+		if s.Packages == nil {
+			s.Packages = map[string]*meta.Package{}
+		}
+		if _, ok := s.Packages[name]; !ok {
+			s.Packages[name] = dep
+		}
+	}
+	for _, p := range s.Projects {
+		if p == ref {
+			return
+		}
+	}
+	s.Projects = append(s.Projects, ref)
+}
+
+// Discover runs Registry.Discover on the default registry
+func Discover(root string, opts DiscoverOptions) ([]Project, error) {
+	return DefaultRegistry.Discover(root, opts)
+}
+
+// Scan runs Registry.Scan on the default registry
+func Scan(ctx context.Context, root string, opts ScanOptions) (*ScanResult, error) {
+	return DefaultRegistry.Scan(ctx, root, opts)
+}
@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opensbom-generator/parsers/meta"
+)
+
+// projectPlugin lists the directory it runs in as root module depending on
+// a package shared by every project
+type projectPlugin struct {
+	fakePlugin
+}
+
+func (p *projectPlugin) ListModulesWithDeps(path string, _ string) ([]meta.Package, error) {
+	if filepath.Base(path) == "broken" {
+		return nil, errors.New("broken manifest")
+	}
+
+	shared := meta.Package{Name: "shared", Version: "1.0.0", PackageURL: "pkg:generic/shared@1.0.0"}
+	return []meta.Package{
+		{Name: filepath.Base(path), Root: true, Packages: map[string]*meta.Package{"shared": &shared}},
+		shared,
+	}, nil
+}
+
+// manifestPlugin lists the root module named in the manifest of the
+// project it runs in
+type manifestPlugin struct {
+	fakePlugin
+}
+
+func (p *manifestPlugin) ListModulesWithDeps(path string, _ string) ([]meta.Package, error) {
+	name, err := os.ReadFile(filepath.Join(path, "m.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	return []meta.Package{{Name: string(name), Root: true}}, nil
+}
+
+func writeTree(t *testing.T, files ...string) string {
+	root := t.TempDir()
+	for _, file := range files {
+		file = filepath.Join(root, filepath.FromSlash(file))
+		require.NoError(t, os.MkdirAll(filepath.Dir(file), 0o755))
+		require.NoError(t, os.WriteFile(file, nil, 0o600))
+	}
+
+	return root
+}
+
+func discoverRegistry(t *testing.T) *Registry {
+	r := NewRegistry()
+	require.NoError(t, r.Register("a", func() Plugin { return &projectPlugin{fakePlugin{manifest: []string{"a.json"}}} }))
+	require.NoError(t, r.Register("b", func() Plugin { return &projectPlugin{fakePlugin{manifest: []string{"b.json"}}} }))
+
+	return r
+}
+
+func projectPaths(projects []Project) []string {
+	paths := []string{}
+	for _, p := range projects {
+		paths = append(paths, p.Path)
+	}
+
+	return paths
+}
+
+func TestDiscover(t *testing.T) {
+	root := writeTree(t,
+		"a.json",
+		"services/api/a.json",
+		"services/api/b.json",
+		"services/api/node_modules/dep/a.json",
+		"web/b.json",
+		"web/vendor/dep/b.json",
+		"tools/deep/nested/a.json",
+	)
+	r := discoverRegistry(t)
+
+	projects, err := r.Discover(root, DiscoverOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{".", "services/api", "tools/deep/nested", "web"}, projectPaths(projects))
+	require.Len(t, projects[1].Matches, 2)
+
+	// custom patterns replace the default ones
+	projects, err = r.Discover(root, DiscoverOptions{Ignore: []string{"tools/*"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{".", "services/api", "services/api/node_modules/dep", "web", "web/vendor/dep"}, projectPaths(projects))
+
+	projects, err = r.Discover(root, DiscoverOptions{MaxDepth: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []string{".", "services/api", "web"}, projectPaths(projects))
+
+	_, err = r.Discover(root, DiscoverOptions{Ignore: []string{"["}})
+	assert.Error(t, err)
+}
+
+func TestScan(t *testing.T) {
+	root := writeTree(t,
+		"api/a.json",
+		"broken/a.json",
+		"web/b.json",
+		"web/node_modules/dep/b.json",
+	)
+
+	result, err := discoverRegistry(t).Scan(context.Background(), root, ScanOptions{})
+	require.NoError(t, err)
+
+	require.Len(t, result.Projects, 3)
+	failed := result.Failed()
+	require.Len(t, failed, 1)
+	assert.Equal(t, "broken", failed[0].Path)
+
+	names := []string{}
+	for _, p := range result.Packages {
+		names = append(names, p.Name)
+	}
+	assert.Equal(t, []string{"api", "shared", "web"}, names)
+	assert.Equal(t, []ProjectRef{{Path: "api", Slug: "a"}, {Path: "web", Slug: "b"}}, result.Packages[1].Projects)
+	assert.True(t, result.Packages[0].Root)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = discoverRegistry(t).Scan(ctx, root, ScanOptions{})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestScanNestedProjects(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "m.json"), []byte("outer"), 0o600))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "packages", "inner"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "packages", "inner", "m.json"), []byte("inner"), 0o600))
+
+	r := NewRegistry()
+	require.NoError(t, r.Register("m", func() Plugin { return &manifestPlugin{fakePlugin{manifest: []string{"m.json"}}} }))
+
+	// each project reads its own manifest, not the one of the working
+	// directory
+	result, err := r.Scan(context.Background(), root, ScanOptions{})
+	require.NoError(t, err)
+	require.Empty(t, result.Failed())
+	require.Len(t, result.Projects, 2)
+	assert.Equal(t, ".", result.Projects[0].Path)
+	assert.Equal(t, "outer", result.Projects[0].Packages[0].Name)
+	assert.Equal(t, "packages/inner", result.Projects[1].Path)
+	assert.Equal(t, "inner", result.Projects[1].Packages[0].Name)
+}
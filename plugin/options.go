@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"context"
+
+	"github.com/opensbom-generator/parsers/internal/helper"
+	"github.com/opensbom-generator/parsers/meta"
+)
+
+// Options tunes how a plugin lists modules
+type Options struct {
+	// RuntimeOnly excludes development, test, build, optional and peer
+	// dependencies from ListModulesWithDeps
+	RuntimeOnly bool
+	// Offline forbids network access, plugins rely on local caches and on
+	// disk metadata and report what they cannot resolve as NOASSERTION
+	Offline bool
+	// ReadOnly forbids changes to the scanned project: no file of the
+	// project is written and no package is installed. Plugins skip what is
+	// only a convenience and fail with ErrReadOnly when they need to write.
+	// Package manager caches outside the project are still filled, combine
+	// with Offline to prevent downloads.
+	ReadOnly bool
+	// LicenseThreshold is the confidence, from 0 to 1, above which the
+	// licenses detected in the license files of a package are kept, 0 uses
+	// the default of 0.85
+	LicenseThreshold float32
+	// HTTP is the client plugins send their requests with, nil uses a client
+	// configured from the environment
+	HTTP *HTTPClient
+	// Runner starts the package manager commands, nil runs them on the host
+	Runner Runner
+}
+
+// HTTPClient is the HTTP client shared by the plugins, it rewrites registry
+// URLs to the configured mirrors, authenticates and retries requests
+type HTTPClient = helper.Client
+
+// HTTPOptions configures an HTTPClient
+type HTTPOptions = helper.ClientOptions
+
+// HTTPCredentials authenticates the requests sent to a host
+type HTTPCredentials = helper.Credentials
+
+// NewHTTPClient returns a client configured by opts, unset options are read
+// from the environment
+func NewHTTPClient(opts HTTPOptions) (*HTTPClient, error) {
+	return helper.NewClient(opts)
+}
+
+// Runner starts the commands of the plugins, a Recorder saves them as
+// fixtures a Replayer serves back without the package managers installed
+type Runner = helper.Runner
+
+// Command is a process started by a plugin
+type Command = helper.Command
+
+// CommandResult is the output and exit code of a Command
+type CommandResult = helper.Result
+
+// ExitError is returned by a Runner for commands exiting with a non zero code
+type ExitError = helper.ExitError
+
+// ExecRunner runs the commands on the host
+type ExecRunner = helper.ExecRunner
+
+// Recorder runs the commands with another Runner and saves them as fixtures
+type Recorder = helper.Recorder
+
+// Replayer serves the fixtures saved by a Recorder
+type Replayer = helper.Replayer
+
+// ErrReadOnly is returned by plugins that need to modify the project in
+// read-only mode
+var ErrReadOnly = helper.ErrReadOnly
+
+// ErrNoFixture is returned by a Replayer for commands it has no fixture for
+var ErrNoFixture = helper.ErrNoFixture
+
+// Configurable is implemented by plugins that accept Options
+type Configurable interface {
+	SetOptions(opts Options)
+}
+
+// Configure passes the options to the plugin if it accepts them
+func Configure(p Plugin, opts Options) bool {
+	c, ok := p.(Configurable)
+	if ok {
+		c.SetOptions(opts)
+	}
+
+	return ok
+}
+
+// Filter returns the modules selected by the options in the order of
+// meta.Sort
+func (o Options) Filter(modules []meta.Package) []meta.Package {
+	if o.RuntimeOnly {
+		modules = meta.RuntimeOnly(modules)
+	}
+	meta.Sort(modules)
+
+	return modules
+}
+
+// Context returns ctx carrying the network and write policies, license
+// threshold, HTTP client and command runner of the options
+func (o Options) Context(ctx context.Context) context.Context {
+	ctx = helper.WithReadOnly(helper.WithOffline(ctx, o.Offline), o.ReadOnly)
+	ctx = helper.WithLicenseThreshold(ctx, o.LicenseThreshold)
+	ctx = helper.WithClient(ctx, o.HTTP)
+	return helper.WithRunner(ctx, o.Runner)
+}
@@ -0,0 +1,32 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/opensbom-generator/parsers/meta"
+)
+
+func TestFilterSorts(t *testing.T) {
+	modules := []meta.Package{
+		{Name: "test-lib", Scope: meta.ScopeTest},
+		{Name: "lib"},
+		{Name: "app", Root: true},
+	}
+
+	assert.Equal(t, []meta.Package{{Name: "app", Root: true}, {Name: "lib"}},
+		Options{RuntimeOnly: true}.Filter(modules))
+	assert.Equal(t, []string{"app", "lib", "test-lib"}, names(Options{}.Filter(modules)))
+}
+
+func names(modules []meta.Package) []string {
+	result := []string{}
+	for i := range modules {
+		result = append(result, modules[i].Name)
+	}
+
+	return result
+}
@@ -21,4 +21,8 @@ type Metadata struct {
 	Slug       string
 	Manifest   []string
 	ModulePath []string
+	// ReadOnly is true when the plugin can scan a project without modifying
+	// it, see Options.ReadOnly
+	ReadOnly     bool
+	Capabilities Capabilities
 }
@@ -0,0 +1,172 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/opensbom-generator/parsers/internal/helper"
+)
+
+// Factory returns a new, unconfigured instance of a plugin. Plugins keep
+// state between calls, so the registry never hands out shared instances.
+type Factory func() Plugin
+
+// Scorer can be implemented by plugins that know better than the default
+// manifest heuristic how well a directory matches their ecosystem.
+// Scores range from 0 (no match) to 1 (certain match).
+type Scorer interface {
+	Confidence(path string) float64
+}
+
+// Match is a plugin detected for a directory
+type Match struct {
+	Slug       string
+	Plugin     Plugin
+	Confidence float64
+}
+
+// Registry keeps the list of known plugins
+type Registry struct {
+	mu        sync.RWMutex
+	factories map[string]Factory
+}
+
+// DefaultRegistry is the registry built-in plugins register into.
+// Import github.com/opensbom-generator/parsers/plugin/all to populate it.
+var DefaultRegistry = NewRegistry()
+
+// NewRegistry returns an empty registry
+func NewRegistry() *Registry {
+	return &Registry{
+		factories: map[string]Factory{},
+	}
+}
+
+// Register adds a plugin factory under the given slug
+func (r *Registry) Register(slug string, factory Factory) error {
+	if slug == "" || factory == nil {
+		return fmt.Errorf("registering plugin: slug and factory are required")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.factories[slug]; ok {
+		return fmt.Errorf("plugin %q is already registered", slug)
+	}
+	r.factories[slug] = factory
+
+	return nil
+}
+
+// Slugs returns the sorted slugs of every registered plugin
+func (r *Registry) Slugs() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	slugs := make([]string, 0, len(r.factories))
+	for slug := range r.factories {
+		slugs = append(slugs, slug)
+	}
+	sort.Strings(slugs)
+
+	return slugs
+}
+
+// Get returns a new instance of the plugin registered under slug
+func (r *Registry) Get(slug string) (Plugin, error) {
+	r.mu.RLock()
+	factory, ok := r.factories[slug]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("plugin %q is not registered", slug)
+	}
+
+	return factory(), nil
+}
+
+// Matches returns every plugin whose manifest is found in path, sorted by
+// descending confidence and then by slug
+func (r *Registry) Matches(path string) []Match {
+	matches := []Match{}
+	for _, slug := range r.Slugs() {
+		p, err := r.Get(slug)
+		if err != nil {
+			continue
+		}
+
+		if !p.IsValid(path) {
+			continue
+		}
+
+		matches = append(matches, Match{
+			Slug:       slug,
+			Plugin:     p,
+			Confidence: confidence(p, path),
+		})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Confidence != matches[j].Confidence {
+			return matches[i].Confidence > matches[j].Confidence
+		}
+		return matches[i].Slug < matches[j].Slug
+	})
+
+	return matches
+}
+
+// Detect returns every plugin whose manifest is found in path, ranked by
+// confidence
+func (r *Registry) Detect(path string) []Plugin {
+	matches := r.Matches(path)
+	plugins := make([]Plugin, 0, len(matches))
+	for i := range matches {
+		plugins = append(plugins, matches[i].Plugin)
+	}
+
+	return plugins
+}
+
+// Register adds a plugin factory to the default registry. It panics if the
+// slug is already taken, as it is meant to be called from init functions.
+func Register(slug string, factory Factory) {
+	if err := DefaultRegistry.Register(slug, factory); err != nil {
+		panic(err)
+	}
+}
+
+// Detect runs Registry.Detect on the default registry
+func Detect(path string) []Plugin {
+	return DefaultRegistry.Detect(path)
+}
+
+// Matches runs Registry.Matches on the default registry
+func Matches(path string) []Match {
+	return DefaultRegistry.Matches(path)
+}
+
+// confidence scores a plugin that already reported path as valid. Plugins
+// without a Scorer get a score based on how many of their manifest files exist.
+func confidence(p Plugin, path string) float64 {
+	if s, ok := p.(Scorer); ok {
+		return s.Confidence(path)
+	}
+
+	manifests := p.GetMetadata().Manifest
+	if len(manifests) == 0 {
+		return 0.5
+	}
+
+	found := 0
+	for i := range manifests {
+		if helper.Exists(filepath.Join(path, manifests[i])) {
+			found++
+		}
+	}
+
+	return 0.5 + 0.5*float64(found)/float64(len(manifests))
+}
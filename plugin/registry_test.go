@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opensbom-generator/parsers/meta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePlugin struct {
+	manifest []string
+	score    float64
+}
+
+func (f *fakePlugin) SetRootModule(string) error  { return nil }
+func (f *fakePlugin) GetVersion() (string, error) { return "", nil }
+func (f *fakePlugin) GetMetadata() Metadata       { return Metadata{Manifest: f.manifest} }
+func (f *fakePlugin) GetRootModule(string) (*meta.Package, error) {
+	return &meta.Package{}, nil
+}
+func (f *fakePlugin) ListUsedModules(string) ([]meta.Package, error) { return nil, nil }
+func (f *fakePlugin) ListModulesWithDeps(string, string) ([]meta.Package, error) {
+	return nil, nil
+}
+func (f *fakePlugin) HasModulesInstalled(string) error { return nil }
+func (f *fakePlugin) IsValid(path string) bool {
+	for i := range f.manifest {
+		if _, err := os.Stat(filepath.Join(path, f.manifest[i])); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+type scoredPlugin struct {
+	fakePlugin
+}
+
+func (s *scoredPlugin) Confidence(string) float64 { return s.score }
+
+func TestRegister(t *testing.T) {
+	r := NewRegistry()
+	require.NoError(t, r.Register("b", func() Plugin { return &fakePlugin{} }))
+	require.NoError(t, r.Register("a", func() Plugin { return &fakePlugin{} }))
+
+	assert.Error(t, r.Register("a", func() Plugin { return &fakePlugin{} }))
+	assert.Error(t, r.Register("", func() Plugin { return &fakePlugin{} }))
+	assert.Error(t, r.Register("c", nil))
+	assert.Equal(t, []string{"a", "b"}, r.Slugs())
+
+	_, err := r.Get("missing")
+	assert.Error(t, err)
+
+	first, err := r.Get("a")
+	require.NoError(t, err)
+	second, err := r.Get("a")
+	require.NoError(t, err)
+	assert.NotSame(t, first, second)
+}
+
+func TestDetect(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.lock", "a.json", "b.json"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("{}"), 0o600))
+	}
+
+	r := NewRegistry()
+	require.NoError(t, r.Register("partial", func() Plugin {
+		return &fakePlugin{manifest: []string{"b.json", "b.lock"}}
+	}))
+	require.NoError(t, r.Register("full", func() Plugin {
+		return &fakePlugin{manifest: []string{"a.json", "a.lock"}}
+	}))
+	require.NoError(t, r.Register("scored", func() Plugin {
+		return &scoredPlugin{fakePlugin{manifest: []string{"a.json"}, score: 0.6}}
+	}))
+	require.NoError(t, r.Register("absent", func() Plugin {
+		return &fakePlugin{manifest: []string{"c.json"}}
+	}))
+
+	matches := r.Matches(dir)
+	require.Len(t, matches, 3)
+	assert.Equal(t, "full", matches[0].Slug)
+	assert.InDelta(t, 1.0, matches[0].Confidence, 0.001)
+	assert.Equal(t, "partial", matches[1].Slug)
+	assert.InDelta(t, 0.75, matches[1].Confidence, 0.001)
+	assert.Equal(t, "scored", matches[2].Slug)
+
+	assert.Len(t, r.Detect(dir), 3)
+	assert.Empty(t, r.Detect(t.TempDir()))
+}
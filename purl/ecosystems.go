@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package purl
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Golang returns the package URL of a go module
+func Golang(modulePath, version string) string {
+	namespace, name := splitLast(modulePath)
+	return New(TypeGolang, namespace, name, version).String()
+}
+
+// NPM returns the package URL of an npm package. Scoped names such as
+// @scope/name keep the scope as namespace.
+func NPM(name, version string) string {
+	namespace, pkg := splitLast(name)
+	return New(TypeNPM, namespace, pkg, version).String()
+}
+
+// PyPI returns the package URL of a python distribution
+func PyPI(name, version string) string {
+	return New(TypePyPI, "", name, version).String()
+}
+
+// Cargo returns the package URL of a rust crate
+func Cargo(name, version string) string {
+	return New(TypeCargo, "", name, version).String()
+}
+
+// Maven returns the package URL of a maven artifact
+func Maven(groupID, artifactID, version string) string {
+	return New(TypeMaven, groupID, artifactID, version).String()
+}
+
+// NuGet returns the package URL of a nuget package
+func NuGet(name, version string) string {
+	return New(TypeNuGet, "", name, version).String()
+}
+
+// Composer returns the package URL of a composer package named vendor/name
+func Composer(name, version string) string {
+	namespace, pkg := splitLast(name)
+	return New(TypeComposer, namespace, pkg, version).String()
+}
+
+// Gem returns the package URL of a ruby gem
+func Gem(name, version string) string {
+	return New(TypeGem, "", name, version).String()
+}
+
+// Swift returns the package URL of a swift package from its source
+// repository, e.g. https://github.com/apple/swift-nio.git
+func Swift(source, version string) string {
+	namespace, name := splitLast(swiftLocation(source))
+	return New(TypeSwift, namespace, name, version).String()
+}
+
+// swiftLocation strips the scheme, credentials and .git suffix from a
+// repository location
+func swiftLocation(source string) string {
+	location := source
+	if u, err := url.Parse(source); err == nil && u.Host != "" {
+		location = u.Host + u.Path
+	} else if i := strings.Index(source, "@"); i >= 0 {
+		// scp like syntax: git@github.com:apple/swift-nio.git
+		location = strings.Replace(source[i+1:], ":", "/", 1)
+	}
+
+	return strings.TrimSuffix(strings.Trim(location, "/"), ".git")
+}
+
+// splitLast splits a slash separated path into its leading segments and
+// its last segment
+func splitLast(path string) (string, string) {
+	path = strings.Trim(path, "/")
+	i := strings.LastIndex(path, "/")
+	if i < 0 {
+		return "", path
+	}
+
+	return path[:i], path[i+1:]
+}
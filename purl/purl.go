@@ -0,0 +1,188 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package purl builds package URLs as defined by the purl specification:
+// https://github.com/package-url/purl-spec
+package purl
+
+import (
+	"sort"
+	"strings"
+)
+
+// Package types supported by the parsers
+const (
+	TypeCargo    = "cargo"
+	TypeComposer = "composer"
+	TypeGem      = "gem"
+	TypeGolang   = "golang"
+	TypeMaven    = "maven"
+	TypeNPM      = "npm"
+	TypeNuGet    = "nuget"
+	TypePyPI     = "pypi"
+	TypeSwift    = "swift"
+)
+
+// Qualifier is a key=value pair appended to a package URL
+type Qualifier struct {
+	Key   string
+	Value string
+}
+
+// PackageURL holds the components of a package URL
+type PackageURL struct {
+	Type       string
+	Namespace  string
+	Name       string
+	Version    string
+	Qualifiers []Qualifier
+	Subpath    string
+}
+
+// New returns a package URL with the type specific normalisation applied
+func New(purlType, namespace, name, version string) PackageURL {
+	p := PackageURL{
+		Type:      strings.ToLower(purlType),
+		Namespace: strings.Trim(namespace, "/"),
+		Name:      strings.Trim(name, "/"),
+		Version:   version,
+	}
+	p.normalize()
+
+	return p
+}
+
+// WithQualifier returns a copy of p with the qualifier added. Empty values
+// are ignored as the specification does not allow them.
+func (p PackageURL) WithQualifier(key, value string) PackageURL {
+	if key == "" || value == "" {
+		return p
+	}
+
+	qualifiers := make([]Qualifier, 0, len(p.Qualifiers)+1)
+	qualifiers = append(qualifiers, p.Qualifiers...)
+	p.Qualifiers = append(qualifiers, Qualifier{Key: strings.ToLower(key), Value: value})
+
+	return p
+}
+
+// WithSubpath returns a copy of p with the given subpath
+func (p PackageURL) WithSubpath(subpath string) PackageURL {
+	p.Subpath = subpath
+	return p
+}
+
+// String returns the canonical form of the package URL. It returns an empty
+// string when the package has no type or name.
+func (p PackageURL) String() string {
+	if p.Type == "" || p.Name == "" {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("pkg:")
+	sb.WriteString(p.Type)
+	sb.WriteString("/")
+
+	if ns := encodeSegments(p.Namespace); ns != "" {
+		sb.WriteString(ns)
+		sb.WriteString("/")
+	}
+	sb.WriteString(escape(p.Name))
+
+	if p.Version != "" {
+		sb.WriteString("@")
+		sb.WriteString(escape(p.Version))
+	}
+
+	if q := encodeQualifiers(p.Qualifiers); q != "" {
+		sb.WriteString("?")
+		sb.WriteString(q)
+	}
+
+	if sub := encodeSegments(p.Subpath); sub != "" {
+		sb.WriteString("#")
+		sb.WriteString(sub)
+	}
+
+	return sb.String()
+}
+
+func (p *PackageURL) normalize() {
+	switch p.Type {
+	case TypePyPI:
+		p.Name = strings.ReplaceAll(strings.ToLower(p.Name), "_", "-")
+	case TypeNPM:
+		p.Namespace = strings.ToLower(p.Namespace)
+		p.Name = strings.ToLower(p.Name)
+	case TypeComposer:
+		p.Namespace = strings.ToLower(p.Namespace)
+		p.Name = strings.ToLower(p.Name)
+	}
+}
+
+// encodeSegments escapes every segment of a slash separated path, dropping
+// empty, "." and ".." segments
+func encodeSegments(path string) string {
+	segments := []string{}
+	for _, s := range strings.Split(path, "/") {
+		if s == "" || s == "." || s == ".." {
+			continue
+		}
+		segments = append(segments, escape(s))
+	}
+
+	return strings.Join(segments, "/")
+}
+
+func encodeQualifiers(qualifiers []Qualifier) string {
+	values := map[string]string{}
+	for _, q := range qualifiers {
+		if q.Key == "" || q.Value == "" {
+			continue
+		}
+		values[strings.ToLower(q.Key)] = q.Value
+	}
+
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, k+"="+escape(values[k]))
+	}
+
+	return strings.Join(pairs, "&")
+}
+
+// escape percent-encodes everything outside of the unreserved set
+func escape(s string) string {
+	const hex = "0123456789ABCDEF"
+
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if isUnreserved(c) {
+			sb.WriteByte(c)
+			continue
+		}
+		sb.WriteByte('%')
+		sb.WriteByte(hex[c>>4])
+		sb.WriteByte(hex[c&0x0f])
+	}
+
+	return sb.String()
+}
+
+func isUnreserved(c byte) bool {
+	switch {
+	case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		return true
+	case c == '-' || c == '.' || c == '_' || c == '~':
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package purl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEcosystems(t *testing.T) {
+	tests := []struct {
+		name     string
+		got      string
+		expected string
+	}{
+		{"golang", Golang("github.com/sirupsen/logrus", "v1.9.0"), "pkg:golang/github.com/sirupsen/logrus@v1.9.0"},
+		{"golang incompatible", Golang("github.com/docker/docker", "v20.10.7+incompatible"), "pkg:golang/github.com/docker/docker@v20.10.7%2Bincompatible"},
+		{"npm", NPM("lodash", "4.17.21"), "pkg:npm/lodash@4.17.21"},
+		{"npm scoped", NPM("@babel/core", "7.20.0"), "pkg:npm/%40babel/core@7.20.0"},
+		{"pypi", PyPI("Django_Rest", "3.1"), "pkg:pypi/django-rest@3.1"},
+		{"cargo", Cargo("serde", "1.0.152"), "pkg:cargo/serde@1.0.152"},
+		{"maven", Maven("org.apache.commons", "commons-lang3", "3.12.0"), "pkg:maven/org.apache.commons/commons-lang3@3.12.0"},
+		{"nuget", NuGet("Newtonsoft.Json", "13.0.1"), "pkg:nuget/Newtonsoft.Json@13.0.1"},
+		{"composer", Composer("Laravel/Framework", "v9.0.0"), "pkg:composer/laravel/framework@v9.0.0"},
+		{"gem", Gem("rails", "7.0.4"), "pkg:gem/rails@7.0.4"},
+		{"swift", Swift("https://github.com/apple/swift-nio.git", "2.41.1"), "pkg:swift/github.com/apple/swift-nio@2.41.1"},
+		{"swift scp", Swift("git@github.com:apple/swift-nio.git", ""), "pkg:swift/github.com/apple/swift-nio"},
+		{"no version", Cargo("rand", ""), "pkg:cargo/rand"},
+		{"no name", NPM("", "1.0.0"), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.got)
+		})
+	}
+}
+
+func TestQualifiersAndSubpath(t *testing.T) {
+	p := New(TypeMaven, "org.example", "lib", "1.0").
+		WithQualifier("type", "jar").
+		WithQualifier("Classifier", "sources dist").
+		WithQualifier("empty", "").
+		WithSubpath("/a/./b/../c/")
+
+	assert.Equal(t, "pkg:maven/org.example/lib@1.0?classifier=sources%20dist&type=jar#a/b/c", p.String())
+}
+
+func TestGolangSubpath(t *testing.T) {
+	p := New(TypeGolang, "google.golang.org", "genproto", "").WithSubpath("googleapis/api/annotations")
+
+	assert.Equal(t, "pkg:golang/google.golang.org/genproto#googleapis/api/annotations", p.String())
+}
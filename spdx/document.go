@@ -0,0 +1,479 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package spdx converts the packages returned by the parsers into SPDX 2.3
+// documents, serialised as JSON or tag-value.
+package spdx
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/opensbom-generator/parsers/meta"
+)
+
+// Version is the SPDX specification version of the generated documents
+const Version = "SPDX-2.3"
+
+const (
+	dataLicense = "CC0-1.0"
+	documentID  = "SPDXRef-DOCUMENT"
+
+	// NoAssertion is used for fields whose value could not be determined
+	NoAssertion = "NOASSERTION"
+	// None is used for fields known to have no value
+	None = "NONE"
+
+	defaultCreator   = "Tool: opensbom-generator-parsers"
+	defaultNamespace = "https://opensbom-generator.github.io/spdxdocs/"
+)
+
+// Relationship types used in generated documents
+const (
+	RelationshipDescribes = "DESCRIBES"
+	RelationshipDependsOn = "DEPENDS_ON"
+	RelationshipContains  = "CONTAINS"
+)
+
+// Options configures the generated document. Empty fields get defaults.
+type Options struct {
+	// Name of the document, defaults to the name of the first root package
+	Name string
+	// Namespace is the unique URI of the document. By default it is derived
+	// from the document name and its packages.
+	Namespace string
+	// Creators such as "Tool: name-version" or "Organization: name"
+	Creators []string
+	// Created is the creation time, defaults to now
+	Created time.Time
+}
+
+// Document is an SPDX document
+type Document struct {
+	SPDXVersion                string                   `json:"spdxVersion"`
+	DataLicense                string                   `json:"dataLicense"`
+	SPDXID                     string                   `json:"SPDXID"`
+	Name                       string                   `json:"name"`
+	DocumentNamespace          string                   `json:"documentNamespace"`
+	CreationInfo               CreationInfo             `json:"creationInfo"`
+	Packages                   []Package                `json:"packages"`
+	Files                      []File                   `json:"files,omitempty"`
+	Relationships              []Relationship           `json:"relationships"`
+	HasExtractedLicensingInfos []ExtractedLicensingInfo `json:"hasExtractedLicensingInfos,omitempty"`
+}
+
+// CreationInfo tells who created the document and when
+type CreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+// Package is an SPDX package
+type Package struct {
+	Name                    string                   `json:"name"`
+	SPDXID                  string                   `json:"SPDXID"`
+	VersionInfo             string                   `json:"versionInfo,omitempty"`
+	Supplier                string                   `json:"supplier,omitempty"`
+	DownloadLocation        string                   `json:"downloadLocation"`
+	FilesAnalyzed           bool                     `json:"filesAnalyzed"`
+	PackageVerificationCode *PackageVerificationCode `json:"packageVerificationCode,omitempty"`
+	Checksums               []Checksum               `json:"checksums,omitempty"`
+	Homepage                string                   `json:"homepage,omitempty"`
+	LicenseConcluded        string                   `json:"licenseConcluded"`
+	LicenseInfoFromFiles    []string                 `json:"licenseInfoFromFiles,omitempty"`
+	LicenseDeclared         string                   `json:"licenseDeclared"`
+	LicenseComments         string                   `json:"licenseComments,omitempty"`
+	CopyrightText           string                   `json:"copyrightText"`
+	Comment                 string                   `json:"comment,omitempty"`
+	ExternalRefs            []ExternalRef            `json:"externalRefs,omitempty"`
+}
+
+// PackageVerificationCode identifies the files of a package, it is the SHA1
+// of their sorted SHA1 digests
+type PackageVerificationCode struct {
+	Value         string   `json:"packageVerificationCodeValue"`
+	ExcludedFiles []string `json:"packageVerificationCodeExcludedFiles,omitempty"`
+}
+
+// File is a file contained in a package whose files were analyzed
+type File struct {
+	FileName           string     `json:"fileName"`
+	SPDXID             string     `json:"SPDXID"`
+	Checksums          []Checksum `json:"checksums"`
+	LicenseConcluded   string     `json:"licenseConcluded"`
+	LicenseInfoInFiles []string   `json:"licenseInfoInFiles"`
+	CopyrightText      string     `json:"copyrightText"`
+}
+
+// Checksum is a package checksum
+type Checksum struct {
+	Algorithm     string `json:"algorithm"`
+	ChecksumValue string `json:"checksumValue"`
+}
+
+// ExternalRef references a package in an external system, e.g. its purl
+type ExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+// Relationship links two SPDX elements
+type Relationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+// ExtractedLicensingInfo holds a license that is not on the SPDX license list
+type ExtractedLicensingInfo struct {
+	LicenseID     string `json:"licenseId"`
+	ExtractedText string `json:"extractedText"`
+	Name          string `json:"name,omitempty"`
+	Comment       string `json:"comment,omitempty"`
+}
+
+// builder keeps the state needed to turn a package tree into a document
+type builder struct {
+	doc      *Document
+	ids      map[string]string
+	used     map[string]bool
+	licenses map[string]bool
+}
+
+// New builds an SPDX document from the packages returned by a plugin. Each
+// package becomes an SPDX package, dependencies found in Packages become
+// DEPENDS_ON relationships and root packages are described by the document.
+func New(pkgs []meta.Package, opts Options) *Document {
+	b := &builder{
+		doc: &Document{
+			SPDXVersion:   Version,
+			DataLicense:   dataLicense,
+			SPDXID:        documentID,
+			Packages:      []Package{},
+			Relationships: []Relationship{},
+		},
+		ids:      map[string]string{},
+		used:     map[string]bool{},
+		licenses: map[string]bool{},
+	}
+
+	for i := range pkgs {
+		b.addPackage(&pkgs[i])
+	}
+	meta.WalkDependencies(pkgs, b.addDependencies)
+	b.addMissingLicenses()
+
+	roots := []string{}
+	for i := range pkgs {
+		if pkgs[i].Root {
+			roots = append(roots, b.ids[meta.Key(&pkgs[i])])
+		}
+	}
+	if len(roots) == 0 {
+		for i := range pkgs {
+			roots = append(roots, b.ids[meta.Key(&pkgs[i])])
+		}
+	}
+	describes := make([]Relationship, 0, len(roots))
+	seen := map[string]bool{}
+	for _, id := range roots {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		describes = append(describes, Relationship{
+			SPDXElementID:      documentID,
+			RelationshipType:   RelationshipDescribes,
+			RelatedSPDXElement: id,
+		})
+	}
+	b.doc.Relationships = append(describes, b.doc.Relationships...)
+
+	b.setCreationInfo(pkgs, opts)
+
+	return b.doc
+}
+
+func (b *builder) setCreationInfo(pkgs []meta.Package, opts Options) {
+	name := opts.Name
+	if name == "" {
+		for i := range pkgs {
+			if pkgs[i].Root {
+				name = pkgs[i].Name
+				break
+			}
+		}
+	}
+	if name == "" && len(pkgs) > 0 {
+		name = pkgs[0].Name
+	}
+	b.doc.Name = name
+
+	namespace := opts.Namespace
+	if namespace == "" {
+		h := sha256.New()
+		for i := range b.doc.Packages {
+			fmt.Fprintf(h, "%s\n", b.doc.Packages[i].SPDXID)
+		}
+		namespace = defaultNamespace + sanitizeID(name) + "-" + hex.EncodeToString(h.Sum(nil))[:16]
+	}
+	b.doc.DocumentNamespace = namespace
+
+	creators := opts.Creators
+	if len(creators) == 0 {
+		creators = []string{defaultCreator}
+	}
+	created := opts.Created
+	if created.IsZero() {
+		created = time.Now()
+	}
+	b.doc.CreationInfo = CreationInfo{
+		Created:  created.UTC().Format(time.RFC3339),
+		Creators: creators,
+	}
+}
+
+// addPackage adds p to the document unless a package with the same name and
+// version was already added, and returns its SPDXID
+func (b *builder) addPackage(p *meta.Package) string {
+	k := meta.Key(p)
+	if id, ok := b.ids[k]; ok {
+		return id
+	}
+
+	id := b.newID(p)
+	b.ids[k] = id
+	b.doc.Packages = append(b.doc.Packages, b.convert(p, id))
+
+	return id
+}
+
+// addDependencies adds a DEPENDS_ON relationship for every dependency of p
+func (b *builder) addDependencies(p *meta.Package, deps []*meta.Package) {
+	id := b.ids[meta.Key(p)]
+	for _, dep := range deps {
+		b.doc.Relationships = append(b.doc.Relationships, Relationship{
+			SPDXElementID:      id,
+			RelationshipType:   RelationshipDependsOn,
+			RelatedSPDXElement: b.addPackage(dep),
+		})
+	}
+}
+
+func (b *builder) convert(p *meta.Package, id string) Package {
+	pkg := Package{
+		Name:             p.Name,
+		SPDXID:           id,
+		VersionInfo:      p.Version,
+		Supplier:         NoAssertion,
+		DownloadLocation: location(p.PackageDownloadLocation, NoAssertion),
+		Homepage:         location(p.PackageHomePage, ""),
+		CopyrightText:    NoAssertion,
+		Comment:          p.PackageComment,
+		LicenseComments:  p.CommentsLicense,
+	}
+
+	if supplier := p.Supplier.Get(); supplier != "" {
+		pkg.Supplier = supplier
+	}
+	if c := strings.TrimSpace(p.Copyright); c != "" {
+		pkg.CopyrightText = c
+	}
+	for _, c := range p.ArtifactChecksums() {
+		if c, ok := checksum(c); ok {
+			pkg.Checksums = append(pkg.Checksums, c)
+		}
+	}
+	if p.VerificationCode != nil {
+		pkg.FilesAnalyzed = true
+		pkg.PackageVerificationCode = &PackageVerificationCode{Value: p.VerificationCode.Value}
+		for _, f := range p.VerificationCode.ExcludedFiles {
+			pkg.PackageVerificationCode.ExcludedFiles = append(pkg.PackageVerificationCode.ExcludedFiles, "./"+f)
+		}
+		pkg.LicenseInfoFromFiles = b.addFiles(p, id)
+	}
+	if p.PackageURL != "" && strings.HasPrefix(p.PackageURL, "pkg:") {
+		pkg.ExternalRefs = []ExternalRef{{
+			ReferenceCategory: "PACKAGE-MANAGER",
+			ReferenceType:     "purl",
+			ReferenceLocator:  p.PackageURL,
+		}}
+	}
+
+	var invalid []string
+	var ok bool
+	if pkg.LicenseConcluded, ok = LicenseExpression(p.LicenseConcluded); !ok {
+		invalid = append(invalid, fmt.Sprintf("concluded license %q is not a valid SPDX expression", p.LicenseConcluded))
+	}
+	if pkg.LicenseDeclared, ok = LicenseExpression(p.LicenseDeclared); !ok {
+		invalid = append(invalid, fmt.Sprintf("declared license %q is not a valid SPDX expression", p.LicenseDeclared))
+	}
+	if len(invalid) > 0 {
+		pkg.LicenseComments = strings.TrimSpace(strings.Join(append([]string{pkg.LicenseComments}, invalid...), "\n"))
+	}
+
+	for i := range p.OtherLicense {
+		b.addLicense(p.OtherLicense[i].ID, p.OtherLicense[i].Name, p.OtherLicense[i].ExtractedText, p.OtherLicense[i].Comments)
+	}
+
+	return pkg
+}
+
+// addFiles adds the files of p, contained in the package id, and returns
+// the licenses found in them
+func (b *builder) addFiles(p *meta.Package, id string) []string {
+	found := map[string]bool{}
+	for i := range p.Files {
+		f := &p.Files[i]
+		file := File{
+			FileName:           "./" + f.Path,
+			SPDXID:             fmt.Sprintf("SPDXRef-File-%s-%d", strings.TrimPrefix(id, "SPDXRef-Package-"), i+1),
+			Checksums:          []Checksum{},
+			LicenseConcluded:   NoAssertion,
+			LicenseInfoInFiles: []string{},
+			CopyrightText:      NoAssertion,
+		}
+		for _, c := range f.Checksums {
+			if c, ok := checksum(c); ok {
+				file.Checksums = append(file.Checksums, c)
+			}
+		}
+		for _, l := range f.Licenses {
+			if l, ok := LicenseExpression(l); ok && l != NoAssertion && l != None {
+				file.LicenseInfoInFiles = append(file.LicenseInfoInFiles, l)
+				found[l] = true
+			}
+		}
+		if len(file.LicenseInfoInFiles) == 0 {
+			file.LicenseInfoInFiles = []string{NoAssertion}
+		}
+
+		b.doc.Files = append(b.doc.Files, file)
+		b.doc.Relationships = append(b.doc.Relationships, Relationship{
+			SPDXElementID:      id,
+			RelationshipType:   RelationshipContains,
+			RelatedSPDXElement: file.SPDXID,
+		})
+	}
+
+	licenses := make([]string, 0, len(found))
+	for l := range found {
+		licenses = append(licenses, l)
+	}
+	sort.Strings(licenses)
+
+	return licenses
+}
+
+func (b *builder) addLicense(id, name, text, comment string) {
+	licenseID := licenseRef(id)
+	if licenseID == "" || b.licenses[licenseID] {
+		return
+	}
+	b.licenses[licenseID] = true
+
+	text = strings.TrimSpace(text)
+	text = strings.TrimSuffix(strings.TrimPrefix(text, "<text>"), "</text>")
+	if text == "" {
+		text = NoAssertion
+	}
+	if name == "" {
+		name = NoAssertion
+	}
+
+	b.doc.HasExtractedLicensingInfos = append(b.doc.HasExtractedLicensingInfos, ExtractedLicensingInfo{
+		LicenseID:     licenseID,
+		ExtractedText: text,
+		Name:          name,
+		Comment:       comment,
+	})
+}
+
+// addMissingLicenses declares the license references used in expressions
+// that no package provided the text for, as the specification requires
+func (b *builder) addMissingLicenses() {
+	expressions := []string{}
+	for i := range b.doc.Packages {
+		expressions = append(expressions, b.doc.Packages[i].LicenseConcluded, b.doc.Packages[i].LicenseDeclared)
+		expressions = append(expressions, b.doc.Packages[i].LicenseInfoFromFiles...)
+	}
+	for _, expr := range expressions {
+		for _, t := range strings.Fields(strings.NewReplacer("(", " ", ")", " ").Replace(expr)) {
+			if strings.HasPrefix(t, licenseRefPrefix) {
+				b.addLicense(t, "", "", "license text was not found")
+			}
+		}
+	}
+}
+
+// newID returns a unique SPDXID for the package
+func (b *builder) newID(p *meta.Package) string {
+	base := "SPDXRef-Package-" + sanitizeID(p.Name)
+	if p.Version != "" {
+		base += "-" + sanitizeID(p.Version)
+	}
+
+	id := base
+	for i := 2; b.used[id]; i++ {
+		id = fmt.Sprintf("%s-%d", base, i)
+	}
+	b.used[id] = true
+
+	return id
+}
+
+// sanitizeID replaces the characters not allowed in SPDX identifiers
+func sanitizeID(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z', '0' <= r && r <= '9', r == '.', r == '-':
+			return r
+		}
+		return '-'
+	}, s)
+}
+
+func checksum(c meta.Checksum) (Checksum, bool) {
+	switch c.Algorithm {
+	case meta.HashAlgoSHA1, meta.HashAlgoSHA224, meta.HashAlgoSHA256, meta.HashAlgoSHA384,
+		meta.HashAlgoSHA512, meta.HashAlgoSHA3_256, meta.HashAlgoSHA3_384, meta.HashAlgoSHA3_512,
+		meta.HashAlgoBLAKE2b_256, meta.HashAlgoBLAKE2b_384, meta.HashAlgoBLAKE2b_512, meta.HashAlgoBLAKE3,
+		meta.HashAlgoMD2, meta.HashAlgoMD4, meta.HashAlgoMD5, meta.HashAlgoMD6:
+	default:
+		return Checksum{}, false
+	}
+	if c.Value == "" && len(c.Content) == 0 {
+		return Checksum{}, false
+	}
+	value, err := c.Digest()
+	if err != nil {
+		return Checksum{}, false
+	}
+
+	return Checksum{
+		Algorithm:     string(c.Algorithm),
+		ChecksumValue: strings.TrimSpace(value),
+	}, true
+}
+
+// location returns a value usable as download location or homepage
+func location(value, fallback string) string {
+	value = strings.TrimSpace(value)
+	switch {
+	case value == "":
+		return fallback
+	case value == None || value == NoAssertion:
+		return value
+	case strings.Contains(value, "://"):
+		return value
+	case strings.Contains(strings.SplitN(value, "/", 2)[0], "."):
+		// host without scheme, e.g. github.com/org/repo
+		return "https://" + value
+	}
+
+	return fallback
+}
@@ -0,0 +1,225 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package spdx
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/opensbom-generator/parsers/internal/license"
+	"github.com/opensbom-generator/parsers/meta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPackages() []meta.Package {
+	dep := &meta.Package{
+		Name:                    "left-pad",
+		Version:                 "1.3.0",
+		PackageURL:              "pkg:npm/left-pad@1.3.0",
+		PackageDownloadLocation: "https://registry.npmjs.org/left-pad/-/left-pad-1.3.0.tgz",
+		LicenseDeclared:         "WTFPL",
+		LicenseConcluded:        "WTFPL",
+		Checksum:                meta.Checksum{Algorithm: meta.HashAlgoSHA1, Value: "abc"},
+		Packages:                map[string]*meta.Package{},
+	}
+	custom := &meta.Package{
+		Name:             "custom",
+		Version:          "0.1.0",
+		LicenseDeclared:  "LicenseRef-My License",
+		LicenseConcluded: "LicenseRef-My License",
+		OtherLicense: []license.License{
+			{ID: "My License", Name: "My License", ExtractedText: "<text>do what you want</text>"},
+		},
+		Checksums: []meta.Checksum{meta.SyntheticChecksum("custom")},
+		Packages:  map[string]*meta.Package{"left-pad": dep},
+	}
+
+	return []meta.Package{
+		{
+			Name:                    "app",
+			Version:                 "1.0.0",
+			Root:                    true,
+			PackageURL:              "pkg:npm/app@1.0.0",
+			PackageHomePage:         "github.com/example/app",
+			LicenseDeclared:         "mit OR Apache-2.0",
+			LicenseConcluded:        "Unlicense/MIT",
+			Supplier:                meta.Supplier{Name: "Example", Email: "dev@example.com"},
+			Copyright:               "Copyright (c) 2023 Example",
+			Checksum:                meta.Checksum{Algorithm: "None", Value: "none"},
+			PackageDownloadLocation: "",
+			Packages:                map[string]*meta.Package{"left-pad": dep, "custom": custom},
+		},
+		*dep,
+		*custom,
+	}
+}
+
+func testOptions() Options {
+	return Options{Created: time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)}
+}
+
+func TestNew(t *testing.T) {
+	doc := New(testPackages(), testOptions())
+
+	assert.Equal(t, Version, doc.SPDXVersion)
+	assert.Equal(t, "app", doc.Name)
+	assert.Equal(t, "2023-01-02T03:04:05Z", doc.CreationInfo.Created)
+	assert.Contains(t, doc.DocumentNamespace, defaultNamespace+"app-")
+	require.Len(t, doc.Packages, 3)
+
+	app := doc.Packages[0]
+	assert.Equal(t, "SPDXRef-Package-app-1.0.0", app.SPDXID)
+	assert.Equal(t, "Organization: Example (dev@example.com)", app.Supplier)
+	assert.Equal(t, NoAssertion, app.DownloadLocation)
+	assert.Equal(t, "https://github.com/example/app", app.Homepage)
+	assert.Empty(t, app.Checksums)
+	assert.Equal(t, "MIT OR Apache-2.0", app.LicenseDeclared, "identifiers are matched regardless of case")
+	assert.Equal(t, NoAssertion, app.LicenseConcluded)
+	assert.Contains(t, app.LicenseComments, "Unlicense/MIT")
+	assert.Equal(t, "pkg:npm/app@1.0.0", app.ExternalRefs[0].ReferenceLocator)
+
+	leftPad := doc.Packages[1]
+	assert.Equal(t, "SPDXRef-Package-left-pad-1.3.0", leftPad.SPDXID)
+	assert.Equal(t, []Checksum{{Algorithm: "SHA1", ChecksumValue: "abc"}}, leftPad.Checksums)
+	assert.Equal(t, NoAssertion, leftPad.CopyrightText)
+
+	assert.Equal(t, "LicenseRef-My-License", doc.Packages[2].LicenseDeclared)
+	assert.Empty(t, doc.Packages[2].Checksums, "synthetic checksums are left out")
+	require.Len(t, doc.HasExtractedLicensingInfos, 1)
+	assert.Equal(t, "LicenseRef-My-License", doc.HasExtractedLicensingInfos[0].LicenseID)
+	assert.Equal(t, "do what you want", doc.HasExtractedLicensingInfos[0].ExtractedText)
+
+	assert.Equal(t, []Relationship{
+		{documentID, RelationshipDescribes, "SPDXRef-Package-app-1.0.0"},
+		{"SPDXRef-Package-app-1.0.0", RelationshipDependsOn, "SPDXRef-Package-custom-0.1.0"},
+		{"SPDXRef-Package-app-1.0.0", RelationshipDependsOn, "SPDXRef-Package-left-pad-1.3.0"},
+		{"SPDXRef-Package-custom-0.1.0", RelationshipDependsOn, "SPDXRef-Package-left-pad-1.3.0"},
+	}, doc.Relationships)
+}
+
+func TestNewIsDeterministic(t *testing.T) {
+	first := New(testPackages(), testOptions())
+	second := New(testPackages(), testOptions())
+
+	assert.Equal(t, first, second)
+}
+
+func TestWriteJSON(t *testing.T) {
+	doc := New(testPackages(), testOptions())
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, doc.WriteJSON(buf))
+
+	decoded := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, "SPDX-2.3", decoded["spdxVersion"])
+	assert.Equal(t, "SPDXRef-DOCUMENT", decoded["SPDXID"])
+	assert.Len(t, decoded["packages"], 3)
+	assert.Len(t, decoded["hasExtractedLicensingInfos"], 1)
+}
+
+func TestWriteTagValue(t *testing.T) {
+	doc := New(testPackages(), testOptions())
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, doc.WriteTagValue(buf))
+
+	out := buf.String()
+	assert.Contains(t, out, "SPDXVersion: SPDX-2.3\n")
+	assert.Contains(t, out, "PackageName: left-pad\n")
+	assert.Contains(t, out, "PackageChecksum: SHA1: abc\n")
+	assert.Contains(t, out, "PackageCopyrightText: <text>Copyright (c) 2023 Example</text>\n")
+	assert.Contains(t, out, "ExternalRef: PACKAGE-MANAGER purl pkg:npm/left-pad@1.3.0\n")
+	assert.Contains(t, out, "Relationship: SPDXRef-DOCUMENT DESCRIBES SPDXRef-Package-app-1.0.0\n")
+	assert.Contains(t, out, "LicenseID: LicenseRef-My-License\nExtractedText: <text>do what you want</text>\n")
+}
+
+func TestFiles(t *testing.T) {
+	sha1 := meta.Checksum{Algorithm: meta.HashAlgoSHA1, Value: "abc"}
+	pkgs := []meta.Package{{
+		Name:    "vendored",
+		Version: "1.0.0",
+		Root:    true,
+		Files: []meta.File{
+			{Path: "LICENSE", Checksums: []meta.Checksum{sha1}, Licenses: []string{"MIT"}},
+			{Path: "lib/a.go", Checksums: []meta.Checksum{sha1}, Licenses: []string{"Apache-2.0", "LicenseRef-Custom"}},
+			{Path: "lib/b.go", Checksums: []meta.Checksum{sha1}},
+		},
+		VerificationCode: &meta.VerificationCode{Value: "def", ExcludedFiles: []string{"package.spdx"}},
+	}}
+	doc := New(pkgs, testOptions())
+
+	require.Len(t, doc.Packages, 1)
+	p := doc.Packages[0]
+	assert.True(t, p.FilesAnalyzed)
+	assert.Equal(t, &PackageVerificationCode{Value: "def", ExcludedFiles: []string{"./package.spdx"}}, p.PackageVerificationCode)
+	assert.Equal(t, []string{"Apache-2.0", "LicenseRef-Custom", "MIT"}, p.LicenseInfoFromFiles)
+
+	require.Len(t, doc.Files, 3)
+	assert.Equal(t, File{
+		FileName:           "./lib/b.go",
+		SPDXID:             "SPDXRef-File-vendored-1.0.0-3",
+		Checksums:          []Checksum{{Algorithm: "SHA1", ChecksumValue: "abc"}},
+		LicenseConcluded:   NoAssertion,
+		LicenseInfoInFiles: []string{NoAssertion},
+		CopyrightText:      NoAssertion,
+	}, doc.Files[2])
+	assert.Contains(t, doc.Relationships, Relationship{"SPDXRef-Package-vendored-1.0.0", RelationshipContains, "SPDXRef-File-vendored-1.0.0-1"})
+	require.Len(t, doc.HasExtractedLicensingInfos, 1)
+	assert.Equal(t, "LicenseRef-Custom", doc.HasExtractedLicensingInfos[0].LicenseID)
+
+	// the license without text is declared with no name either
+	buf := &bytes.Buffer{}
+	require.NoError(t, doc.WriteJSON(buf))
+	decoded := struct {
+		HasExtractedLicensingInfos []map[string]string `json:"hasExtractedLicensingInfos"`
+	}{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	require.Len(t, decoded.HasExtractedLicensingInfos, 1)
+	assert.Equal(t, NoAssertion, decoded.HasExtractedLicensingInfos[0]["name"])
+	assert.Equal(t, NoAssertion, decoded.HasExtractedLicensingInfos[0]["extractedText"])
+
+	buf.Reset()
+	require.NoError(t, doc.WriteTagValue(buf))
+	out := buf.String()
+	assert.Contains(t, out, "LicenseID: LicenseRef-Custom\nExtractedText: NOASSERTION\nLicenseName: NOASSERTION\n")
+	assert.Contains(t, out, "FilesAnalyzed: true\nPackageVerificationCode: def (excludes: ./package.spdx)\n")
+	assert.Contains(t, out, "PackageLicenseInfoFromFiles: MIT\n")
+	assert.Contains(t, out, "FileName: ./lib/a.go\nSPDXID: SPDXRef-File-vendored-1.0.0-2\nFileChecksum: SHA1: abc\n"+
+		"LicenseConcluded: NOASSERTION\nLicenseInfoInFile: Apache-2.0\nLicenseInfoInFile: LicenseRef-Custom\nFileCopyrightText: NOASSERTION\n")
+
+	// packages whose files were not analyzed keep filesAnalyzed false
+	assert.False(t, New(testPackages(), testOptions()).Packages[0].FilesAnalyzed)
+}
+
+func TestLicenseExpression(t *testing.T) {
+	tests := []struct {
+		in    string
+		out   string
+		valid bool
+	}{
+		{"", NoAssertion, true},
+		{"MIT", "MIT", true},
+		{"(MIT or Apache-2.0) AND BSD-3-Clause", "(MIT OR Apache-2.0) AND BSD-3-Clause", true},
+		{"GPL-2.0-only WITH Classpath-exception-2.0", "GPL-2.0-only WITH Classpath-exception-2.0", true},
+		{"LicenseRef-foo bar", "LicenseRef-foo-bar", true},
+		{"Unlicense/MIT", NoAssertion, false},
+		{"(MIT", NoAssertion, false},
+		{"MIT OR", NoAssertion, false},
+		{"AND", NoAssertion, false},
+		{"MIT WITH", NoAssertion, false},
+		{"MIT Apache-2.0", NoAssertion, false},
+		{"MIT WITH Unknown-exception", NoAssertion, false},
+		{"MIT AND LicenseRef-foo_bar", NoAssertion, false},
+		{"mit OR LicenseRef-foo", "MIT OR LicenseRef-foo", true},
+	}
+
+	for _, tt := range tests {
+		out, valid := LicenseExpression(tt.in)
+		assert.Equal(t, tt.out, out, tt.in)
+		assert.Equal(t, tt.valid, valid, tt.in)
+	}
+}
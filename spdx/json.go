@@ -0,0 +1,17 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package spdx
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// WriteJSON writes the document in the SPDX JSON format
+func (d *Document) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+
+	return enc.Encode(d)
+}
@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package spdx
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/opensbom-generator/parsers/internal/license"
+)
+
+const (
+	licenseRefPrefix  = "LicenseRef-"
+	documentRefPrefix = "DocumentRef-"
+)
+
+// LicenseExpression validates a license value and returns it in a form that
+// can be written to an SPDX document. Empty values become NOASSERTION, invalid
+// ones are reported so the caller can keep the original text as a comment.
+func LicenseExpression(value string) (string, bool) {
+	value = strings.TrimSpace(value)
+	switch value {
+	case "", NoAssertion:
+		return NoAssertion, true
+	case None:
+		return None, true
+	}
+
+	// single license reference, possibly built from a free form name
+	if strings.HasPrefix(value, licenseRefPrefix) && !hasOperator(value) {
+		return licenseRef(value), true
+	}
+
+	e, err := license.Parse(value)
+	if err != nil {
+		return NoAssertion, false
+	}
+	for _, id := range e.Licenses() {
+		if hasRefPrefix(id) && !refPattern.MatchString(id) {
+			return NoAssertion, false
+		}
+	}
+
+	return e.String(), true
+}
+
+// refPattern matches the valid license references
+var refPattern = regexp.MustCompile(`^(?:DocumentRef-[A-Za-z0-9.-]+:)?LicenseRef-[A-Za-z0-9.-]+$`)
+
+func hasRefPrefix(id string) bool {
+	return strings.HasPrefix(id, licenseRefPrefix) || strings.HasPrefix(id, documentRefPrefix)
+}
+
+func hasOperator(value string) bool {
+	if strings.ContainsAny(value, "()") {
+		return true
+	}
+	for _, t := range strings.Fields(value) {
+		if strings.EqualFold(t, "AND") || strings.EqualFold(t, "OR") || strings.EqualFold(t, "WITH") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// listed reports whether id is on the SPDX license list, written as listed
+func listed(id string) bool {
+	l, ok := license.Lookup(id)
+	return ok && l.ID == id
+}
+
+// licenseRef returns the LicenseRef identifier of a license that is not on
+// the SPDX license list
+func licenseRef(id string) string {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return ""
+	}
+	if listed(id) {
+		return ""
+	}
+
+	return licenseRefPrefix + sanitizeID(strings.TrimPrefix(id, licenseRefPrefix))
+}
@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package spdx
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteTagValue writes the document in the SPDX tag-value format
+func (d *Document) WriteTagValue(w io.Writer) error {
+	tv := &tagWriter{w: bufio.NewWriter(w)}
+
+	tv.tag("SPDXVersion", d.SPDXVersion)
+	tv.tag("DataLicense", d.DataLicense)
+	tv.tag("SPDXID", d.SPDXID)
+	tv.tag("DocumentName", d.Name)
+	tv.tag("DocumentNamespace", d.DocumentNamespace)
+	for _, c := range d.CreationInfo.Creators {
+		tv.tag("Creator", c)
+	}
+	tv.tag("Created", d.CreationInfo.Created)
+
+	// the files are written after the package containing them
+	files := map[string][]*File{}
+	ids := map[string]*File{}
+	for i := range d.Files {
+		ids[d.Files[i].SPDXID] = &d.Files[i]
+	}
+	for _, r := range d.Relationships {
+		if f, ok := ids[r.RelatedSPDXElement]; ok && r.RelationshipType == RelationshipContains {
+			files[r.SPDXElementID] = append(files[r.SPDXElementID], f)
+		}
+	}
+
+	for i := range d.Packages {
+		p := &d.Packages[i]
+		tv.line("")
+		tv.line("##### Package: " + p.Name)
+		tv.line("")
+		tv.tag("PackageName", p.Name)
+		tv.tag("SPDXID", p.SPDXID)
+		tv.tag("PackageVersion", p.VersionInfo)
+		tv.tag("PackageSupplier", p.Supplier)
+		tv.tag("PackageDownloadLocation", p.DownloadLocation)
+		tv.tag("FilesAnalyzed", fmt.Sprintf("%t", p.FilesAnalyzed))
+		if code := p.PackageVerificationCode; code != nil {
+			value := code.Value
+			if len(code.ExcludedFiles) > 0 {
+				value += " (excludes: " + strings.Join(code.ExcludedFiles, ", ") + ")"
+			}
+			tv.tag("PackageVerificationCode", value)
+		}
+		for _, c := range p.Checksums {
+			tv.tag("PackageChecksum", c.Algorithm+": "+c.ChecksumValue)
+		}
+		tv.tag("PackageHomePage", p.Homepage)
+		tv.tag("PackageLicenseConcluded", p.LicenseConcluded)
+		for _, l := range p.LicenseInfoFromFiles {
+			tv.tag("PackageLicenseInfoFromFiles", l)
+		}
+		tv.tag("PackageLicenseDeclared", p.LicenseDeclared)
+		tv.text("PackageLicenseComments", p.LicenseComments)
+		tv.text("PackageCopyrightText", p.CopyrightText)
+		tv.text("PackageComment", p.Comment)
+		for _, r := range p.ExternalRefs {
+			tv.tag("ExternalRef", r.ReferenceCategory+" "+r.ReferenceType+" "+r.ReferenceLocator)
+		}
+
+		for _, f := range files[p.SPDXID] {
+			tv.line("")
+			tv.tag("FileName", f.FileName)
+			tv.tag("SPDXID", f.SPDXID)
+			for _, c := range f.Checksums {
+				tv.tag("FileChecksum", c.Algorithm+": "+c.ChecksumValue)
+			}
+			tv.tag("LicenseConcluded", f.LicenseConcluded)
+			for _, l := range f.LicenseInfoInFiles {
+				tv.tag("LicenseInfoInFile", l)
+			}
+			tv.text("FileCopyrightText", f.CopyrightText)
+		}
+	}
+
+	if len(d.Relationships) > 0 {
+		tv.line("")
+	}
+	for _, r := range d.Relationships {
+		tv.tag("Relationship", r.SPDXElementID+" "+r.RelationshipType+" "+r.RelatedSPDXElement)
+	}
+
+	for _, l := range d.HasExtractedLicensingInfos {
+		tv.line("")
+		tv.tag("LicenseID", l.LicenseID)
+		tv.text("ExtractedText", l.ExtractedText)
+		tv.tag("LicenseName", l.Name)
+		tv.text("LicenseComment", l.Comment)
+	}
+
+	if tv.err != nil {
+		return tv.err
+	}
+
+	return tv.w.Flush()
+}
+
+// tagWriter writes tag-value lines and keeps the first error
+type tagWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (t *tagWriter) line(s string) {
+	if t.err != nil {
+		return
+	}
+	_, t.err = t.w.WriteString(s + "\n")
+}
+
+// tag writes a single line value, skipping empty ones
+func (t *tagWriter) tag(name, value string) {
+	if value == "" {
+		return
+	}
+	t.line(name + ": " + value)
+}
+
+// text writes a value that may span multiple lines
+func (t *tagWriter) text(name, value string) {
+	if value == "" {
+		return
+	}
+	if value == NoAssertion || value == None {
+		t.tag(name, value)
+		return
+	}
+	t.line(name + ": <text>" + strings.ReplaceAll(value, "</text>", "&lt;/text&gt;") + "</text>")
+}
@@ -4,9 +4,10 @@ package swift
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"os/exec"
 	"path/filepath"
+	"regexp"
 
 	"github.com/opensbom-generator/parsers/internal/helper"
 	"github.com/opensbom-generator/parsers/meta"
@@ -15,6 +16,7 @@ import (
 
 type Swift struct {
 	metadata plugin.Metadata
+	options  plugin.Options
 }
 
 const (
@@ -22,6 +24,10 @@ const (
 	BuildDirectory string = ".build"
 )
 
+func init() {
+	plugin.Register("swift", func() plugin.Plugin { return New() })
+}
+
 // New creates a new Swift package instance
 func New() *Swift {
 	return &Swift{
@@ -30,14 +36,29 @@ func New() *Swift {
 			Slug:       "swift",
 			Manifest:   []string{ManifestFile},
 			ModulePath: []string{BuildDirectory},
+			Capabilities: plugin.Capabilities{
+				Executables: []plugin.Executable{
+					// on macOS the version of swift-driver is printed first
+					{Name: "swift", Constraint: ">=5.5", VersionPattern: regexp.MustCompile(`Swift version (\d+(?:\.\d+){0,2})`)},
+					{Name: "git", Optional: true},
+				},
+				MutatesWorkspace: true,
+				InstalledModules: true,
+				Lockfiles:        []string{"Package.resolved"},
+			},
 		},
 	}
 }
 
 // GetVersion returns Swift language version
 func (m *Swift) GetVersion() (string, error) {
-	cmd := exec.Command("swift", "--version")
-	output, err := cmd.Output()
+	return m.GetVersionContext(context.Background())
+}
+
+// GetVersionContext returns Swift language version
+func (m *Swift) GetVersionContext(ctx context.Context) (string, error) {
+	ctx = m.options.Context(ctx)
+	output, err := helper.Output(ctx, "", "swift", "--version")
 	if err != nil {
 		return "", err
 	}
@@ -47,6 +68,11 @@ func (m *Swift) GetVersion() (string, error) {
 	return version, nil
 }
 
+// SetOptions configures how modules are listed
+func (m *Swift) SetOptions(opts plugin.Options) {
+	m.options = opts
+}
+
 // GetMetadata returns root package information base on path given
 func (m *Swift) GetMetadata() plugin.Metadata {
 	return m.metadata
@@ -54,14 +80,27 @@ func (m *Swift) GetMetadata() plugin.Metadata {
 
 // SetRootModule sets root package information base on path given
 func (m *Swift) SetRootModule(path string) error {
+	return m.SetRootModuleContext(context.Background(), path)
+}
+
+// SetRootModuleContext sets root package information base on path given
+func (m *Swift) SetRootModuleContext(ctx context.Context, path string) error {
+	ctx = m.options.Context(ctx)
 	return nil
 }
 
 // GetRootModule returns root package information base on path given
 func (m *Swift) GetRootModule(path string) (*meta.Package, error) {
-	cmd := exec.Command("swift", "package", "describe", "--type", "json")
-	cmd.Dir = path
-	output, err := cmd.Output()
+	return m.GetRootModuleContext(context.Background(), path)
+}
+
+// GetRootModuleContext returns root package information base on path given
+func (m *Swift) GetRootModuleContext(ctx context.Context, path string) (*meta.Package, error) {
+	ctx = m.options.Context(ctx)
+	if err := checkWritable(ctx); err != nil {
+		return nil, err
+	}
+	output, err := helper.Output(ctx, path, "swift", "package", "describe", "--type", "json")
 	if err != nil {
 		return nil, err
 	}
@@ -71,7 +110,7 @@ func (m *Swift) GetRootModule(path string) (*meta.Package, error) {
 		return nil, err
 	}
 
-	mod := description.Module()
+	mod := description.Module(ctx)
 
 	return mod, nil
 }
@@ -82,9 +121,20 @@ func (m *Swift) GetRootModule(path string) (*meta.Package, error) {
 // this is a plain list of all used modules
 // (no nested or tree view)
 func (m *Swift) ListUsedModules(path string) ([]meta.Package, error) {
-	cmd := exec.Command("swift", "package", "show-dependencies", "--disable-automatic-resolution", "--format", "json")
-	cmd.Dir = path
-	output, err := cmd.Output()
+	return m.ListUsedModulesContext(context.Background(), path)
+}
+
+// ListUsedModulesContext fetches and lists
+// all packages required by the project
+// in the given project directory,
+// this is a plain list of all used modules
+// (no nested or tree view)
+func (m *Swift) ListUsedModulesContext(ctx context.Context, path string) ([]meta.Package, error) {
+	ctx = m.options.Context(ctx)
+	if err := checkWritable(ctx); err != nil {
+		return nil, err
+	}
+	output, err := helper.Output(ctx, path, "swift", "package", "show-dependencies", "--disable-automatic-resolution", "--format", "json")
 	if err != nil {
 		return nil, err
 	}
@@ -105,13 +155,13 @@ func (m *Swift) ListUsedModules(path string) ([]meta.Package, error) {
 	}
 	recurse(root)
 
-	collection := make([]meta.Package, len(dependencies))
+	collection := make([]meta.Package, 0, len(dependencies))
 	for _, dep := range dependencies {
-		mod := dep.Module()
+		mod := dep.Module(ctx)
 		collection = append(collection, *mod)
 	}
 
-	return collection, nil
+	return m.options.Filter(collection), nil
 }
 
 // ListModulesWithDeps fetches and lists all packages
@@ -121,17 +171,26 @@ func (m *Swift) ListUsedModules(path string) ([]meta.Package, error) {
 // and each with its direct dependency only
 // (similar output to ListUsedModules but with direct dependency only)
 func (m *Swift) ListModulesWithDeps(path string, globalSettingFile string) ([]meta.Package, error) {
+	return m.ListModulesWithDepsContext(context.Background(), path, globalSettingFile)
+}
+
+// ListModulesWithDepsContext fetches and lists all packages
+// (root and direct dependencies)
+// required by the project in the given project directory (side-by-side),
+// this is a one level only list of all used modules,
+// and each with its direct dependency only
+// (similar output to ListUsedModules but with direct dependency only)
+func (m *Swift) ListModulesWithDepsContext(ctx context.Context, path string, globalSettingFile string) ([]meta.Package, error) {
+	ctx = m.options.Context(ctx)
 	var collection []meta.Package //nolint: prealloc
 
-	mod, err := m.GetRootModule(path)
+	mod, err := m.GetRootModuleContext(ctx, path)
 	if err != nil {
 		return nil, err
 	}
 	collection = append(collection, *mod)
 
-	cmd := exec.Command("swift", "package", "show-dependencies", "--disable-automatic-resolution", "--format", "json")
-	cmd.Dir = path
-	output, err := cmd.Output()
+	output, err := helper.Output(ctx, path, "swift", "package", "show-dependencies", "--disable-automatic-resolution", "--format", "json")
 	if err != nil {
 		return nil, err
 	}
@@ -142,7 +201,7 @@ func (m *Swift) ListModulesWithDeps(path string, globalSettingFile string) ([]me
 	}
 
 	for _, dep := range root.Dependencies {
-		mod := dep.Module()
+		mod := dep.Module(ctx)
 		collection = append(collection, *mod)
 	}
 
@@ -158,6 +217,13 @@ func (m *Swift) IsValid(path string) bool {
 // the current project (based on given path)
 // has the dependent packages installed
 func (m *Swift) HasModulesInstalled(path string) error {
+	return m.HasModulesInstalledContext(context.Background(), path)
+}
+
+// HasModulesInstalledContext checks whether
+// the current project (based on given path)
+// has the dependent packages installed
+func (m *Swift) HasModulesInstalledContext(ctx context.Context, path string) error {
 	if helper.Exists(filepath.Join(path, BuildDirectory)) {
 		return nil
 	}
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/opensbom-generator/parsers/plugin"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -62,7 +63,8 @@ func TestListUsedModules(t *testing.T) {
 	for _, mod := range mods {
 		if mod.Name == "DeckOfPlayingCards" {
 			assert.Equal(t, "3.0.4", mod.Version)
-			assert.Equal(t, "https://github.com/apple/example-package-deckofplayingcards", mod.PackageURL)
+			assert.Equal(t, "pkg:swift/github.com/apple/example-package-deckofplayingcards@3.0.4", mod.PackageURL)
+			assert.Equal(t, "https://github.com/apple/example-package-deckofplayingcards", mod.PackageHomePage)
 			assert.Equal(t, "git+https://github.com/apple/example-package-deckofplayingcards.git", mod.PackageDownloadLocation)
 			count++
 			continue
@@ -70,7 +72,8 @@ func TestListUsedModules(t *testing.T) {
 
 		if mod.Name == "FisherYates" {
 			assert.Equal(t, "2.0.6", mod.Version)
-			assert.Equal(t, "https://github.com/apple/example-package-fisheryates", mod.PackageURL)
+			assert.Equal(t, "pkg:swift/github.com/apple/example-package-fisheryates@2.0.6", mod.PackageURL)
+			assert.Equal(t, "https://github.com/apple/example-package-fisheryates", mod.PackageHomePage)
 			assert.Equal(t, "git+https://github.com/apple/example-package-fisheryates.git", mod.PackageDownloadLocation)
 			count++
 			continue
@@ -78,7 +81,8 @@ func TestListUsedModules(t *testing.T) {
 
 		if mod.Name == "PlayingCard" {
 			assert.Equal(t, "3.0.5", mod.Version)
-			assert.Equal(t, "https://github.com/apple/example-package-playingcard", mod.PackageURL)
+			assert.Equal(t, "pkg:swift/github.com/apple/example-package-playingcard@3.0.5", mod.PackageURL)
+			assert.Equal(t, "https://github.com/apple/example-package-playingcard", mod.PackageHomePage)
 			assert.Equal(t, "git+https://github.com/apple/example-package-playingcard.git", mod.PackageDownloadLocation)
 			count++
 			continue
@@ -105,7 +109,8 @@ func TestListModulesWithDeps(t *testing.T) {
 
 		if mod.Name == "DeckOfPlayingCards" {
 			assert.Equal(t, "3.0.4", mod.Version)
-			assert.Equal(t, "https://github.com/apple/example-package-deckofplayingcards", mod.PackageURL)
+			assert.Equal(t, "pkg:swift/github.com/apple/example-package-deckofplayingcards@3.0.4", mod.PackageURL)
+			assert.Equal(t, "https://github.com/apple/example-package-deckofplayingcards", mod.PackageHomePage)
 			assert.Equal(t, "git+https://github.com/apple/example-package-deckofplayingcards.git", mod.PackageDownloadLocation)
 			count++
 			continue
@@ -125,3 +130,15 @@ func getPath() string {
 
 	return path
 }
+
+func TestReadOnly(t *testing.T) {
+	n := New()
+	n.SetOptions(plugin.Options{ReadOnly: true})
+	path := fmt.Sprintf("%s/test", getPath())
+
+	assert.False(t, n.GetMetadata().ReadOnly)
+	_, err := n.GetRootModule(path)
+	assert.ErrorIs(t, err, plugin.ErrReadOnly)
+	_, err = n.ListUsedModules(path)
+	assert.ErrorIs(t, err, plugin.ErrReadOnly)
+}
@@ -4,33 +4,36 @@ package swift
 
 import (
 	"bufio"
-	"fmt"
-	"os/exec"
+	"context"
 	"strings"
 
 	"golang.org/x/mod/semver"
 
 	"github.com/opensbom-generator/parsers/internal/helper"
 	"github.com/opensbom-generator/parsers/meta"
+	"github.com/opensbom-generator/parsers/purl"
 )
 
-func (description PackageDescription) Module() *meta.Package {
+func (description PackageDescription) Module(ctx context.Context) *meta.Package {
 	mod := &meta.Package{}
 
 	mod.Name = description.Name
 	mod.Root = true
 	mod.LocalPath = description.Path
-	_ = setLicense(mod, description.Path)
-	_ = setCheckSum(mod, description.Path)
-	_ = setVersion(mod, description.Path)
+	setLicense(ctx, mod, description.Path)
+	_ = setCheckSum(ctx, mod, description.Path)
+	_ = setVersion(ctx, mod, description.Path)
 
 	return mod
 }
 
-func (dep PackageDependency) Module() *meta.Package {
+func (dep PackageDependency) Module(ctx context.Context) *meta.Package {
 	mod := &meta.Package{}
 	mod.Name = dep.Name
-	mod.PackageURL = strings.TrimSuffix(dep.URL, ".git")
+	mod.PackageURL = purl.Swift(dep.URL, dep.Version)
+	if strings.HasPrefix(dep.URL, "http") {
+		mod.PackageHomePage = strings.TrimSuffix(dep.URL, ".git")
+	}
 
 	if strings.HasSuffix(dep.URL, ".git") {
 		if strings.HasPrefix(dep.URL, "http") ||
@@ -42,34 +45,21 @@ func (dep PackageDependency) Module() *meta.Package {
 
 	mod.Version = dep.Version
 	mod.LocalPath = dep.Path
-	_ = setLicense(mod, dep.Path)
-	_ = setCheckSum(mod, dep.Path)
+	mod.Scope = meta.ScopeRuntime
+	setLicense(ctx, mod, dep.Path)
+	_ = setCheckSum(ctx, mod, dep.Path)
 
 	return mod
 }
 
-func setLicense(mod *meta.Package, path string) error {
-	licensePkg, err := helper.GetLicenses(path)
-	if err != nil {
-		return err
-	}
-
-	mod.LicenseDeclared = helper.BuildLicenseDeclared(licensePkg.ID)
-	mod.LicenseConcluded = helper.BuildLicenseConcluded(licensePkg.ID)
-	if !helper.LicenseSPDXExists(licensePkg.ID) {
-		licensePkg.ID = fmt.Sprintf("LicenseRef-%s", licensePkg.ID)
-		mod.OtherLicense = append(mod.OtherLicense, *licensePkg)
-	}
-	mod.Copyright = helper.GetCopyright(licensePkg.ExtractedText)
-	mod.CommentsLicense = licensePkg.Comments
-
-	return nil
+func setLicense(ctx context.Context, mod *meta.Package, path string) {
+	// Package.swift declares no license, only the license files tell it
+	helper.SetLicenses(ctx, mod, "", path)
+	mod.Copyright = helper.ScanCopyright(path)
 }
 
-func setVersion(mod *meta.Package, path string) error {
-	cmd := exec.Command("git", "describe", "--tags", "--exact-match")
-	cmd.Dir = path
-	output, err := cmd.Output()
+func setVersion(ctx context.Context, mod *meta.Package, path string) error {
+	output, err := helper.Output(ctx, path, "git", "describe", "--tags", "--exact-match")
 	if err != nil {
 		return err
 	}
@@ -92,20 +82,26 @@ func setVersion(mod *meta.Package, path string) error {
 	return nil
 }
 
-func setCheckSum(mod *meta.Package, path string) error {
-	cmd := exec.Command("git", "rev-parse", "HEAD")
-	cmd.Dir = path
-	output, err := cmd.Output()
+func setCheckSum(ctx context.Context, mod *meta.Package, path string) error {
+	output, err := helper.Output(ctx, path, "git", "rev-parse", "HEAD")
 	if err != nil {
 		return err
 	}
 
+	// the commit identifies the sources, it is no digest of an artifact
 	if len(output) > 0 {
-		mod.Checksum = meta.Checksum{
+		mod.AddChecksum(meta.Checksum{
 			Algorithm: meta.HashAlgoSHA1, // FIXME: derive from git
-			Value:     string(output),
-		}
+			Value:     strings.TrimSpace(string(output)),
+			Synthetic: true,
+		})
 	}
 
 	return nil
 }
+
+// checkWritable fails in read-only mode, swift package commands always
+// update the workspace state kept in the build directory of the project
+func checkWritable(ctx context.Context) error {
+	return helper.CheckWritable(ctx, "swift package writes to "+BuildDirectory)
+}
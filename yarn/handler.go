@@ -4,11 +4,10 @@ package yarn
 
 import (
 	"bufio"
-	"crypto/sha256"
+	"context"
 	"errors"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -16,11 +15,13 @@ import (
 	"github.com/opensbom-generator/parsers/internal/helper"
 	"github.com/opensbom-generator/parsers/meta"
 	"github.com/opensbom-generator/parsers/plugin"
+	"github.com/opensbom-generator/parsers/purl"
 	"github.com/opensbom-generator/parsers/reader"
 )
 
 type Yarn struct {
 	metadata plugin.Metadata
+	options  plugin.Options
 }
 
 var (
@@ -29,6 +30,10 @@ var (
 	rg                      = regexp.MustCompile(`^(((git|hg|svn|bzr)\+)?(http:\/\/www\.|https:\/\/www\.|http:\/\/|https:\/\/|ssh:\/\/|git:\/\/|svn:\/\/|sftp:\/\/|ftp:\/\/)?[a-z0-9]+([\-\.]{1}[a-z0-9]+){0,100}\.[a-z]{2,5}(:[0-9]{1,5})?(\/.*))|(git\+git@[a-zA-Z0-9\.]+:[a-zA-Z0-9/\\.@]+)|(bzr\+lp:[a-zA-Z0-9\.]+)$`)
 )
 
+func init() {
+	plugin.Register("yarn", func() plugin.Plugin { return New() })
+}
+
 // New creates a new yarn instance
 func New() *Yarn {
 	return &Yarn{
@@ -37,10 +42,22 @@ func New() *Yarn {
 			Slug:       "yarn",
 			Manifest:   []string{"package.json", lockFile},
 			ModulePath: []string{"node_modules"},
+			ReadOnly:   true,
+			Capabilities: plugin.Capabilities{
+				Executables:      []plugin.Executable{{Name: "yarn", Constraint: "<2", Optional: true}},
+				InstalledModules: true,
+				Lockfiles:        []string{lockFile},
+				TransitiveGraph:  true,
+			},
 		},
 	}
 }
 
+// SetOptions configures how modules are listed
+func (m *Yarn) SetOptions(opts plugin.Options) {
+	m.options = opts
+}
+
 // GetMetadata returns metadata descriptions Name, Slug, Manifest, ModulePath
 func (m *Yarn) GetMetadata() plugin.Metadata {
 	return m.metadata
@@ -59,6 +76,11 @@ func (m *Yarn) IsValid(path string) bool {
 
 // HasModulesInstalled checks if modules of manifest file already installed
 func (m *Yarn) HasModulesInstalled(path string) error {
+	return m.HasModulesInstalledContext(context.Background(), path)
+}
+
+// HasModulesInstalledContext checks if modules of manifest file already installed
+func (m *Yarn) HasModulesInstalledContext(ctx context.Context, path string) error {
 	for _, p := range m.metadata.ModulePath {
 		if !helper.Exists(filepath.Join(path, p)) {
 			return errDependenciesNotFound
@@ -75,8 +97,13 @@ func (m *Yarn) HasModulesInstalled(path string) error {
 
 // GetVersion returns yarn version
 func (m *Yarn) GetVersion() (string, error) {
-	cmd := exec.Command("yarn", "-v")
-	output, err := cmd.Output()
+	return m.GetVersionContext(context.Background())
+}
+
+// GetVersionContext returns yarn version
+func (m *Yarn) GetVersionContext(ctx context.Context) (string, error) {
+	ctx = m.options.Context(ctx)
+	output, err := helper.Output(ctx, "", "yarn", "-v")
 	if err != nil {
 		return "", err
 	}
@@ -90,18 +117,31 @@ func (m *Yarn) GetVersion() (string, error) {
 
 // SetRootModule ...
 func (m *Yarn) SetRootModule(path string) error {
+	return m.SetRootModuleContext(context.Background(), path)
+}
+
+// SetRootModuleContext ...
+func (m *Yarn) SetRootModuleContext(ctx context.Context, path string) error {
+	ctx = m.options.Context(ctx)
 	return nil
 }
 
 // GetRootModule return
 // root package information ex. Name, Version
 func (m *Yarn) GetRootModule(path string) (*meta.Package, error) {
+	return m.GetRootModuleContext(context.Background(), path)
+}
+
+// GetRootModuleContext return
+// root package information ex. Name, Version
+func (m *Yarn) GetRootModuleContext(ctx context.Context, path string) (*meta.Package, error) {
+	ctx = m.options.Context(ctx)
 	r := reader.New(filepath.Join(path, m.metadata.Manifest[0]))
 	pkResult, err := r.ReadJSON()
 	if err != nil {
 		return &meta.Package{}, err
 	}
-	mod := &meta.Package{}
+	mod := &meta.Package{Root: true}
 
 	if pkResult["name"] != nil {
 		mod.Name = pkResult["name"].(string)
@@ -122,29 +162,27 @@ func (m *Yarn) GetRootModule(path string) (*meta.Package, error) {
 		}
 	}
 	if pkResult["homepage"] != nil {
-		mod.PackageURL = helper.RemoveURLProtocol(pkResult["homepage"].(string))
-		mod.PackageDownloadLocation = mod.PackageURL
+		mod.PackageHomePage = helper.RemoveURLProtocol(pkResult["homepage"].(string))
+		mod.PackageDownloadLocation = mod.PackageHomePage
 	}
+	mod.PackageURL = purl.NPM(mod.Name, mod.Version)
 	if !rg.MatchString(mod.PackageDownloadLocation) {
 		mod.PackageDownloadLocation = "NONE"
 	}
 	mod.Packages = map[string]*meta.Package{}
-	mod.Copyright = getCopyright(path)
-	modLic, err := helper.GetLicenses(path)
-	if err != nil {
-		return mod, nil
-	}
-	mod.LicenseDeclared = helper.BuildLicenseDeclared(modLic.ID)
-	mod.LicenseConcluded = helper.BuildLicenseConcluded(modLic.ID)
-	mod.CommentsLicense = modLic.Comments
-	if !helper.LicenseSPDXExists(modLic.ID) {
-		mod.OtherLicense = append(mod.OtherLicense, *modLic)
-	}
+	mod.Copyright = helper.ScanCopyright(path)
+	helper.SetLicenses(ctx, mod, helper.PackageJSONLicense(pkResult), path)
 	return mod, nil
 }
 
 // ListUsedModules return brief info of installed modules, Name and Version
 func (m *Yarn) ListUsedModules(path string) ([]meta.Package, error) {
+	return m.ListUsedModulesContext(context.Background(), path)
+}
+
+// ListUsedModulesContext return brief info of installed modules, Name and Version
+func (m *Yarn) ListUsedModulesContext(ctx context.Context, path string) ([]meta.Package, error) {
+	ctx = m.options.Context(ctx)
 	r := reader.New(filepath.Join(path, m.metadata.Manifest[0]))
 	pkResult, err := r.ReadJSON()
 	if err != nil {
@@ -159,32 +197,98 @@ func (m *Yarn) ListUsedModules(path string) ([]meta.Package, error) {
 		mod.Version = strings.TrimPrefix(v.(string), "^")
 		modules = append(modules, mod)
 	}
+	meta.Sort(modules)
 
 	return modules, nil
 }
 
 // ListModulesWithDeps return all info of installed modules
 func (m *Yarn) ListModulesWithDeps(path string, globalSettingFile string) ([]meta.Package, error) {
+	return m.ListModulesWithDepsContext(context.Background(), path, globalSettingFile)
+}
+
+// ListModulesWithDepsContext return all info of installed modules
+func (m *Yarn) ListModulesWithDepsContext(ctx context.Context, path string, globalSettingFile string) ([]meta.Package, error) {
+	ctx = m.options.Context(ctx)
 	deps, err := readLockFile(filepath.Join(path, lockFile))
 	allDeps := appendNestedDependencies(deps)
 	if err != nil {
 		return nil, err
 	}
 
-	return m.buildDependencies(path, allDeps)
+	modules, err := m.buildDependencies(ctx, path, allDeps)
+	if err != nil {
+		return nil, err
+	}
+	m.setScopes(path, modules)
+
+	return m.options.Filter(modules), nil
+}
+
+// setScopes sets the scope of every module from the dependency sections of
+// the manifest. The root module lists every locked package, so only the
+// packages declared in the manifest are used as starting points.
+func (m *Yarn) setScopes(path string, modules []meta.Package) {
+	direct := manifestScopes(filepath.Join(path, m.metadata.Manifest[0]))
+
+	view := make([]meta.Package, len(modules))
+	copy(view, modules)
+	view[0].Packages = map[string]*meta.Package{}
+	for name, dep := range modules[0].Packages {
+		if scope, ok := direct[name]; ok {
+			view[0].Packages[name] = &meta.Package{Name: dep.Name, Version: dep.Version, Scope: scope}
+		}
+	}
+	meta.ResolveScopes(view)
+
+	scopes := map[string]meta.Scope{}
+	for i := 1; i < len(modules); i++ {
+		modules[i].Scope = view[i].Scope
+		scopes[modules[i].Name+"@"+modules[i].Version] = view[i].Scope
+	}
+	for _, dep := range modules[0].Packages {
+		dep.Scope = scopes[dep.Name+"@"+dep.Version]
+	}
+}
+
+// manifestScopes returns the scope of the dependencies declared in package.json
+func manifestScopes(manifest string) map[string]meta.Scope {
+	scopes := map[string]meta.Scope{}
+	pkResult, err := reader.New(manifest).ReadJSON()
+	if err != nil {
+		return scopes
+	}
+
+	sections := []struct {
+		name  string
+		scope meta.Scope
+	}{
+		{"peerDependencies", meta.ScopePeer},
+		{"optionalDependencies", meta.ScopeOptional},
+		{"devDependencies", meta.ScopeDevelopment},
+		{"dependencies", meta.ScopeRuntime},
+	}
+	for _, section := range sections {
+		deps, ok := pkResult[section.name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for name := range deps {
+			scopes[strings.TrimPrefix(name, "@")] = section.scope
+		}
+	}
+
+	return scopes
 }
 
-func (m *Yarn) buildDependencies(path string, deps []dependency) ([]meta.Package, error) {
+func (m *Yarn) buildDependencies(ctx context.Context, path string, deps []dependency) ([]meta.Package, error) {
 	modules := make([]meta.Package, 0)
-	de, err := m.GetRootModule(path)
+	de, err := m.GetRootModuleContext(ctx, path)
 	if err != nil {
 		return modules, err
 	}
-	h := fmt.Sprintf("%x", sha256.Sum256([]byte(fmt.Sprintf("%s-%s", de.Name, de.Version))))
-	de.Checksum = meta.Checksum{
-		Algorithm: "SHA256",
-		Value:     h,
-	}
+	// the project itself has no artifact to take a digest of
+	de.AddChecksum(meta.SyntheticChecksum(fmt.Sprintf("%s-%s", de.Name, de.Version)))
 	de.Supplier.Name = de.Name
 	if de.PackageDownloadLocation == "" {
 		de.PackageDownloadLocation = de.Name
@@ -194,10 +298,18 @@ func (m *Yarn) buildDependencies(path string, deps []dependency) ([]meta.Package
 		var mod meta.Package
 		mod.Name = d.Name
 		mod.Version = extractVersion(d.Version)
+		checksums := lockChecksums(d)
+		if len(checksums) == 0 {
+			checksums = append(checksums, meta.SyntheticChecksum(mod.Name))
+		}
 		modules[0].Packages[d.Name] = &meta.Package{
-			Name:     d.Name,
-			Version:  mod.Version,
-			Checksum: meta.Checksum{Content: []byte(fmt.Sprintf("%s-%s", d.Name, mod.Version))},
+			Name:       d.Name,
+			Version:    mod.Version,
+			PackageURL: purl.NPM(d.PkPath, mod.Version),
+		}
+		for _, c := range checksums {
+			mod.AddChecksum(c)
+			modules[0].Packages[d.Name].AddChecksum(c)
 		}
 		if len(d.Dependencies) != 0 {
 			mod.Packages = map[string]*meta.Package{}
@@ -213,10 +325,11 @@ func (m *Yarn) buildDependencies(path string, deps []dependency) ([]meta.Package
 					continue
 				}
 				mod.Packages[name] = &meta.Package{
-					Name:     name,
-					Version:  extractVersion(version),
-					Checksum: meta.Checksum{Content: []byte(fmt.Sprintf("%s-%s", name, version))},
+					Name:       name,
+					Version:    extractVersion(version),
+					PackageURL: purl.NPM(strings.Trim(ar[0], "\""), extractVersion(version)),
 				}
+				mod.Packages[name].AddChecksum(meta.SyntheticChecksum(fmt.Sprintf("%s-%s", name, version)))
 			}
 		}
 		r := strings.TrimSuffix(strings.TrimPrefix(d.Resolved, "\""), "\"")
@@ -230,35 +343,33 @@ func (m *Yarn) buildDependencies(path string, deps []dependency) ([]meta.Package
 		}
 		mod.Supplier.Name = mod.Name
 
-		mod.PackageURL = getPackageHomepage(filepath.Join(path, m.metadata.ModulePath[0], d.PkPath, m.metadata.Manifest[0]))
-		h := fmt.Sprintf("%x", sha256.Sum256([]byte(mod.Name)))
-		mod.Checksum = meta.Checksum{
-			Algorithm: "SHA256",
-			Value:     h,
-		}
-		licensePath := filepath.Join(path, m.metadata.ModulePath[0], d.PkPath, "LICENSE")
-		if helper.Exists(licensePath) {
-			r := reader.New(licensePath)
-			s := r.StringFromFile()
-			mod.Copyright = helper.GetCopyright(s)
-		}
-
-		modLic, err := helper.GetLicenses(filepath.Join(path, m.metadata.ModulePath[0], d.PkPath))
-		if err != nil {
-			modules = append(modules, mod)
-			continue
-		}
-		mod.LicenseDeclared = helper.BuildLicenseDeclared(modLic.ID)
-		mod.LicenseConcluded = helper.BuildLicenseConcluded(modLic.ID)
-		mod.CommentsLicense = modLic.Comments
-		if !helper.LicenseSPDXExists(modLic.ID) {
-			mod.OtherLicense = append(mod.OtherLicense, *modLic)
-		}
+		mod.PackageURL = purl.NPM(d.PkPath, mod.Version)
+		mod.PackageHomePage = getPackageHomepage(filepath.Join(path, m.metadata.ModulePath[0], d.PkPath, m.metadata.Manifest[0]))
+		modPath := filepath.Join(path, m.metadata.ModulePath[0], d.PkPath)
+		mod.Copyright = helper.ScanCopyright(modPath)
+		helper.SetLicenses(ctx, &mod, getPackageLicense(filepath.Join(modPath, m.metadata.Manifest[0])), modPath)
 		modules = append(modules, mod)
 	}
 	return modules, nil
 }
 
+// lockChecksums returns the digests the lock file records for d: its
+// integrity and the SHA1 of the tarball yarn appends to the resolved URL
+func lockChecksums(d dependency) []meta.Checksum {
+	checksums := []meta.Checksum{}
+	if sri, err := meta.ParseSRI(strings.Trim(d.Integrity, "\"")); err == nil {
+		checksums = append(checksums, sri...)
+	}
+	resolved := strings.Trim(d.Resolved, "\"")
+	if i := strings.LastIndex(resolved, "#"); i > 0 {
+		if c, err := meta.NewChecksum(meta.HashAlgoSHA1, resolved[i+1:]); err == nil && len(c.Value) == 40 {
+			checksums = append(checksums, c)
+		}
+	}
+
+	return checksums
+}
+
 func readLockFile(path string) ([]dependency, error) {
 	file, err := os.Open(path)
 	if err != nil {
@@ -336,37 +447,25 @@ func readLockFile(path string) ([]dependency, error) {
 	return p, nil
 }
 
-func getCopyright(path string) string {
-	licensePath := filepath.Join(path, "LICENSE")
-	if helper.Exists(licensePath) {
-		r := reader.New(licensePath)
-		s := r.StringFromFile()
-		return helper.GetCopyright(s)
-	}
-
-	licenseMDPath, err := filepath.Glob(filepath.Join(path, "LICENSE*"))
+func getPackageHomepage(path string) string {
+	r := reader.New(path)
+	pkResult, err := r.ReadJSON()
 	if err != nil {
 		return ""
 	}
-	if len(licenseMDPath) > 0 && helper.Exists(licenseMDPath[0]) {
-		r := reader.New(licenseMDPath[0])
-		s := r.StringFromFile()
-		return helper.GetCopyright(s)
+	if pkResult["homepage"] != nil {
+		return helper.RemoveURLProtocol(pkResult["homepage"].(string))
 	}
-
 	return ""
 }
 
-func getPackageHomepage(path string) string {
+func getPackageLicense(path string) string {
 	r := reader.New(path)
 	pkResult, err := r.ReadJSON()
 	if err != nil {
 		return ""
 	}
-	if pkResult["homepage"] != nil {
-		return helper.RemoveURLProtocol(pkResult["homepage"].(string))
-	}
-	return ""
+	return helper.PackageJSONLicense(pkResult)
 }
 
 func extractVersion(s string) string {
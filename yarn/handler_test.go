@@ -3,7 +3,6 @@
 package yarn
 
 import (
-	"crypto/sha256"
 	"fmt"
 	"os/exec"
 	"strings"
@@ -98,35 +97,30 @@ func TestListAllModules(t *testing.T) {
 	count := 0
 	for _, mod := range mods {
 		if mod.Name == "axios" {
-			h := fmt.Sprintf("%x", sha256.Sum256([]byte(mod.Name)))
 			assert.Equal(t, "0.19.2", mod.Version)
 			assert.Equal(t, "https://registry.yarnpkg.com/axios/-/axios-0.19.2.tgz", mod.PackageDownloadLocation)
-			assert.Equal(t, meta.HashAlgorithm("SHA256"), mod.Checksum.Algorithm)
-			assert.Equal(t, h, mod.Checksum.Value)
+			assert.Equal(t, meta.HashAlgoSHA512, mod.Checksum.Algorithm)
+			assert.False(t, mod.Checksum.Synthetic)
 			assert.Equal(t, "Copyright (c) 2014-present Matt Zabriskie", mod.Copyright)
 			assert.Equal(t, "MIT", mod.LicenseDeclared)
 			count++
 			continue
 		}
 		if mod.Name == "react" {
-			h := fmt.Sprintf("%x", sha256.Sum256([]byte(mod.Name)))
-
 			assert.Equal(t, "16.14.0", mod.Version)
 			assert.Equal(t, "https://registry.yarnpkg.com/react/-/react-16.14.0.tgz", mod.PackageDownloadLocation)
-			assert.Equal(t, meta.HashAlgorithm("SHA256"), mod.Checksum.Algorithm)
-			assert.Equal(t, h, mod.Checksum.Value)
+			assert.Equal(t, meta.HashAlgoSHA512, mod.Checksum.Algorithm)
+			assert.False(t, mod.Checksum.Synthetic)
 			assert.Equal(t, "Copyright (c) Facebook, Inc. and its affiliates.", mod.Copyright)
 			assert.Equal(t, "MIT", mod.LicenseDeclared)
 			count++
 			continue
 		}
 		if mod.Name == "react-dom" {
-			h := fmt.Sprintf("%x", sha256.Sum256([]byte(mod.Name)))
-
 			assert.Equal(t, "16.14.0", mod.Version)
 			assert.Equal(t, "https://registry.yarnpkg.com/react-dom/-/react-dom-16.14.0.tgz", mod.PackageDownloadLocation)
-			assert.Equal(t, meta.HashAlgorithm("SHA256"), mod.Checksum.Algorithm)
-			assert.Equal(t, h, mod.Checksum.Value)
+			assert.Equal(t, meta.HashAlgoSHA512, mod.Checksum.Algorithm)
+			assert.False(t, mod.Checksum.Synthetic)
 			assert.Equal(t, "Copyright (c) Facebook, Inc. and its affiliates.", mod.Copyright)
 			assert.Equal(t, "MIT", mod.LicenseDeclared)
 			count++
@@ -147,3 +141,22 @@ func getPath() string {
 
 	return path
 }
+
+func TestManifestScopes(t *testing.T) {
+	scopes := manifestScopes(fmt.Sprintf("%s/test/package.json", getPath()))
+
+	assert.Equal(t, meta.ScopeRuntime, scopes["axios"])
+	assert.Equal(t, meta.ScopeDevelopment, scopes["netlify-lambda"])
+}
+
+func TestLockChecksums(t *testing.T) {
+	checksums := lockChecksums(dependency{
+		Resolved:  `"https://registry.yarnpkg.com/axios/-/axios-0.19.2.tgz#e1f9a9461034a6820e64f496c9eaa1b80175bc3e"`,
+		Integrity: "sha512-USbRo9X++6NaJ7ziWEFy71E/kshc0bdgFFse4nWQNGYo8S9j0bwxIef7+Po4FDzvkIvQI/DrgMWp5lZ5NL8Y7w==",
+	})
+
+	assert.Equal(t, []meta.Checksum{
+		{Algorithm: meta.HashAlgoSHA512, Value: "5126d1a3d5fefba35a27bce2584172ef513f92c85cd1b760145b1ee27590346628f12f63d1bc3121e7fbf8fa38143cef908bd023f0eb80c5a9e6567934bf18ef"},
+		{Algorithm: meta.HashAlgoSHA1, Value: "e1f9a9461034a6820e64f496c9eaa1b80175bc3e"},
+	}, checksums)
+}